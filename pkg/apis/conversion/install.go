@@ -18,5 +18,15 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.Test)(nil), (*v1alpha1.Test)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_Test_To_v1alpha1_Test(a.(*v1alpha2.Test), b.(*v1alpha1.Test), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.Test)(nil), (*v1alpha2.Test)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Test_To_v1alpha2_Test(a.(*v1alpha1.Test), b.(*v1alpha2.Test), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
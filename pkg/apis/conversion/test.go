@@ -0,0 +1,277 @@
+package conversion
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/utils/ptr"
+)
+
+func Convert_v1alpha2_TestSpec_To_v1alpha1_TestSpec(in *v1alpha2.TestSpec, out *v1alpha1.TestSpec, s conversion.Scope) error {
+	// options keeping their default value are left unset so that the configuration still applies
+	if in.Cleanup.SkipDelete {
+		out.SkipDelete = ptr.To(true)
+	}
+	out.DelayBeforeCleanup = in.Cleanup.DelayBeforeCleanup
+	if in.Cluster != "" {
+		out.Cluster = ptr.To(in.Cluster)
+	}
+	out.Clusters = in.Clusters
+	out.Concurrent = ptr.To(in.Execution.Concurrent)
+	out.DependsOn = in.Execution.DependsOn
+	if in.Execution.Skip {
+		out.Skip = ptr.To(true)
+	}
+	out.ForceTerminationGracePeriod = in.Execution.TerminationGracePeriod
+	out.Retry = in.Execution.Retry
+	out.Bindings = in.Bindings
+	if in.Deletion.Propagation != "" && in.Deletion.Propagation != metav1.DeletePropagationBackground {
+		out.DeletionPropagationPolicy = ptr.To(in.Deletion.Propagation)
+	}
+	out.Description = in.Description
+	out.Catch = in.Error.Catch
+	out.Namespace = in.Namespace.Name
+	out.NamespaceTemplate = in.Namespace.Template
+	if !in.Templating.Enabled {
+		out.Template = ptr.To(false)
+	}
+	if in.Timeouts != (v1alpha2.Timeouts{}) {
+		out.Timeouts = ptr.To(in.Timeouts)
+	}
+	out.Steps = nil
+	for i := range in.Steps {
+		var step v1alpha1.TestStep
+		if err := Convert_v1alpha2_TestStep_To_v1alpha1_TestStep(&in.Steps[i], &step, s); err != nil {
+			return fmt.Errorf("failed to convert step %d: %w", i+1, err)
+		}
+		out.Steps = append(out.Steps, step)
+	}
+	return nil
+}
+
+func Convert_v1alpha1_TestSpec_To_v1alpha2_TestSpec(in *v1alpha1.TestSpec, out *v1alpha2.TestSpec, s conversion.Scope) error {
+	if in.FailFast != nil {
+		return errors.New("failFast is not supported in v1alpha2")
+	}
+	if len(in.Scenarios) != 0 {
+		return errors.New("scenarios are not supported in v1alpha2")
+	}
+	out.Cleanup = v1alpha2.CleanupOptions{
+		SkipDelete:         ptr.Deref(in.SkipDelete, false),
+		DelayBeforeCleanup: in.DelayBeforeCleanup,
+	}
+	out.Cluster = ptr.Deref(in.Cluster, "")
+	out.Clusters = in.Clusters
+	out.Execution = v1alpha2.TestExecutionOptions{
+		Concurrent:             ptr.Deref(in.Concurrent, true),
+		DependsOn:              in.DependsOn,
+		Skip:                   ptr.Deref(in.Skip, false),
+		TerminationGracePeriod: in.ForceTerminationGracePeriod,
+		Retry:                  in.Retry,
+	}
+	out.Bindings = in.Bindings
+	out.Deletion = v1alpha2.DeletionOptions{
+		Propagation: ptr.Deref(in.DeletionPropagationPolicy, ""),
+	}
+	out.Description = in.Description
+	out.Error = v1alpha2.ErrorOptions{
+		Catch: in.Catch,
+	}
+	out.Namespace = v1alpha2.NamespaceOptions{
+		Name:     in.Namespace,
+		Template: in.NamespaceTemplate,
+	}
+	out.Templating = v1alpha2.TemplatingOptions{
+		Enabled: ptr.Deref(in.Template, true),
+	}
+	out.Timeouts = ptr.Deref(in.Timeouts, v1alpha2.Timeouts{})
+	out.Steps = nil
+	for i := range in.Steps {
+		var step v1alpha2.TestStep
+		if err := Convert_v1alpha1_TestStep_To_v1alpha2_TestStep(&in.Steps[i], &step, s); err != nil {
+			return fmt.Errorf("failed to convert step %d: %w", i+1, err)
+		}
+		out.Steps = append(out.Steps, step)
+	}
+	return nil
+}
+
+func Convert_v1alpha2_TestStep_To_v1alpha1_TestStep(in *v1alpha2.TestStep, out *v1alpha1.TestStep, _ conversion.Scope) error {
+	out.Name = in.Name
+	out.Description = in.Description
+	out.Timeouts = in.Timeouts
+	out.DeletionPropagationPolicy = in.DeletionPropagationPolicy
+	out.Cluster = nil
+	if in.Cluster != "" {
+		out.Cluster = ptr.To(in.Cluster)
+	}
+	out.Clusters = in.Clusters
+	out.SkipDelete = in.SkipDelete
+	out.Template = in.Template
+	out.Bindings = in.Bindings
+	out.Try = make([]v1alpha1.Operation, len(in.Try))
+	for i := range in.Try {
+		if err := convertOperation(&in.Try[i], &out.Try[i], false); err != nil {
+			return fmt.Errorf("failed to convert try operation %d: %w", i+1, err)
+		}
+	}
+	var err error
+	if out.Catch, err = convertCatchFinally("catch", in.Catch); err != nil {
+		return err
+	}
+	if out.Finally, err = convertCatchFinally("finally", in.Finally); err != nil {
+		return err
+	}
+	if out.Cleanup, err = convertCatchFinally("cleanup", in.Cleanup); err != nil {
+		return err
+	}
+	out.Outputs = in.Outputs
+	return nil
+}
+
+func Convert_v1alpha1_TestStep_To_v1alpha2_TestStep(in *v1alpha1.TestStep, out *v1alpha2.TestStep, _ conversion.Scope) error {
+	if in.Use != nil {
+		return errors.New("step templates are not supported in v1alpha2")
+	}
+	if in.WorkDir != "" {
+		return errors.New("workDir is not supported in v1alpha2")
+	}
+	out.Name = in.Name
+	out.Description = in.Description
+	out.Timeouts = in.Timeouts
+	out.DeletionPropagationPolicy = in.DeletionPropagationPolicy
+	out.Cluster = ptr.Deref(in.Cluster, "")
+	out.Clusters = in.Clusters
+	out.SkipDelete = in.SkipDelete
+	out.Template = in.Template
+	out.Bindings = in.Bindings
+	out.Try = make([]v1alpha2.TryOperation, len(in.Try))
+	for i := range in.Try {
+		if err := convertOperation(&in.Try[i], &out.Try[i], true); err != nil {
+			return fmt.Errorf("failed to convert try operation %d: %w", i+1, err)
+		}
+	}
+	var err error
+	if out.Catch, err = convertOperations("catch", in.Catch); err != nil {
+		return err
+	}
+	if out.Finally, err = convertOperations("finally", in.Finally); err != nil {
+		return err
+	}
+	if out.Cleanup, err = convertOperations("cleanup", in.Cleanup); err != nil {
+		return err
+	}
+	out.Outputs = in.Outputs
+	return nil
+}
+
+func autoConvert_v1alpha2_Test_To_v1alpha1_Test(in *v1alpha2.Test, out *v1alpha1.Test, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha2_TestSpec_To_v1alpha1_TestSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_Test_To_v1alpha1_Test is an autogenerated conversion function.
+func Convert_v1alpha2_Test_To_v1alpha1_Test(in *v1alpha2.Test, out *v1alpha1.Test, s conversion.Scope) error {
+	return autoConvert_v1alpha2_Test_To_v1alpha1_Test(in, out, s)
+}
+
+func autoConvert_v1alpha1_Test_To_v1alpha2_Test(in *v1alpha1.Test, out *v1alpha2.Test, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_TestSpec_To_v1alpha2_TestSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_Test_To_v1alpha2_Test is an autogenerated conversion function.
+func Convert_v1alpha1_Test_To_v1alpha2_Test(in *v1alpha1.Test, out *v1alpha2.Test, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Test_To_v1alpha2_Test(in, out, s)
+}
+
+func convertCatchFinally(name string, in []v1alpha2.Operation) ([]v1alpha1.CatchFinally, error) {
+	if in == nil {
+		return nil, nil
+	}
+	out := make([]v1alpha1.CatchFinally, len(in))
+	for i := range in {
+		if err := convertOperation(&in[i], &out[i], false); err != nil {
+			return nil, fmt.Errorf("failed to convert %s operation %d: %w", name, i+1, err)
+		}
+	}
+	return out, nil
+}
+
+func convertOperations(name string, in []v1alpha1.CatchFinally) ([]v1alpha2.Operation, error) {
+	if in == nil {
+		return nil, nil
+	}
+	out := make([]v1alpha2.Operation, len(in))
+	for i := range in {
+		if err := convertOperation(&in[i], &out[i], true); err != nil {
+			return nil, fmt.Errorf("failed to convert %s operation %d: %w", name, i+1, err)
+		}
+	}
+	return out, nil
+}
+
+// operationFields are the fields v1alpha2 declares at the operation level
+// and v1alpha1 declares on every action.
+var operationFields = []string{"bindings", "cluster", "clusters", "outputs"}
+
+// convertOperation converts an operation between versions.
+// Actions share the same serialized form in both versions, only the fields in operationFields
+// are moved between the operation (lift is true) and its action (lift is false).
+// Fields that don't exist in the target version make the conversion fail.
+func convertOperation(in any, out any, lift bool) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	var operation map[string]any
+	if err := json.Unmarshal(data, &operation); err != nil {
+		return err
+	}
+	var actions []string
+	for key, value := range operation {
+		if _, ok := value.(map[string]any); ok && !slices.Contains(operationFields, key) {
+			actions = append(actions, key)
+		}
+	}
+	if len(actions) != 1 {
+		return fmt.Errorf("operation must define exactly one action, found %d", len(actions))
+	}
+	action := operation[actions[0]].(map[string]any)
+	for _, field := range operationFields {
+		if lift {
+			if value, ok := action[field]; ok {
+				operation[field] = value
+				delete(action, field)
+			}
+		} else {
+			if value, ok := operation[field]; ok {
+				action[field] = value
+				delete(operation, field)
+			}
+		}
+	}
+	data, err = json.Marshal(operation)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(out); err != nil {
+		return fmt.Errorf("failed to convert %s action: %w", actions[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package conversion
+
+import (
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestConvert_v1alpha2_Test_To_v1alpha1_Test(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      v1alpha2.Test
+		want    v1alpha1.Test
+		wantErr string
+	}{{
+		name: "operations",
+		in: v1alpha2.Test{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: v1alpha2.TestSpec{
+				Execution:  v1alpha2.TestExecutionOptions{Concurrent: true},
+				Templating: v1alpha2.TemplatingOptions{Enabled: true},
+				Steps: []v1alpha2.TestStep{{
+					Name: "step",
+					TestStepSpec: v1alpha2.TestStepSpec{
+						Try: []v1alpha2.TryOperation{{
+							Operation: v1alpha2.Operation{
+								OperationAction: v1alpha2.OperationAction{
+									Script: &v1alpha2.Script{Content: "echo"},
+								},
+								OperationClusters: v1alpha2.OperationClusters{
+									Cluster:  "foo",
+									Clusters: v1alpha2.Clusters{"foo": {Kubeconfig: "kubeconfig"}},
+								},
+								OperationOutputs: v1alpha2.OperationOutputs{
+									Outputs: []v1alpha2.Output{{Binding: v1alpha1.Binding{Name: "out", Value: v1alpha1.Any{Value: "($stdout)"}}}},
+								},
+							},
+							ContinueOnError: ptr.To(true),
+						}},
+						Finally: []v1alpha2.Operation{{
+							OperationAction: v1alpha2.OperationAction{
+								Sleep: &v1alpha2.Sleep{Duration: metav1.Duration{Duration: 1}},
+							},
+						}},
+					},
+				}},
+			},
+		},
+		want: v1alpha1.Test{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: v1alpha1.TestSpec{
+				Concurrent: ptr.To(true),
+				Steps: []v1alpha1.TestStep{{
+					Name: "step",
+					TestStepSpec: v1alpha1.TestStepSpec{
+						Try: []v1alpha1.Operation{{
+							OperationBase: v1alpha1.OperationBase{ContinueOnError: ptr.To(true)},
+							Script: &v1alpha1.Script{
+								ActionClusters: v1alpha1.ActionClusters{
+									Cluster:  ptr.To("foo"),
+									Clusters: v1alpha1.Clusters{"foo": {Kubeconfig: "kubeconfig"}},
+								},
+								ActionOutputs: v1alpha1.ActionOutputs{
+									Outputs: []v1alpha1.Output{{Binding: v1alpha1.Binding{Name: "out", Value: v1alpha1.Any{Value: "($stdout)"}}}},
+								},
+								Content: "echo",
+							},
+						}},
+						Finally: []v1alpha1.CatchFinally{{
+							Sleep: &v1alpha1.Sleep{Duration: metav1.Duration{Duration: 1}},
+						}},
+					},
+				}},
+			},
+		},
+	}, {
+		name: "default deletion propagation",
+		in: v1alpha2.Test{
+			Spec: v1alpha2.TestSpec{
+				Deletion:   v1alpha2.DeletionOptions{Propagation: metav1.DeletePropagationBackground},
+				Execution:  v1alpha2.TestExecutionOptions{Concurrent: true},
+				Templating: v1alpha2.TemplatingOptions{Enabled: true},
+			},
+		},
+		want: v1alpha1.Test{
+			Spec: v1alpha1.TestSpec{
+				Concurrent: ptr.To(true),
+			},
+		},
+	}, {
+		name: "deletion propagation",
+		in: v1alpha2.Test{
+			Spec: v1alpha2.TestSpec{
+				Deletion:   v1alpha2.DeletionOptions{Propagation: metav1.DeletePropagationForeground},
+				Execution:  v1alpha2.TestExecutionOptions{Concurrent: true},
+				Templating: v1alpha2.TemplatingOptions{Enabled: true},
+			},
+		},
+		want: v1alpha1.Test{
+			Spec: v1alpha1.TestSpec{
+				Concurrent:                ptr.To(true),
+				DeletionPropagationPolicy: ptr.To(metav1.DeletePropagationForeground),
+			},
+		},
+	}, {
+		name: "unsupported catch operation",
+		in: v1alpha2.Test{
+			Spec: v1alpha2.TestSpec{
+				Steps: []v1alpha2.TestStep{{
+					TestStepSpec: v1alpha2.TestStepSpec{
+						Catch: []v1alpha2.Operation{{
+							OperationAction: v1alpha2.OperationAction{
+								Apply: &v1alpha2.Apply{},
+							},
+						}},
+					},
+				}},
+			},
+		},
+		wantErr: `failed to convert step 1: failed to convert catch operation 1: failed to convert apply action: json: unknown field "apply"`,
+	}, {
+		name: "unsupported operation field",
+		in: v1alpha2.Test{
+			Spec: v1alpha2.TestSpec{
+				Steps: []v1alpha2.TestStep{{
+					TestStepSpec: v1alpha2.TestStepSpec{
+						Try: []v1alpha2.TryOperation{{
+							Operation: v1alpha2.Operation{
+								OperationAction: v1alpha2.OperationAction{
+									Sleep: &v1alpha2.Sleep{},
+								},
+								OperationClusters: v1alpha2.OperationClusters{
+									Cluster: "foo",
+								},
+							},
+						}},
+					},
+				}},
+			},
+		},
+		wantErr: `failed to convert step 1: failed to convert try operation 1: failed to convert sleep action: json: unknown field "cluster"`,
+	}, {
+		name: "no action",
+		in: v1alpha2.Test{
+			Spec: v1alpha2.TestSpec{
+				Steps: []v1alpha2.TestStep{{
+					TestStepSpec: v1alpha2.TestStepSpec{
+						Try: []v1alpha2.TryOperation{{}},
+					},
+				}},
+			},
+		},
+		wantErr: "failed to convert step 1: failed to convert try operation 1: operation must define exactly one action, found 0",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got v1alpha1.Test
+			err := Convert_v1alpha2_Test_To_v1alpha1_Test(&tt.in, &got, nil)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConvert_v1alpha1_Test_To_v1alpha2_Test(t *testing.T) {
+	in := v1alpha1.Test{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: v1alpha1.TestSpec{
+			Cluster: ptr.To("foo"),
+			Steps: []v1alpha1.TestStep{{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Try: []v1alpha1.Operation{{
+						Apply: &v1alpha1.Apply{
+							ActionBindings: v1alpha1.ActionBindings{
+								Bindings: []v1alpha1.Binding{{Name: "foo", Value: v1alpha1.Any{Value: "bar"}}},
+							},
+							ActionResourceRef: v1alpha1.ActionResourceRef{
+								FileRef: v1alpha1.FileRef{File: "foo.yaml"},
+							},
+						},
+					}},
+				},
+			}},
+		},
+	}
+	var got v1alpha2.Test
+	assert.NoError(t, Convert_v1alpha1_Test_To_v1alpha2_Test(&in, &got, nil))
+	assert.Equal(t, v1alpha2.Test{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: v1alpha2.TestSpec{
+			Cluster:    "foo",
+			Execution:  v1alpha2.TestExecutionOptions{Concurrent: true},
+			Error:      v1alpha2.ErrorOptions{},
+			Templating: v1alpha2.TemplatingOptions{Enabled: true},
+			Steps: []v1alpha2.TestStep{{
+				TestStepSpec: v1alpha2.TestStepSpec{
+					Try: []v1alpha2.TryOperation{{
+						Operation: v1alpha2.Operation{
+							OperationAction: v1alpha2.OperationAction{
+								Apply: &v1alpha2.Apply{
+									ActionResourceRef: v1alpha2.ActionResourceRef{
+										FileRef: v1alpha2.FileRef{File: "foo.yaml"},
+									},
+								},
+							},
+							OperationBindings: v1alpha2.OperationBindings{
+								Bindings: []v1alpha2.Binding{{Name: "foo", Value: v1alpha1.Any{Value: "bar"}}},
+							},
+						},
+					}},
+				},
+			}},
+		},
+	}, got)
+	// converting back gives the original test
+	var back v1alpha1.Test
+	assert.NoError(t, Convert_v1alpha2_Test_To_v1alpha1_Test(&got, &back, nil))
+	back.Spec.Concurrent = nil
+	assert.Equal(t, in, back)
+	// features unknown to v1alpha2 are rejected
+	in.Spec.Steps[0].Use = &v1alpha1.Use{Template: "template.yaml"}
+	assert.EqualError(t, Convert_v1alpha1_Test_To_v1alpha2_Test(&in, &got, nil), "failed to convert step 1: step templates are not supported in v1alpha2")
+}
@@ -69,6 +69,13 @@ type ActionExpectations struct {
 	Expect []Expectation `json:"expect,omitempty"`
 }
 
+// ActionFanOut contains fan out options for an action.
+type ActionFanOut struct {
+	// FanOut runs the action on multiple data clusters in parallel.
+	// +optional
+	FanOut *FanOut `json:"fanOut,omitempty"`
+}
+
 // ActionFormat contains format for an action.
 type ActionFormat struct {
 	// Format determines the output format (json or yaml).
@@ -145,6 +152,7 @@ type Apply struct {
 	ActionClusters     `json:",inline"`
 	ActionDryRun       `json:",inline"`
 	ActionExpectations `json:",inline"`
+	ActionFanOut       `json:",inline"`
 	ActionOutputs      `json:",inline"`
 	ActionResourceRef  `json:",inline"`
 	ActionTimeout      `json:",inline"`
@@ -156,6 +164,7 @@ type Assert struct {
 	ActionBindings `json:",inline"`
 	ActionCheckRef `json:",inline"`
 	ActionClusters `json:",inline"`
+	ActionFanOut   `json:",inline"`
 	ActionTimeout  `json:",inline"`
 }
 
@@ -194,6 +203,7 @@ type Delete struct {
 	ActionBindings     `json:",inline"`
 	ActionClusters     `json:",inline"`
 	ActionExpectations `json:",inline"`
+	ActionFanOut       `json:",inline"`
 	ActionTimeout      `json:",inline"`
 
 	// Template determines whether resources should be considered for templating.
@@ -261,6 +271,7 @@ type Patch struct {
 	ActionClusters     `json:",inline"`
 	ActionDryRun       `json:",inline"`
 	ActionExpectations `json:",inline"`
+	ActionFanOut       `json:",inline"`
 	ActionOutputs      `json:",inline"`
 	ActionResourceRef  `json:",inline"`
 	ActionTimeout      `json:",inline"`
@@ -24,6 +24,10 @@ type StepTemplate struct {
 
 // StepTemplateSpec defines the spec of a step template.
 type StepTemplateSpec struct {
+	// Inputs declares the arguments the template accepts through `use.with`.
+	// +optional
+	Inputs []StepTemplateInput `json:"inputs,omitempty"`
+
 	// Bindings defines additional binding key/values.
 	// +optional
 	Bindings []Binding `json:"bindings,omitempty"`
@@ -43,6 +47,48 @@ type StepTemplateSpec struct {
 	// Cleanup defines what will be executed after the test is terminated.
 	// +optional
 	Cleanup []CatchFinally `json:"cleanup,omitempty"`
+
+	// Outputs defines the bindings returned to the calling test.
+	// +optional
+	Outputs []Output `json:"outputs,omitempty"`
+}
+
+// StepTemplateInputType defines the type of a step template input.
+// +kubebuilder:validation:Enum:=any;string;number;boolean;object;array
+type StepTemplateInputType string
+
+const (
+	StepTemplateInputAny     StepTemplateInputType = "any"
+	StepTemplateInputString  StepTemplateInputType = "string"
+	StepTemplateInputNumber  StepTemplateInputType = "number"
+	StepTemplateInputBoolean StepTemplateInputType = "boolean"
+	StepTemplateInputObject  StepTemplateInputType = "object"
+	StepTemplateInputArray   StepTemplateInputType = "array"
+)
+
+// StepTemplateInput declares an argument of a step template.
+type StepTemplateInput struct {
+	// Name of the input, it is registered as a binding when the template is used.
+	// +kubebuilder:validation:Pattern:=`^\w+$`
+	Name string `json:"name"`
+
+	// Description contains a description of the input.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Type of the input, literal values passed to the template are checked against it.
+	// +optional
+	Type StepTemplateInputType `json:"type,omitempty"`
+
+	// Required determines whether the input must be passed to the template.
+	// +optional
+	Required bool `json:"required,omitempty"`
+
+	// Default is the value used when the input is not passed to the template.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Default *Any `json:"default,omitempty"`
 }
 
 // TestStep contains the test step definition used in a test spec.
@@ -65,7 +111,13 @@ type TestStep struct {
 // Use defines a reference to a step template.
 type Use struct {
 	// Template references a step template.
+	// It is either a path relative to the test folder, an OCI artifact reference (oci://<registry>/<repository>:<tag>)
+	// or a git reference (git::<url>//<path>?ref=<ref>).
 	Template string `json:"template,omitempty"`
+
+	// With defines the values of the step template inputs.
+	// +optional
+	With []Binding `json:"with,omitempty"`
 }
 
 // TestStepSpec defines the desired state and behavior for each test step.
@@ -121,4 +173,8 @@ type TestStepSpec struct {
 	// Cleanup defines what will be executed after the test is terminated.
 	// +optional
 	Cleanup []CatchFinally `json:"cleanup,omitempty"`
+
+	// Outputs defines bindings evaluated after the try operations and made available to the next steps of the test.
+	// +optional
+	Outputs []Output `json:"outputs,omitempty"`
 }
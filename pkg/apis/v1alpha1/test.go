@@ -36,6 +36,10 @@ type TestSpec struct {
 	// +optional
 	Timeouts *Timeouts `json:"timeouts,omitempty"`
 
+	// Retry defines how the test is retried when it fails. Overrides the retry policy set in the Configuration.
+	// +optional
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
 	// Cluster defines the target cluster (will be inherited if not specified).
 	// +optional
 	Cluster *string `json:"cluster,omitempty"`
@@ -52,6 +56,11 @@ type TestSpec struct {
 	// +optional
 	Concurrent *bool `json:"concurrent,omitempty"`
 
+	// DependsOn lists the tests that must pass before this test runs.
+	// The test is skipped if one of them fails or is skipped.
+	// +optional
+	DependsOn []TestDependency `json:"dependsOn,omitempty"`
+
 	// SkipDelete determines whether the resources created by the test should be deleted after the test is executed.
 	// +optional
 	SkipDelete *bool `json:"skipDelete,omitempty"`
@@ -100,6 +109,17 @@ type TestSpec struct {
 	DeletionPropagationPolicy *metav1.DeletionPropagation `json:"deletionPropagationPolicy,omitempty"`
 }
 
+// TestDependency selects tests a test depends on, by name or by labels.
+type TestDependency struct {
+	// Name is the name of the test.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Selector is a label selector (label query) selecting tests.
+	// +optional
+	Selector string `json:"selector,omitempty"`
+}
+
 // Scenario defines per scenario bindings.
 type Scenario struct {
 	// Bindings defines binding key/values.
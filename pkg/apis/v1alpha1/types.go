@@ -61,6 +61,23 @@ type Cluster struct {
 	// DataClusterName is the namespace of the data cluster to use.
 	// +optional
 	ControlClusterName string `json:"controlClusterName,omitempty"`
+	// Ephemeral starts a throwaway control plane for the lifetime of the configuration, test or step declaring it.
+	// Ephemeral clusters can't be declared in operations.
+	// +optional
+	Ephemeral *EphemeralCluster `json:"ephemeral,omitempty"`
+}
+
+// EphemeralCluster defines a local control plane (etcd and kube-apiserver)
+// started when the cluster is first used and stopped when the scope declaring it ends.
+type EphemeralCluster struct {
+	// CRDs contains the directories or files of the CRDs to install.
+	// +optional
+	CRDs []string `json:"crds,omitempty"`
+
+	// BinaryAssetsDirectory is the path to the etcd and kube-apiserver binaries.
+	// When empty, the KUBEBUILDER_ASSETS environment variable is used.
+	// +optional
+	BinaryAssetsDirectory string `json:"binaryAssetsDirectory,omitempty"`
 }
 
 // Clusters defines a cluster map.
@@ -112,6 +129,23 @@ func (e Expression) Value(ctx context.Context, bindings binding.Bindings) (strin
 	return expressions.String(ctx, string(e), bindings)
 }
 
+// FanOut defines how an action is executed on multiple data clusters.
+type FanOut struct {
+	// ControlCluster is the name of the cluster used to resolve the data clusters.
+	// +optional
+	ControlCluster string `json:"controlCluster,omitempty"`
+
+	// Clusters is the list of data clusters the action is executed on.
+	// An entry can evaluate to a comma separated list of cluster names.
+	Clusters []Expression `json:"clusters"`
+
+	// Concurrency is the maximum number of clusters processed in parallel.
+	// All clusters are processed in parallel if not specified.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	Concurrency *int `json:"concurrency,omitempty"`
+}
+
 // Format determines the output format (json or yaml).
 // +kubebuilder:validation:Type:=string
 // +kubebuilder:validation:Pattern:=`^(?:json|yaml|\(.+\))$`
@@ -165,6 +199,38 @@ type Output struct {
 	Match *Match `json:"match,omitempty"`
 }
 
+// RetryOn is a class of errors a failed test can be retried on.
+// +kubebuilder:validation:Enum:=Timeout;Conflict;Unavailable
+type RetryOn string
+
+const (
+	// RetryOnTimeout matches operations that timed out.
+	RetryOnTimeout RetryOn = "Timeout"
+	// RetryOnConflict matches conflicts when writing resources.
+	RetryOnConflict RetryOn = "Conflict"
+	// RetryOnUnavailable matches errors reaching the cluster.
+	RetryOnUnavailable RetryOn = "Unavailable"
+)
+
+// RetryPolicy defines how failed tests are retried.
+// A test that passes after being retried is reported as flaky.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a test runs, including the first attempt.
+	// +kubebuilder:validation:Format:=int
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+
+	// Backoff is the delay before the first retry, it doubles for every following retry.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+
+	// On restricts retries to failures caused by the given error classes (Timeout|Conflict|Unavailable).
+	// Any failure is retried if not specified.
+	// +optional
+	On []RetryOn `json:"on,omitempty"`
+}
+
 // DefaultTimeouts contains defautl timeouts per operation.
 type DefaultTimeouts struct {
 	// Apply defines the timeout for the apply operation
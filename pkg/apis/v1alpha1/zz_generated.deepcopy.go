@@ -107,7 +107,7 @@ func (in *ActionClusters) DeepCopyInto(out *ActionClusters) {
 		in, out := &in.Clusters, &out.Clusters
 		*out = make(Clusters, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	return
@@ -190,6 +190,27 @@ func (in *ActionExpectations) DeepCopy() *ActionExpectations {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionFanOut) DeepCopyInto(out *ActionFanOut) {
+	*out = *in
+	if in.FanOut != nil {
+		in, out := &in.FanOut, &out.FanOut
+		*out = new(FanOut)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionFanOut.
+func (in *ActionFanOut) DeepCopy() *ActionFanOut {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionFanOut)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionFormat) DeepCopyInto(out *ActionFormat) {
 	*out = *in
@@ -338,6 +359,7 @@ func (in *Apply) DeepCopyInto(out *Apply) {
 	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
 	in.ActionDryRun.DeepCopyInto(&out.ActionDryRun)
 	in.ActionExpectations.DeepCopyInto(&out.ActionExpectations)
+	in.ActionFanOut.DeepCopyInto(&out.ActionFanOut)
 	in.ActionOutputs.DeepCopyInto(&out.ActionOutputs)
 	in.ActionResourceRef.DeepCopyInto(&out.ActionResourceRef)
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
@@ -360,6 +382,7 @@ func (in *Assert) DeepCopyInto(out *Assert) {
 	in.ActionBindings.DeepCopyInto(&out.ActionBindings)
 	in.ActionCheckRef.DeepCopyInto(&out.ActionCheckRef)
 	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionFanOut.DeepCopyInto(&out.ActionFanOut)
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
 	return
 }
@@ -455,6 +478,11 @@ func (in *CatchFinally) DeepCopy() *CatchFinally {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cluster) DeepCopyInto(out *Cluster) {
 	*out = *in
+	if in.Ephemeral != nil {
+		in, out := &in.Ephemeral, &out.Ephemeral
+		*out = new(EphemeralCluster)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -474,7 +502,7 @@ func (in Clusters) DeepCopyInto(out *Clusters) {
 		in := &in
 		*out = make(Clusters, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 		return
 	}
@@ -576,7 +604,7 @@ func (in *ConfigurationSpec) DeepCopyInto(out *ConfigurationSpec) {
 		in, out := &in.Clusters, &out.Clusters
 		*out = make(Clusters, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	if in.Catch != nil {
@@ -650,6 +678,7 @@ func (in *Delete) DeepCopyInto(out *Delete) {
 	in.ActionBindings.DeepCopyInto(&out.ActionBindings)
 	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
 	in.ActionExpectations.DeepCopyInto(&out.ActionExpectations)
+	in.ActionFanOut.DeepCopyInto(&out.ActionFanOut)
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
@@ -703,6 +732,27 @@ func (in *Describe) DeepCopy() *Describe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralCluster) DeepCopyInto(out *EphemeralCluster) {
+	*out = *in
+	if in.CRDs != nil {
+		in, out := &in.CRDs, &out.CRDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralCluster.
+func (in *EphemeralCluster) DeepCopy() *EphemeralCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(EphemeralCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Error) DeepCopyInto(out *Error) {
 	*out = *in
@@ -764,6 +814,32 @@ func (in *Expectation) DeepCopy() *Expectation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FanOut) DeepCopyInto(out *FanOut) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]Expression, len(*in))
+		copy(*out, *in)
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FanOut.
+func (in *FanOut) DeepCopy() *FanOut {
+	if in == nil {
+		return nil
+	}
+	out := new(FanOut)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileRef) DeepCopyInto(out *FileRef) {
 	*out = *in
@@ -1003,6 +1079,7 @@ func (in *Patch) DeepCopyInto(out *Patch) {
 	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
 	in.ActionDryRun.DeepCopyInto(&out.ActionDryRun)
 	in.ActionExpectations.DeepCopyInto(&out.ActionExpectations)
+	in.ActionFanOut.DeepCopyInto(&out.ActionFanOut)
 	in.ActionOutputs.DeepCopyInto(&out.ActionOutputs)
 	in.ActionResourceRef.DeepCopyInto(&out.ActionResourceRef)
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
@@ -1064,6 +1141,32 @@ func (in *Proxy) DeepCopy() *Proxy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.On != nil {
+		in, out := &in.On, &out.On
+		*out = make([]RetryOn, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Scenario) DeepCopyInto(out *Scenario) {
 	*out = *in
@@ -1153,9 +1256,36 @@ func (in *StepTemplate) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepTemplateInput) DeepCopyInto(out *StepTemplateInput) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepTemplateInput.
+func (in *StepTemplateInput) DeepCopy() *StepTemplateInput {
+	if in == nil {
+		return nil
+	}
+	out := new(StepTemplateInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StepTemplateSpec) DeepCopyInto(out *StepTemplateSpec) {
 	*out = *in
+	if in.Inputs != nil {
+		in, out := &in.Inputs, &out.Inputs
+		*out = make([]StepTemplateInput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Bindings != nil {
 		in, out := &in.Bindings, &out.Bindings
 		*out = make([]Binding, len(*in))
@@ -1191,6 +1321,13 @@ func (in *StepTemplateSpec) DeepCopyInto(out *StepTemplateSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]Output, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1231,6 +1368,22 @@ func (in *Test) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestDependency) DeepCopyInto(out *TestDependency) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestDependency.
+func (in *TestDependency) DeepCopy() *TestDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(TestDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TestSpec) DeepCopyInto(out *TestSpec) {
 	*out = *in
@@ -1244,6 +1397,11 @@ func (in *TestSpec) DeepCopyInto(out *TestSpec) {
 		*out = new(Timeouts)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Cluster != nil {
 		in, out := &in.Cluster, &out.Cluster
 		*out = new(string)
@@ -1253,7 +1411,7 @@ func (in *TestSpec) DeepCopyInto(out *TestSpec) {
 		in, out := &in.Clusters, &out.Clusters
 		*out = make(Clusters, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	if in.Skip != nil {
@@ -1266,6 +1424,11 @@ func (in *TestSpec) DeepCopyInto(out *TestSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]TestDependency, len(*in))
+		copy(*out, *in)
+	}
 	if in.SkipDelete != nil {
 		in, out := &in.SkipDelete, &out.SkipDelete
 		*out = new(bool)
@@ -1342,7 +1505,7 @@ func (in *TestStep) DeepCopyInto(out *TestStep) {
 	if in.Use != nil {
 		in, out := &in.Use, &out.Use
 		*out = new(Use)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	in.TestStepSpec.DeepCopyInto(&out.TestStepSpec)
 	return
@@ -1380,7 +1543,7 @@ func (in *TestStepSpec) DeepCopyInto(out *TestStepSpec) {
 		in, out := &in.Clusters, &out.Clusters
 		*out = make(Clusters, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	if in.SkipDelete != nil {
@@ -1428,6 +1591,13 @@ func (in *TestStepSpec) DeepCopyInto(out *TestStepSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]Output, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1513,6 +1683,13 @@ func (in *Update) DeepCopy() *Update {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Use) DeepCopyInto(out *Use) {
 	*out = *in
+	if in.With != nil {
+		in, out := &in.With, &out.With
+		*out = make([]Binding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -59,10 +59,24 @@ type ConfigurationSpec struct {
 	// +kubebuilder:default:={}
 	Namespace NamespaceOptions `json:"namespace"`
 
+	// Preflight contains the health checks run against clusters before tests start.
+	// +optional
+	// +kubebuilder:default:={}
+	Preflight PreflightOptions `json:"preflight,omitempty"`
+
 	// Report contains properties for the report.
 	// +optional
 	Report *ReportOptions `json:"report,omitempty"`
 
+	// Setup contains steps run once before all tests, resources they create are deleted after the teardown steps ran.
+	// Outputs of setup steps are available to all tests as bindings, tests don't run if setup fails.
+	// +optional
+	Setup []TestStep `json:"setup,omitempty"`
+
+	// Teardown contains steps run once after all tests.
+	// +optional
+	Teardown []TestStep `json:"teardown,omitempty"`
+
 	// Templating contains the templating config.
 	// +optional
 	// +kubebuilder:default:={}
@@ -15,6 +15,62 @@ type CleanupOptions struct {
 	// DelayBeforeCleanup adds a delay between the time a test ends and the time cleanup starts.
 	// +optional
 	DelayBeforeCleanup *metav1.Duration `json:"delayBeforeCleanup,omitempty"`
+
+	// ForceFinalize is the grace period after which the finalizers of resources still being deleted are removed.
+	// Finalizers are never removed when not set.
+	// +optional
+	ForceFinalize *metav1.Duration `json:"forceFinalize,omitempty"`
+
+	// LeakDetection detects resources left behind by tests, snapshots of concurrent tests are scoped to their namespace.
+	// +optional
+	LeakDetection *LeakDetectionOptions `json:"leakDetection,omitempty"`
+
+	// Journal is the path of a file recording the resources registered for cleanup until they are deleted,
+	// resources left behind by an interrupted run can be deleted with `chainsaw cleanup --journal`.
+	// +optional
+	Journal string `json:"journal,omitempty"`
+}
+
+// LeakAction determines what happens when leaked resources are detected.
+// +kubebuilder:validation:Enum:=Warn;Fail;Collect
+type LeakAction string
+
+const (
+	// LeakActionWarn reports leaked resources as warnings.
+	LeakActionWarn LeakAction = "Warn"
+	// LeakActionFail fails the test leaking resources.
+	LeakActionFail LeakAction = "Fail"
+	// LeakActionCollect deletes leaked resources.
+	LeakActionCollect LeakAction = "Collect"
+)
+
+// LeakDetectionOptions contains the configuration used for detecting leaked resources.
+type LeakDetectionOptions struct {
+	// Resources are the resources snapshotted when a test starts and after it has been cleaned up,
+	// resources present in the second snapshot only have been leaked by the test.
+	Resources []LeakResource `json:"resources"`
+
+	// Action determines what happens when leaked resources are detected.
+	// +optional
+	// +kubebuilder:default:=Warn
+	Action LeakAction `json:"action,omitempty"`
+}
+
+// LeakResource selects resources watched for leaks.
+type LeakResource struct {
+	// API version of the resources.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the resources.
+	Kind string `json:"kind"`
+
+	// Namespace of the resources, all namespaces are considered when not set.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Label selector of the resources.
+	// +optional
+	Selector string `json:"selector,omitempty"`
 }
 
 // DeletionOptions contains the configuration used for deleting resources.
@@ -77,6 +133,10 @@ type ExecutionOptions struct {
 	// ForceTerminationGracePeriod forces the termination grace period on pods, statefulsets, daemonsets and deployments.
 	// +optional
 	ForceTerminationGracePeriod *metav1.Duration `json:"forceTerminationGracePeriod,omitempty"`
+
+	// Retry defines how failed tests are retried.
+	// +optional
+	Retry *RetryPolicy `json:"retry,omitempty"`
 }
 
 // NamespaceOptions contains the configuration used to allocate a namespace for each test.
@@ -92,19 +152,73 @@ type NamespaceOptions struct {
 	Template *Any `json:"template,omitempty"`
 }
 
+type PreflightFailureType string
+
+const (
+	PreflightFail PreflightFailureType = "Fail"
+	PreflightSkip PreflightFailureType = "Skip"
+)
+
+// PreflightOptions contains the configuration of the health checks run against clusters before tests start.
+type PreflightOptions struct {
+	// Enabled determines whether clusters are checked before running tests.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Failure determines what happens to the tests targeting an unhealthy cluster (Fail|Skip).
+	// Tests are failed if not specified.
+	// +optional
+	// +kubebuilder:validation:Enum:=Fail;Skip
+	Failure PreflightFailureType `json:"failure,omitempty"`
+
+	// Timeout is the maximum duration of the checks of a single cluster.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// APIGroups contains the API groups (or group versions) every cluster must serve.
+	// +optional
+	APIGroups []string `json:"apiGroups,omitempty"`
+
+	// CRDs contains the names of the CRDs every cluster must have.
+	// +optional
+	CRDs []string `json:"crds,omitempty"`
+
+	// Permissions contains the access every cluster must grant to the running user.
+	// +optional
+	Permissions []PreflightPermission `json:"permissions,omitempty"`
+}
+
+// PreflightPermission describes an access checked with a SelfSubjectAccessReview.
+type PreflightPermission struct {
+	// Group is the API group of the resource.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Resource is the resource to check.
+	Resource string `json:"resource"`
+
+	// Verbs contains the verbs to check.
+	Verbs []string `json:"verbs"`
+
+	// Namespace is the namespace to check, cluster wide if empty.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
 type ReportFormatType string
 
 const (
 	JSONFormat ReportFormatType = "JSON"
 	XMLFormat  ReportFormatType = "XML"
 	CSVFormat  ReportFormatType = "CSV"
+	HTMLFormat ReportFormatType = "HTML"
 )
 
 // ReportOptions contains the configuration used for reporting.
 type ReportOptions struct {
-	// ReportFormat determines test report format (JSON|XML|CSV).
+	// ReportFormat determines test report format (JSON|XML|CSV|HTML).
 	// +optional
-	// +kubebuilder:validation:Enum:=JSON;XML;CSV
+	// +kubebuilder:validation:Enum:=JSON;XML;CSV;HTML
 	// +kubebuilder:default:="JSON"
 	Format ReportFormatType `json:"format,omitempty"`
 
@@ -116,6 +230,11 @@ type ReportOptions struct {
 	// +optional
 	// +kubebuilder:default:="chainsaw-report"
 	Name string `json:"name,omitempty"`
+
+	// Events defines the path of a file where report events are appended as they happen (one JSON document per line).
+	// The event log can be used to rebuild a report when a run is interrupted.
+	// +optional
+	Events string `json:"events,omitempty"`
 }
 
 // TemplatingOptions contains the templating configuration.
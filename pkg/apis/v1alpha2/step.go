@@ -68,4 +68,8 @@ type TestStepSpec struct {
 	// Cleanup defines what will be executed after the test is terminated.
 	// +optional
 	Cleanup []Operation `json:"cleanup,omitempty"`
+
+	// Outputs defines bindings evaluated after the try operations and made available to the next steps of the test.
+	// +optional
+	Outputs []Output `json:"outputs,omitempty"`
 }
@@ -86,6 +86,11 @@ type TestExecutionOptions struct {
 	// +kubebuilder:default:=true
 	Concurrent bool `json:"concurrent"`
 
+	// DependsOn lists the tests that must pass before this test runs.
+	// The test is skipped if one of them fails or is skipped.
+	// +optional
+	DependsOn []TestDependency `json:"dependsOn,omitempty"`
+
 	// Skip determines whether the test should skipped.
 	// +optional
 	Skip bool `json:"skip,omitempty"`
@@ -93,4 +98,8 @@ type TestExecutionOptions struct {
 	// TerminationGracePeriod forces the termination grace period on pods, statefulsets, daemonsets and deployments.
 	// +optional
 	TerminationGracePeriod *metav1.Duration `json:"terminationGracePeriod,omitempty"`
+
+	// Retry defines how the test is retried when it fails. Overrides the retry policy set in the Configuration.
+	// +optional
+	Retry *RetryPolicy `json:"retry,omitempty"`
 }
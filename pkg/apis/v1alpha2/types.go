@@ -17,6 +17,8 @@ type (
 	ObjectName      = v1alpha1.ObjectName
 	ObjectType      = v1alpha1.ObjectType
 	Output          = v1alpha1.Output
+	RetryPolicy     = v1alpha1.RetryPolicy
+	TestDependency  = v1alpha1.TestDependency
 	Timeouts        = v1alpha1.Timeouts
 	DefaultTimeouts = v1alpha1.DefaultTimeouts
 )
@@ -304,6 +304,16 @@ func (in *CleanupOptions) DeepCopyInto(out *CleanupOptions) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.ForceFinalize != nil {
+		in, out := &in.ForceFinalize, &out.ForceFinalize
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LeakDetection != nil {
+		in, out := &in.LeakDetection, &out.LeakDetection
+		*out = new(LeakDetectionOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -376,7 +386,7 @@ func (in *ConfigurationSpec) DeepCopyInto(out *ConfigurationSpec) {
 		in, out := &in.Clusters, &out.Clusters
 		*out = make(v1alpha1.Clusters, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	out.Deletion = in.Deletion
@@ -384,11 +394,26 @@ func (in *ConfigurationSpec) DeepCopyInto(out *ConfigurationSpec) {
 	in.Error.DeepCopyInto(&out.Error)
 	in.Execution.DeepCopyInto(&out.Execution)
 	in.Namespace.DeepCopyInto(&out.Namespace)
+	in.Preflight.DeepCopyInto(&out.Preflight)
 	if in.Report != nil {
 		in, out := &in.Report, &out.Report
 		*out = new(ReportOptions)
 		**out = **in
 	}
+	if in.Setup != nil {
+		in, out := &in.Setup, &out.Setup
+		*out = make([]TestStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Teardown != nil {
+		in, out := &in.Teardown, &out.Teardown
+		*out = make([]TestStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.Templating = in.Templating
 	out.Timeouts = in.Timeouts
 	return
@@ -590,6 +615,11 @@ func (in *ExecutionOptions) DeepCopyInto(out *ExecutionOptions) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(v1alpha1.RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -638,6 +668,43 @@ func (in *Get) DeepCopy() *Get {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeakDetectionOptions) DeepCopyInto(out *LeakDetectionOptions) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]LeakResource, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeakDetectionOptions.
+func (in *LeakDetectionOptions) DeepCopy() *LeakDetectionOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(LeakDetectionOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeakResource) DeepCopyInto(out *LeakResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeakResource.
+func (in *LeakResource) DeepCopy() *LeakResource {
+	if in == nil {
+		return nil
+	}
+	out := new(LeakResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceOptions) DeepCopyInto(out *NamespaceOptions) {
 	*out = *in
@@ -822,7 +889,7 @@ func (in *OperationClusters) DeepCopyInto(out *OperationClusters) {
 		in, out := &in.Clusters, &out.Clusters
 		*out = make(v1alpha1.Clusters, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	return
@@ -904,6 +971,65 @@ func (in *PodLogs) DeepCopy() *PodLogs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreflightOptions) DeepCopyInto(out *PreflightOptions) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.APIGroups != nil {
+		in, out := &in.APIGroups, &out.APIGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CRDs != nil {
+		in, out := &in.CRDs, &out.CRDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]PreflightPermission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreflightOptions.
+func (in *PreflightOptions) DeepCopy() *PreflightOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PreflightOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreflightPermission) DeepCopyInto(out *PreflightPermission) {
+	*out = *in
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreflightPermission.
+func (in *PreflightPermission) DeepCopy() *PreflightPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(PreflightPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReportOptions) DeepCopyInto(out *ReportOptions) {
 	*out = *in
@@ -1002,11 +1128,21 @@ func (in *Test) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TestExecutionOptions) DeepCopyInto(out *TestExecutionOptions) {
 	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]v1alpha1.TestDependency, len(*in))
+		copy(*out, *in)
+	}
 	if in.TerminationGracePeriod != nil {
 		in, out := &in.TerminationGracePeriod, &out.TerminationGracePeriod
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(v1alpha1.RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1028,7 +1164,7 @@ func (in *TestSpec) DeepCopyInto(out *TestSpec) {
 		in, out := &in.Clusters, &out.Clusters
 		*out = make(v1alpha1.Clusters, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	in.Execution.DeepCopyInto(&out.Execution)
@@ -1098,7 +1234,7 @@ func (in *TestStepSpec) DeepCopyInto(out *TestStepSpec) {
 		in, out := &in.Clusters, &out.Clusters
 		*out = make(v1alpha1.Clusters, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	if in.SkipDelete != nil {
@@ -1146,6 +1282,13 @@ func (in *TestStepSpec) DeepCopyInto(out *TestStepSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]v1alpha1.Output, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
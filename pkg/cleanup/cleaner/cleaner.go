@@ -2,16 +2,26 @@ package cleaner
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/kyverno/chainsaw/pkg/cleanup/journal"
 	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/pkg/ext/output/color"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 type cleanupEntry struct {
 	client client.Client
 	object client.Object
+	// journal is the entry recorded in the journal, if any
+	journal *journal.Entry
 }
 
 type CleanerCollector interface {
@@ -24,11 +34,13 @@ type Cleaner interface {
 	Run(ctx context.Context) []error
 }
 
-func New(timeout time.Duration, delay *time.Duration, propagation metav1.DeletionPropagation) Cleaner {
+func New(timeout time.Duration, delay *time.Duration, propagation metav1.DeletionPropagation, forceFinalize *time.Duration, journal journal.Journal) Cleaner {
 	return &cleaner{
-		delay:       delay,
-		timeout:     timeout,
-		propagation: propagation,
+		delay:         delay,
+		timeout:       timeout,
+		propagation:   propagation,
+		forceFinalize: forceFinalize,
+		journal:       journal,
 	}
 }
 
@@ -36,42 +48,145 @@ type cleaner struct {
 	delay       *time.Duration
 	timeout     time.Duration
 	propagation metav1.DeletionPropagation
-	entries     []cleanupEntry
+	// forceFinalize is the grace period after which finalizers of objects being deleted are removed
+	forceFinalize *time.Duration
+	// journal persists the entries so that they can be deleted if the process is interrupted
+	journal journal.Journal
+	entries []cleanupEntry
 }
 
 func (c *cleaner) Add(client client.Client, object client.Object) {
-	c.entries = append(c.entries, cleanupEntry{
+	entry := cleanupEntry{
 		client: client,
 		object: object,
-	})
+	}
+	c.record(&entry)
+	c.entries = append(c.entries, entry)
+}
+
+// record adds the entry to the journal, objects created on clusters that cannot be identified are not recorded.
+func (c *cleaner) record(entry *cleanupEntry) {
+	if c.journal == nil {
+		return
+	}
+	if cluster := client.Cluster(entry.client); cluster != "" {
+		if journalEntry, ok := journal.NewEntry(cluster, entry.object); ok {
+			c.journal.Record(journalEntry)
+			entry.journal = &journalEntry
+		}
+	}
 }
 
 func (c *cleaner) Empty() bool {
 	return len(c.entries) == 0
 }
 
+// Run deletes the registered objects wave by wave, see waves for how objects are grouped.
+// Objects in a wave are deleted concurrently and the wave completes when all of them are gone.
 func (c *cleaner) Run(ctx context.Context) []error {
 	if c.delay != nil {
 		time.Sleep(*c.delay)
 	}
 	var errs []error
-	for i := len(c.entries) - 1; i >= 0; i-- {
-		if err := c.delete(ctx, c.entries[i]); err != nil {
-			errs = append(errs, err)
-		}
+	for _, wave := range waves(c.entries) {
+		errs = append(errs, c.deleteWave(ctx, wave)...)
 	}
 	return errs
 }
 
-func (c *cleaner) delete(ctx context.Context, entry cleanupEntry) error {
+func (c *cleaner) deleteWave(ctx context.Context, wave []cleanupEntry) []error {
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
+	errs := make([]error, len(wave))
+	var wg sync.WaitGroup
+	for i := range wave {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = c.delete(ctx, wave[i])
+		}(i)
+	}
+	wg.Wait()
+	var out []error
+	for _, err := range errs {
+		if err != nil {
+			out = append(out, err)
+		}
+	}
+	return out
+}
+
+func (c *cleaner) delete(ctx context.Context, entry cleanupEntry) error {
+	err := c.deleteAndWait(ctx, entry)
+	if err == nil && entry.journal != nil {
+		c.journal.Prune(*entry.journal)
+	}
+	return err
+}
+
+func (c *cleaner) deleteAndWait(ctx context.Context, entry cleanupEntry) error {
 	if err := entry.client.Delete(ctx, entry.object, client.PropagationPolicy(c.propagation)); err != nil {
 		if !kerrors.IsNotFound(err) {
 			return err
 		}
-	} else if err := client.WaitForDeletion(ctx, entry.client, entry.object); err != nil {
+	} else if err := c.waitForDeletion(ctx, entry); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return &LeftBehindError{Object: entry.object, Diagnostics: diagnose(ctx, entry), Err: err}
+		}
 		return err
 	}
 	return nil
 }
+
+// waitForDeletion waits until the object is gone, when force finalize is enabled
+// the object finalizers are removed if it still exists after the grace period.
+func (c *cleaner) waitForDeletion(ctx context.Context, entry cleanupEntry) error {
+	if c.forceFinalize != nil {
+		graceCtx, cancel := context.WithTimeout(ctx, *c.forceFinalize)
+		err := client.WaitForDeletion(graceCtx, entry.client, entry.object)
+		cancel()
+		if err == nil || ctx.Err() != nil || !errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+		if finalizers := entry.object.GetFinalizers(); len(finalizers) != 0 {
+			logging.Log(ctx, logging.Cleanup, logging.WarnStatus, color.BoldYellow, logging.Section("FINALIZERS", fmt.Sprintf("removing %v from %s", finalizers, objectName(entry.object))))
+			if err := entry.client.Patch(ctx, entry.object, client.RawPatch(types.MergePatchType, []byte(`{"metadata":{"finalizers":null}}`))); err != nil {
+				if kerrors.IsNotFound(err) {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	return client.WaitForDeletion(ctx, entry.client, entry.object)
+}
+
+// LeftBehindError is returned for objects that still exist when the cleanup timeout expires.
+type LeftBehindError struct {
+	// Object is the last known state of the object.
+	Object      client.Object
+	Diagnostics Diagnostics
+	Err         error
+}
+
+func (e *LeftBehindError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s was not deleted (finalizers: %v): %s", objectName(e.Object), e.Object.GetFinalizers(), e.Err)
+	if e.Diagnostics.DeletionTimestamp != nil {
+		fmt.Fprintf(&b, "\ndeletion requested at %s", e.Diagnostics.DeletionTimestamp.UTC().Format(time.RFC3339))
+	}
+	if len(e.Diagnostics.Controllers) != 0 {
+		fmt.Fprintf(&b, "\ncontrolled by %s", strings.Join(e.Diagnostics.Controllers, ", "))
+	}
+	if len(e.Diagnostics.Events) != 0 {
+		b.WriteString("\nrecent events:")
+		for _, event := range e.Diagnostics.Events {
+			b.WriteString("\n- " + event)
+		}
+	}
+	return b.String()
+}
+
+func (e *LeftBehindError) Unwrap() error {
+	return e.Err
+}
@@ -3,9 +3,11 @@ package cleaner
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/kyverno/chainsaw/pkg/cleanup/journal"
 	"github.com/kyverno/chainsaw/pkg/client"
 	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
 	"github.com/stretchr/testify/assert"
@@ -18,10 +20,11 @@ import (
 
 func TestNew(t *testing.T) {
 	tests := []struct {
-		name    string
-		timeout time.Duration
-		delay   *time.Duration
-		want    Cleaner
+		name          string
+		timeout       time.Duration
+		delay         *time.Duration
+		forceFinalize *time.Duration
+		want          Cleaner
 	}{{
 		name:    "with timeout",
 		timeout: time.Minute,
@@ -40,10 +43,19 @@ func TestNew(t *testing.T) {
 			delay:       ptr.To(10 * time.Second),
 			propagation: metav1.DeletePropagationBackground,
 		},
+	}, {
+		name:          "with force finalize",
+		timeout:       time.Minute,
+		forceFinalize: ptr.To(30 * time.Second),
+		want: &cleaner{
+			timeout:       time.Minute,
+			propagation:   metav1.DeletePropagationBackground,
+			forceFinalize: ptr.To(30 * time.Second),
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := New(tt.timeout, tt.delay, metav1.DeletePropagationBackground)
+			got := New(tt.timeout, tt.delay, metav1.DeletePropagationBackground, tt.forceFinalize, nil)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -187,7 +199,7 @@ func Test_cleaner_Run(t *testing.T) {
 				},
 			},
 		}},
-		want: []error{context.DeadlineExceeded},
+		want: []error{&LeftBehindError{Object: obj, Err: context.DeadlineExceeded}},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -201,3 +213,192 @@ func Test_cleaner_Run(t *testing.T) {
 		})
 	}
 }
+
+func Test_cleaner_Run_concurrent(t *testing.T) {
+	// each delete blocks until all objects in the wave have been deleted
+	var deleted sync.WaitGroup
+	deleted.Add(2)
+	entry := func(name string) cleanupEntry {
+		return cleanupEntry{
+			object: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+					Name:      name,
+				},
+			},
+			client: &tclient.FakeClient{
+				DeleteFn: func(ctx context.Context, call int, obj client.Object, opts ...client.DeleteOption) error {
+					deleted.Done()
+					deleted.Wait()
+					return nil
+				},
+				GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					return kerror.NewNotFound(corev1.Resource("configmap"), key.Name)
+				},
+			},
+		}
+	}
+	c := &cleaner{
+		timeout: 1 * time.Second,
+		entries: []cleanupEntry{entry("a"), entry("b")},
+	}
+	got := c.Run(context.TODO())
+	assert.Nil(t, got)
+}
+
+func Test_cleaner_Run_forceFinalize(t *testing.T) {
+	tests := []struct {
+		name          string
+		forceFinalize *time.Duration
+		wantPatch     bool
+		wantErr       bool
+	}{{
+		name:    "disabled",
+		wantErr: true,
+	}, {
+		name:          "enabled",
+		forceFinalize: ptr.To(200 * time.Millisecond),
+		wantPatch:     true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var patch string
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion("v1")
+			obj.SetKind("ConfigMap")
+			obj.SetNamespace("foo")
+			obj.SetName("bar")
+			c := &cleaner{
+				timeout:       1 * time.Second,
+				forceFinalize: tt.forceFinalize,
+				entries: []cleanupEntry{{
+					object: obj,
+					client: &tclient.FakeClient{
+						DeleteFn: func(ctx context.Context, call int, obj client.Object, opts ...client.DeleteOption) error {
+							return nil
+						},
+						GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+							mu.Lock()
+							defer mu.Unlock()
+							// the object is gone once its finalizers have been removed
+							if patch != "" {
+								return kerror.NewNotFound(corev1.Resource("configmap"), key.Name)
+							}
+							obj.SetFinalizers([]string{"example.com/protect"})
+							return nil
+						},
+						ListFn: func(ctx context.Context, call int, list client.ObjectList, opts ...client.ListOption) error {
+							return nil
+						},
+						PatchFn: func(ctx context.Context, call int, obj client.Object, p client.Patch, opts ...client.PatchOption) error {
+							data, err := p.Data(obj)
+							mu.Lock()
+							defer mu.Unlock()
+							patch = string(data)
+							return err
+						},
+					},
+				}},
+			}
+			errs := c.Run(context.TODO())
+			if tt.wantErr {
+				assert.Len(t, errs, 1)
+				var leftBehind *LeftBehindError
+				assert.ErrorAs(t, errs[0], &leftBehind)
+			} else {
+				assert.Nil(t, errs)
+			}
+			if tt.wantPatch {
+				assert.JSONEq(t, `{"metadata":{"finalizers":null}}`, patch)
+			} else {
+				assert.Empty(t, patch)
+			}
+		})
+	}
+}
+
+func TestLeftBehindError(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("foo")
+	obj.SetName("bar")
+	obj.SetFinalizers([]string{"example.com/protect"})
+	err := &LeftBehindError{Object: obj, Err: context.DeadlineExceeded}
+	assert.Equal(t, "v1/ConfigMap foo/bar was not deleted (finalizers: [example.com/protect]): context deadline exceeded", err.Error())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestLeftBehindError_diagnostics(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("foo")
+	obj.SetName("bar")
+	err := &LeftBehindError{
+		Object: obj,
+		Diagnostics: Diagnostics{
+			DeletionTimestamp: &metav1.Time{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+			Controllers:       []string{"apps/v1/Deployment foo"},
+			Events:            []string{"Warning FailedDelete: denied (x2)"},
+		},
+		Err: context.DeadlineExceeded,
+	}
+	want := `v1/ConfigMap foo/bar was not deleted (finalizers: []): context deadline exceeded
+deletion requested at 2024-01-02T03:04:05Z
+controlled by apps/v1/Deployment foo
+recent events:
+- Warning FailedDelete: denied (x2)`
+	assert.Equal(t, want, err.Error())
+}
+
+type fakeJournal struct {
+	lock     sync.Mutex
+	recorded []journal.Entry
+	pruned   []journal.Entry
+}
+
+func (j *fakeJournal) Record(entry journal.Entry) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.recorded = append(j.recorded, entry)
+}
+
+func (j *fakeJournal) Prune(entry journal.Entry) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.pruned = append(j.pruned, entry)
+}
+
+func Test_cleaner_journal(t *testing.T) {
+	object := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: name}}
+	}
+	entry := func(name string) journal.Entry {
+		return journal.Entry{Cluster: "https://cluster", APIVersion: "v1", Kind: "ConfigMap", Namespace: "foo", Name: name}
+	}
+	// objects are deleted concurrently, each one gets its own client
+	fake := func() *tclient.FakeClient {
+		return &tclient.FakeClient{
+			DeleteFn: func(ctx context.Context, call int, obj client.Object, opts ...client.DeleteOption) error {
+				if obj.GetName() == "failed" {
+					return errors.New("dummy")
+				}
+				return nil
+			},
+			GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return kerror.NewNotFound(corev1.Resource("configmap"), key.Name)
+			},
+		}
+	}
+	j := &fakeJournal{}
+	c := New(time.Second, nil, metav1.DeletePropagationBackground, nil, j)
+	c.Add(client.WithCluster(fake(), "https://cluster"), object("deleted"))
+	c.Add(client.WithCluster(fake(), "https://cluster"), object("failed"))
+	// objects created on unknown clusters are not recorded
+	c.Add(fake(), object("unknown"))
+	assert.Equal(t, []journal.Entry{entry("deleted"), entry("failed")}, j.recorded)
+	assert.Len(t, c.Run(context.TODO()), 1)
+	assert.Equal(t, []journal.Entry{entry("deleted")}, j.pruned)
+}
@@ -0,0 +1,99 @@
+package cleaner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// diagnosticsTimeout bounds the time spent collecting diagnostics, the cleanup timeout has already expired
+	diagnosticsTimeout = 10 * time.Second
+	// maxEvents is the number of recent events kept in diagnostics
+	maxEvents = 5
+)
+
+// Diagnostics describes why an object could not be deleted.
+type Diagnostics struct {
+	DeletionTimestamp *metav1.Time
+	// Controllers are the owners managing the object
+	Controllers []string
+	// Events are the most recent events involving the object
+	Events []string
+}
+
+func diagnose(ctx context.Context, entry cleanupEntry) Diagnostics {
+	diagnostics := Diagnostics{
+		DeletionTimestamp: entry.object.GetDeletionTimestamp(),
+	}
+	for _, owner := range entry.object.GetOwnerReferences() {
+		if owner.Controller != nil && *owner.Controller {
+			diagnostics.Controllers = append(diagnostics.Controllers, fmt.Sprintf("%s/%s %s", owner.APIVersion, owner.Kind, owner.Name))
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), diagnosticsTimeout)
+	defer cancel()
+	// events are best effort, errors are ignored
+	if events, err := events(ctx, entry); err == nil {
+		for _, event := range events {
+			message := fmt.Sprintf("%s %s: %s", event.Type, event.Reason, event.Message)
+			if event.Count > 1 {
+				message += fmt.Sprintf(" (x%d)", event.Count)
+			}
+			diagnostics.Events = append(diagnostics.Events, message)
+		}
+	}
+	return diagnostics
+}
+
+// events returns the most recent events involving the object of the given entry.
+func events(ctx context.Context, entry cleanupEntry) ([]corev1.Event, error) {
+	uid := entry.object.GetUID()
+	if uid == "" {
+		return nil, nil
+	}
+	var list unstructured.UnstructuredList
+	list.SetAPIVersion("v1")
+	list.SetKind("EventList")
+	var opts []client.ListOption
+	if namespace := entry.object.GetNamespace(); namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	if err := entry.client.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+	var out []corev1.Event
+	for _, item := range list.Items {
+		var event corev1.Event
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.UnstructuredContent(), &event); err != nil {
+			return nil, err
+		}
+		if event.InvolvedObject.UID == uid {
+			out = append(out, event)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return lastSeen(out[i]).Before(lastSeen(out[j]))
+	})
+	if len(out) > maxEvents {
+		out = out[len(out)-maxEvents:]
+	}
+	return out, nil
+}
+
+func lastSeen(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
@@ -0,0 +1,100 @@
+package cleaner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func event(t *testing.T, uid types.UID, reason string, count int32, lastSeen time.Time) unstructured.Unstructured {
+	t.Helper()
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "foo", Name: reason},
+		InvolvedObject: corev1.ObjectReference{UID: uid},
+		Type:           corev1.EventTypeWarning,
+		Reason:         reason,
+		Message:        "message",
+		Count:          count,
+		LastTimestamp:  metav1.Time{Time: lastSeen},
+	})
+	assert.NoError(t, err)
+	return unstructured.Unstructured{Object: data}
+}
+
+func Test_diagnose(t *testing.T) {
+	now := time.Now()
+	obj := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "foo",
+			Name:              "bar",
+			UID:               "uid",
+			DeletionTimestamp: &metav1.Time{Time: now},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "owner",
+				Controller: ptr.To(true),
+			}, {
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Name:       "other",
+			}},
+		},
+	}
+	var items []unstructured.Unstructured
+	items = append(items, event(t, "other", "Unrelated", 1, now))
+	for i := 0; i < maxEvents+1; i++ {
+		items = append(items, event(t, "uid", fmt.Sprintf("Reason%d", i), int32(i), now.Add(time.Duration(i)*time.Second)))
+	}
+	tests := []struct {
+		name   string
+		listFn func(context.Context, int, client.ObjectList, ...client.ListOption) error
+		want   Diagnostics
+	}{{
+		name: "with events",
+		listFn: func(_ context.Context, _ int, list client.ObjectList, opts ...client.ListOption) error {
+			list.(*unstructured.UnstructuredList).Items = items
+			return nil
+		},
+		want: Diagnostics{
+			DeletionTimestamp: obj.DeletionTimestamp,
+			Controllers:       []string{"apps/v1/Deployment owner"},
+			Events: []string{
+				"Warning Reason1: message",
+				"Warning Reason2: message (x2)",
+				"Warning Reason3: message (x3)",
+				"Warning Reason4: message (x4)",
+				"Warning Reason5: message (x5)",
+			},
+		},
+	}, {
+		name: "list error",
+		listFn: func(context.Context, int, client.ObjectList, ...client.ListOption) error {
+			return fmt.Errorf("dummy")
+		},
+		want: Diagnostics{
+			DeletionTimestamp: obj.DeletionTimestamp,
+			Controllers:       []string{"apps/v1/Deployment owner"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diagnose(context.TODO(), cleanupEntry{
+				object: obj,
+				client: &tclient.FakeClient{ListFn: tt.listFn},
+			})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
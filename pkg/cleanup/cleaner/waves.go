@@ -0,0 +1,101 @@
+package cleaner
+
+import (
+	"sort"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// class determines the order in which objects are deleted,
+// namespaced objects go first, then cluster-scoped objects, and finally CRDs and namespaces.
+type class int
+
+const (
+	classNamespaced class = iota
+	classClustered
+	classDefinitions
+)
+
+func classOf(object client.Object) class {
+	if object.GetNamespace() != "" {
+		return classNamespaced
+	}
+	gvk := object.GetObjectKind().GroupVersionKind()
+	if (gvk.Group == "" && gvk.Kind == "Namespace") || (gvk.Group == "apiextensions.k8s.io" && gvk.Kind == "CustomResourceDefinition") {
+		return classDefinitions
+	}
+	return classClustered
+}
+
+type waveKey struct {
+	class class
+	depth int
+}
+
+// waves groups entries in the order they must be deleted.
+// In a given class, an object owned by another registered object goes in a wave after its owner,
+// this way controllers can't recreate the object and the garbage collector usually takes care of it.
+// The most recently registered objects go first in a wave.
+func waves(entries []cleanupEntry) [][]cleanupEntry {
+	uids := map[types.UID]int{}
+	for i, entry := range entries {
+		if uid := entry.object.GetUID(); uid != "" {
+			uids[uid] = i
+		}
+	}
+	depths := make([]int, len(entries))
+	for i := range depths {
+		depths[i] = -1
+	}
+	var depth func(int, map[int]bool) int
+	depth = func(i int, visiting map[int]bool) int {
+		if depths[i] >= 0 {
+			return depths[i]
+		}
+		// ignore ownership cycles
+		if visiting[i] {
+			return 0
+		}
+		visiting[i] = true
+		defer delete(visiting, i)
+		d := 0
+		for _, owner := range entries[i].object.GetOwnerReferences() {
+			if j, ok := uids[owner.UID]; ok && j != i && classOf(entries[j].object) == classOf(entries[i].object) {
+				if od := depth(j, visiting) + 1; od > d {
+					d = od
+				}
+			}
+		}
+		depths[i] = d
+		return d
+	}
+	grouped := map[waveKey][]cleanupEntry{}
+	var keys []waveKey
+	for i := len(entries) - 1; i >= 0; i-- {
+		key := waveKey{class: classOf(entries[i].object), depth: depth(i, map[int]bool{})}
+		if _, ok := grouped[key]; !ok {
+			keys = append(keys, key)
+		}
+		grouped[key] = append(grouped[key], entries[i])
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].class != keys[j].class {
+			return keys[i].class < keys[j].class
+		}
+		return keys[i].depth < keys[j].depth
+	})
+	out := make([][]cleanupEntry, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, grouped[key])
+	}
+	return out
+}
+
+func objectName(object client.Object) string {
+	name := client.Name(client.Key(object))
+	if gvk := object.GetObjectKind().GroupVersionKind(); gvk.Kind != "" {
+		return gvk.GroupVersion().String() + "/" + gvk.Kind + " " + name
+	}
+	return name
+}
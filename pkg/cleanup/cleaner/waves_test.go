@@ -0,0 +1,84 @@
+package cleaner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func entry(apiVersion, kind, namespace, name string, owners ...string) cleanupEntry {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetUID(types.UID(name))
+	var refs []metav1.OwnerReference
+	for _, owner := range owners {
+		refs = append(refs, metav1.OwnerReference{UID: types.UID(owner)})
+	}
+	obj.SetOwnerReferences(refs)
+	return cleanupEntry{object: obj}
+}
+
+func Test_waves(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []cleanupEntry
+		want    [][]string
+	}{{
+		name: "empty",
+		want: [][]string{},
+	}, {
+		name: "reverse order",
+		entries: []cleanupEntry{
+			entry("v1", "ConfigMap", "foo", "a"),
+			entry("v1", "Secret", "foo", "b"),
+		},
+		want: [][]string{{"b", "a"}},
+	}, {
+		name: "classes",
+		entries: []cleanupEntry{
+			entry("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "crd"),
+			entry("v1", "Namespace", "", "ns"),
+			entry("rbac.authorization.k8s.io/v1", "ClusterRole", "", "role"),
+			entry("v1", "ConfigMap", "ns", "cm"),
+		},
+		want: [][]string{{"cm"}, {"role"}, {"ns", "crd"}},
+	}, {
+		name: "owners",
+		entries: []cleanupEntry{
+			entry("apps/v1", "Deployment", "foo", "deploy"),
+			entry("apps/v1", "ReplicaSet", "foo", "rs", "deploy"),
+			entry("v1", "ConfigMap", "foo", "cm"),
+			entry("v1", "Pod", "foo", "pod", "rs"),
+			entry("v1", "Namespace", "", "foo"),
+			// ownership across classes is ignored
+			entry("v1", "Secret", "foo", "secret", "foo"),
+		},
+		want: [][]string{{"secret", "cm", "deploy"}, {"rs"}, {"pod"}, {"foo"}},
+	}, {
+		name: "cycle",
+		entries: []cleanupEntry{
+			entry("v1", "ConfigMap", "foo", "a", "b"),
+			entry("v1", "ConfigMap", "foo", "b", "a"),
+		},
+		want: [][]string{{"a"}, {"b"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := [][]string{}
+			for _, wave := range waves(tt.entries) {
+				var names []string
+				for _, entry := range wave {
+					names = append(names, entry.object.GetName())
+				}
+				got = append(got, names)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package journal
+
+import (
+	"context"
+)
+
+type contextKey struct{}
+
+func FromContext(ctx context.Context) Journal {
+	if ctx != nil {
+		if v, ok := ctx.Value(contextKey{}).(Journal); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func IntoContext(ctx context.Context, journal Journal) context.Context {
+	return context.WithValue(ctx, contextKey{}, journal)
+}
@@ -0,0 +1,177 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/utils/ndjson"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Entry identifies an object registered for cleanup.
+type Entry struct {
+	// Cluster is the address of the API server the object was created on.
+	Cluster    string    `json:"cluster"`
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Name       string    `json:"name"`
+	UID        types.UID `json:"uid,omitempty"`
+}
+
+// NewEntry creates the entry of an object, it returns false when the object type cannot be determined.
+func NewEntry(cluster string, object client.Object) (Entry, bool) {
+	gvk := object.GetObjectKind().GroupVersionKind()
+	if gvk.Kind == "" {
+		// typed objects don't always carry their type
+		if _gvk, err := apiutil.GVKForObject(object, scheme.Scheme); err == nil {
+			gvk = _gvk
+		}
+	}
+	if gvk.Kind == "" {
+		return Entry{}, false
+	}
+	return Entry{
+		Cluster:    cluster,
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Namespace:  object.GetNamespace(),
+		Name:       object.GetName(),
+		UID:        object.GetUID(),
+	}, true
+}
+
+func (e Entry) String() string {
+	return fmt.Sprintf("%s/%s %s", e.APIVersion, e.Kind, client.Name(client.ObjectKey{Namespace: e.Namespace, Name: e.Name}))
+}
+
+type Action string
+
+const (
+	ActionRecord Action = "record"
+	ActionPrune  Action = "prune"
+)
+
+type line struct {
+	Action Action `json:"action"`
+	Entry
+}
+
+// Journal keeps track of the objects registered for cleanup.
+type Journal interface {
+	// Record adds an entry to the journal.
+	Record(Entry)
+	// Prune removes an entry from the journal once the object has been deleted.
+	Prune(Entry)
+}
+
+// File is a journal persisted to a file, one JSON document per line.
+// Every change is written immediately so that the file survives an interrupted run.
+type File struct {
+	path     string
+	appender *ndjson.Appender
+}
+
+// Open opens the journal at path, entries are appended to the ones already present.
+func Open(path string) (*File, error) {
+	appender, err := ndjson.Open(path, false)
+	if err != nil {
+		return nil, err
+	}
+	return &File{path: path, appender: appender}, nil
+}
+
+func (f *File) Record(entry Entry) {
+	f.appender.Append(line{Action: ActionRecord, Entry: entry})
+}
+
+func (f *File) Prune(entry Entry) {
+	f.appender.Append(line{Action: ActionPrune, Entry: entry})
+}
+
+// Close closes the file and compacts the journal, it returns the first error encountered while writing entries.
+func (f *File) Close() error {
+	if err := f.appender.Close(); err != nil {
+		return err
+	}
+	entries, err := Load(f.path)
+	if err != nil {
+		return err
+	}
+	return Write(f.path, entries)
+}
+
+// Load reads the journal at path, a missing file is an empty journal.
+func Load(path string) ([]Entry, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+	return Read(file)
+}
+
+// Read reads a journal and returns the entries recorded and not pruned, in the order they were recorded.
+// A truncated last line (interrupted write) is ignored.
+func Read(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	err := ndjson.Read(r, func(l line) error {
+		switch l.Action {
+		case ActionRecord:
+			entries = append(entries, l.Entry)
+		case ActionPrune:
+			for i := range entries {
+				if entries[i] == l.Entry {
+					entries = append(entries[:i], entries[i+1:]...)
+					break
+				}
+			}
+		default:
+			return fmt.Errorf("invalid action %q", l.Action)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid journal: %w", err)
+	}
+	return entries, nil
+}
+
+// Write replaces the journal at path with the given entries, the file is removed when there is no entry.
+func Write(path string, entries []Entry) error {
+	if len(entries) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	appender, err := ndjson.Open(tmp.Name(), true)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		appender.Append(line{Action: ActionRecord, Entry: entry})
+	}
+	if err := appender.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
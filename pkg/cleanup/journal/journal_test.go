@@ -0,0 +1,129 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNewEntry(t *testing.T) {
+	var deployment unstructured.Unstructured
+	deployment.SetAPIVersion("apps/v1")
+	deployment.SetKind("Deployment")
+	deployment.SetNamespace("foo")
+	deployment.SetName("bar")
+	deployment.SetUID("uid")
+	tests := []struct {
+		name   string
+		object client.Object
+		want   Entry
+		wantOk bool
+	}{{
+		name:   "unstructured",
+		object: &deployment,
+		want:   Entry{Cluster: "https://cluster", APIVersion: "apps/v1", Kind: "Deployment", Namespace: "foo", Name: "bar", UID: "uid"},
+		wantOk: true,
+	}, {
+		name:   "typed",
+		object: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "foo"}},
+		want:   Entry{Cluster: "https://cluster", APIVersion: "v1", Kind: "Namespace", Name: "foo"},
+		wantOk: true,
+	}, {
+		name:   "unknown type",
+		object: &unstructured.Unstructured{},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NewEntry("https://cluster", tt.object)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir", "journal.jsonl")
+	a := Entry{Cluster: "https://cluster", APIVersion: "v1", Kind: "Namespace", Name: "a"}
+	b := Entry{Cluster: "https://cluster", APIVersion: "v1", Kind: "ConfigMap", Namespace: "a", Name: "b", UID: "uid"}
+	file, err := Open(path)
+	assert.NoError(t, err)
+	file.Record(a)
+	file.Record(b)
+	// entries are persisted as soon as they are recorded
+	entries, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{a, b}, entries)
+	file.Prune(b)
+	assert.NoError(t, file.Close())
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"action":"record","cluster":"https://cluster","apiVersion":"v1","kind":"Namespace","name":"a"}`+"\n", string(data))
+	// entries are appended to the existing journal
+	file, err = Open(path)
+	assert.NoError(t, err)
+	file.Record(b)
+	file.Prune(a)
+	file.Prune(b)
+	assert.NoError(t, file.Close())
+	// the journal is removed when empty
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Entry
+		wantErr bool
+	}{{
+		name: "empty",
+	}, {
+		name: "prune",
+		input: `{"action":"record","cluster":"c","apiVersion":"v1","kind":"Namespace","name":"a"}
+{"action":"record","cluster":"c","apiVersion":"v1","kind":"Namespace","name":"b"}
+
+{"action":"prune","cluster":"c","apiVersion":"v1","kind":"Namespace","name":"a"}
+`,
+		want: []Entry{{Cluster: "c", APIVersion: "v1", Kind: "Namespace", Name: "b"}},
+	}, {
+		name: "truncated last line",
+		input: `{"action":"record","cluster":"c","apiVersion":"v1","kind":"Namespace","name":"a"}
+{"action":"record","cluster":"c","apiV`,
+		want: []Entry{{Cluster: "c", APIVersion: "v1", Kind: "Namespace", Name: "a"}},
+	}, {
+		name: "invalid line",
+		input: `{"action":"record","cluster":"c","apiV
+{"action":"record","cluster":"c","apiVersion":"v1","kind":"Namespace","name":"a"}
+`,
+		wantErr: true,
+	}, {
+		name:    "invalid action",
+		input:   `{"action":"foo","cluster":"c","apiVersion":"v1","kind":"Namespace","name":"a"}`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(strings.NewReader(tt.input))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLoad_missing(t *testing.T) {
+	entries, err := Load(filepath.Join(t.TempDir(), "missing.jsonl"))
+	assert.NoError(t, err)
+	assert.Nil(t, entries)
+}
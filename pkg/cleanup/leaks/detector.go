@@ -0,0 +1,127 @@
+package leaks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type Detector interface {
+	// Detect returns the resources that did not exist when the detector was created.
+	Detect(ctx context.Context) ([]unstructured.Unstructured, error)
+}
+
+// New creates a detector and takes the initial snapshot of the given resources.
+func New(ctx context.Context, client client.Client, resources ...v1alpha2.LeakResource) (Detector, error) {
+	d := &detector{
+		client:    client,
+		resources: resources,
+		before:    map[types.UID]struct{}{},
+	}
+	objects, err := d.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, object := range objects {
+		d.before[object.GetUID()] = struct{}{}
+	}
+	return d, nil
+}
+
+// InNamespace scopes the resources to a namespace, resources are listed in this namespace only.
+// Cluster scoped resources and resources restricted to another namespace can't be scoped, they are returned as skipped.
+func InNamespace(c client.Client, namespace string, resources ...v1alpha2.LeakResource) (scoped, skipped []v1alpha2.LeakResource, _ error) {
+	for _, resource := range resources {
+		if namespace == "" || (resource.Namespace != "" && resource.Namespace != namespace) {
+			skipped = append(skipped, resource)
+			continue
+		}
+		var obj unstructured.Unstructured
+		obj.SetAPIVersion(resource.APIVersion)
+		obj.SetKind(resource.Kind)
+		namespaced, err := c.IsObjectNamespaced(&obj)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get scope of %s/%s: %w", resource.APIVersion, resource.Kind, err)
+		}
+		if !namespaced {
+			skipped = append(skipped, resource)
+			continue
+		}
+		resource.Namespace = namespace
+		scoped = append(scoped, resource)
+	}
+	return scoped, skipped, nil
+}
+
+type detector struct {
+	client    client.Client
+	resources []v1alpha2.LeakResource
+	before    map[types.UID]struct{}
+}
+
+func (d *detector) Detect(ctx context.Context) ([]unstructured.Unstructured, error) {
+	objects, err := d.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var leaks []unstructured.Unstructured
+	for _, object := range objects {
+		// objects being deleted are not considered leaked
+		if object.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if _, ok := d.before[object.GetUID()]; !ok {
+			leaks = append(leaks, object)
+		}
+	}
+	return leaks, nil
+}
+
+func (d *detector) snapshot(ctx context.Context) ([]unstructured.Unstructured, error) {
+	var objects []unstructured.Unstructured
+	for _, resource := range d.resources {
+		var list unstructured.UnstructuredList
+		list.SetAPIVersion(resource.APIVersion)
+		list.SetKind(resource.Kind + "List")
+		var opts []client.ListOption
+		if resource.Namespace != "" {
+			opts = append(opts, client.InNamespace(resource.Namespace))
+		}
+		if resource.Selector != "" {
+			selector, err := labels.Parse(resource.Selector)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, client.MatchingSelector{Selector: selector})
+		}
+		if err := d.client.List(ctx, &list, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list %s/%s: %w", resource.APIVersion, resource.Kind, err)
+		}
+		for _, item := range list.Items {
+			// list items don't always carry their type
+			item.SetAPIVersion(resource.APIVersion)
+			item.SetKind(resource.Kind)
+			objects = append(objects, item)
+		}
+	}
+	return objects, nil
+}
+
+// LeakError is returned when a test leaked resources.
+type LeakError struct {
+	Objects []unstructured.Unstructured
+}
+
+func (e *LeakError) Error() string {
+	names := make([]string, 0, len(e.Objects))
+	for _, object := range e.Objects {
+		names = append(names, object.GetAPIVersion()+"/"+object.GetKind()+" "+client.Name(client.Key(&object)))
+	}
+	return fmt.Sprintf("%d resource(s) leaked: %s", len(e.Objects), strings.Join(names, ", "))
+}
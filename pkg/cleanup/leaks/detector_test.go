@@ -0,0 +1,195 @@
+package leaks
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func object(kind, namespace, name string, uid types.UID, labels map[string]string) unstructured.Unstructured {
+	var obj unstructured.Unstructured
+	obj.SetAPIVersion("v1")
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetUID(uid)
+	obj.SetLabels(labels)
+	return obj
+}
+
+// fakeClient returns a client listing the objects returned by the given function, it honors namespaces and label selectors.
+func fakeClient(objects func() []unstructured.Unstructured) *tclient.FakeClient {
+	return &tclient.FakeClient{
+		ListFn: func(_ context.Context, _ int, list client.ObjectList, opts ...client.ListOption) error {
+			var options ctrlclient.ListOptions
+			options.ApplyOptions(opts)
+			kind := strings.TrimSuffix(list.GetObjectKind().GroupVersionKind().Kind, "List")
+			l := list.(*unstructured.UnstructuredList)
+			for _, item := range objects() {
+				if item.GetKind() != kind {
+					continue
+				}
+				if options.Namespace != "" && item.GetNamespace() != options.Namespace {
+					continue
+				}
+				if options.LabelSelector != nil && !options.LabelSelector.Matches(labels.Set(item.GetLabels())) {
+					continue
+				}
+				// list items don't carry their type
+				item := *item.DeepCopy()
+				item.SetAPIVersion("")
+				item.SetKind("")
+				l.Items = append(l.Items, item)
+			}
+			return nil
+		},
+	}
+}
+
+func Test_detector(t *testing.T) {
+	existing := object("ConfigMap", "foo", "existing", "1", nil)
+	leaked := object("ConfigMap", "foo", "leaked", "2", map[string]string{"app": "foo"})
+	deleting := object("ConfigMap", "foo", "deleting", "3", nil)
+	deleting.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+	other := object("ConfigMap", "bar", "other", "4", nil)
+	secret := object("Secret", "foo", "secret", "5", nil)
+	tests := []struct {
+		name      string
+		resources []v1alpha2.LeakResource
+		want      []unstructured.Unstructured
+	}{{
+		name: "none",
+	}, {
+		name:      "all namespaces",
+		resources: []v1alpha2.LeakResource{{APIVersion: "v1", Kind: "ConfigMap"}},
+		want:      []unstructured.Unstructured{leaked, other},
+	}, {
+		name:      "namespace",
+		resources: []v1alpha2.LeakResource{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "foo"}},
+		want:      []unstructured.Unstructured{leaked},
+	}, {
+		name:      "selector",
+		resources: []v1alpha2.LeakResource{{APIVersion: "v1", Kind: "ConfigMap", Selector: "app=foo"}},
+		want:      []unstructured.Unstructured{leaked},
+	}, {
+		name: "kinds",
+		resources: []v1alpha2.LeakResource{
+			{APIVersion: "v1", Kind: "ConfigMap", Namespace: "bar"},
+			{APIVersion: "v1", Kind: "Secret"},
+		},
+		want: []unstructured.Unstructured{other, secret},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := []unstructured.Unstructured{existing}
+			d, err := New(context.TODO(), fakeClient(func() []unstructured.Unstructured { return objects }), tt.resources...)
+			assert.NoError(t, err)
+			objects = []unstructured.Unstructured{existing, leaked, deleting, other, secret}
+			got, err := d.Detect(context.TODO())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_detector_errors(t *testing.T) {
+	_, err := New(context.TODO(), fakeClient(nil), v1alpha2.LeakResource{APIVersion: "v1", Kind: "ConfigMap", Selector: "="})
+	assert.Error(t, err)
+	_, err = New(context.TODO(), &tclient.FakeClient{
+		ListFn: func(context.Context, int, client.ObjectList, ...client.ListOption) error {
+			return errors.New("dummy")
+		},
+	}, v1alpha2.LeakResource{APIVersion: "v1", Kind: "ConfigMap"})
+	assert.EqualError(t, err, "failed to list v1/ConfigMap: dummy")
+}
+
+func TestInNamespace(t *testing.T) {
+	c := &tclient.FakeClient{
+		IsObjectNamespacedFn: func(_ int, obj runtime.Object) (bool, error) {
+			switch obj.GetObjectKind().GroupVersionKind().Kind {
+			case "Namespace":
+				return false, nil
+			case "Foo":
+				return false, errors.New("dummy")
+			}
+			return true, nil
+		},
+	}
+	configMaps := v1alpha2.LeakResource{APIVersion: "v1", Kind: "ConfigMap", Selector: "app=foo"}
+	namespaces := v1alpha2.LeakResource{APIVersion: "v1", Kind: "Namespace"}
+	shared := v1alpha2.LeakResource{APIVersion: "v1", Kind: "Secret", Namespace: "shared"}
+	tests := []struct {
+		name        string
+		namespace   string
+		resources   []v1alpha2.LeakResource
+		wantScoped  []v1alpha2.LeakResource
+		wantSkipped []v1alpha2.LeakResource
+		wantErr     bool
+	}{{
+		name:      "none",
+		namespace: "foo",
+	}, {
+		name:       "namespaced",
+		namespace:  "foo",
+		resources:  []v1alpha2.LeakResource{configMaps},
+		wantScoped: []v1alpha2.LeakResource{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "foo", Selector: "app=foo"}},
+	}, {
+		name:        "cluster scoped",
+		namespace:   "foo",
+		resources:   []v1alpha2.LeakResource{configMaps, namespaces},
+		wantScoped:  []v1alpha2.LeakResource{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "foo", Selector: "app=foo"}},
+		wantSkipped: []v1alpha2.LeakResource{namespaces},
+	}, {
+		name:        "other namespace",
+		namespace:   "foo",
+		resources:   []v1alpha2.LeakResource{shared},
+		wantSkipped: []v1alpha2.LeakResource{shared},
+	}, {
+		name:       "same namespace",
+		namespace:  "shared",
+		resources:  []v1alpha2.LeakResource{shared},
+		wantScoped: []v1alpha2.LeakResource{shared},
+	}, {
+		name:        "no namespace",
+		resources:   []v1alpha2.LeakResource{configMaps, namespaces},
+		wantSkipped: []v1alpha2.LeakResource{configMaps, namespaces},
+	}, {
+		name:      "error",
+		namespace: "foo",
+		resources: []v1alpha2.LeakResource{{APIVersion: "v1", Kind: "Foo"}},
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scoped, skipped, err := InNamespace(c, tt.namespace, tt.resources...)
+			if tt.wantErr {
+				assert.EqualError(t, err, "failed to get scope of v1/Foo: dummy")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantScoped, scoped)
+			assert.Equal(t, tt.wantSkipped, skipped)
+		})
+	}
+}
+
+func TestLeakError(t *testing.T) {
+	err := &LeakError{Objects: []unstructured.Unstructured{
+		object("ConfigMap", "foo", "bar", "1", nil),
+		object("Namespace", "", "baz", "2", nil),
+	}}
+	assert.Equal(t, "2 resource(s) leaked: v1/ConfigMap foo/bar, v1/Namespace baz", err.Error())
+}
@@ -0,0 +1,22 @@
+package client
+
+// WithCluster returns a client carrying the identity of the cluster it is connected to.
+func WithCluster(inner Client, cluster string) Client {
+	return &clusterClient{
+		Client:  inner,
+		cluster: cluster,
+	}
+}
+
+// Cluster returns the identity of the cluster a client is connected to, it is empty when unknown.
+func Cluster(client Client) string {
+	if c, ok := client.(*clusterClient); ok {
+		return c.cluster
+	}
+	return ""
+}
+
+type clusterClient struct {
+	Client
+	cluster string
+}
@@ -18,6 +18,7 @@ type (
 	InNamespace       = ctrlclient.InNamespace
 	PropagationPolicy = ctrlclient.PropagationPolicy
 	MatchingLabels    = ctrlclient.MatchingLabels
+	MatchingSelector  = ctrlclient.MatchingLabelsSelector
 )
 
 var RawPatch = ctrlclient.RawPatch
@@ -0,0 +1,115 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/cleanup/journal"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/client/simple"
+	"github.com/kyverno/chainsaw/pkg/loaders/config"
+	restutils "github.com/kyverno/chainsaw/pkg/utils/rest"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type options struct {
+	journal             string
+	timeout             metav1.Duration
+	clusters            []string
+	kubeConfigOverrides clientcmd.ConfigOverrides
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Delete resources left behind by interrupted test runs",
+		Long: `Delete resources left behind by interrupted test runs.
+Resources are read from the cleanup journal written by chainsaw test --cleanup-journal,
+they are deleted on the cluster with the same API server address and pruned from the journal once deleted.`,
+		Example:      "  chainsaw cleanup --journal .chainsaw/journal.jsonl --cluster ./kubeconfig-2:kind-2",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			out := cmd.OutOrStdout()
+			entries, err := journal.Load(options.journal)
+			if err != nil {
+				return err
+			}
+			if len(entries) == 0 {
+				fmt.Fprintln(out, "Nothing to clean up.")
+				return nil
+			}
+			configs, err := options.configs()
+			if err != nil {
+				return err
+			}
+			clients := map[string]client.Client{}
+			for _, config := range configs {
+				if _, ok := clients[config.Host]; ok {
+					continue
+				}
+				c, err := simple.New(config)
+				if err != nil {
+					return err
+				}
+				clients[config.Host] = c
+			}
+			remaining, errs := replay(context.Background(), clients, options.timeout.Duration, entries...)
+			for _, err := range errs {
+				fmt.Fprintln(out, "-", err)
+			}
+			if err := journal.Write(options.journal, remaining); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Deleted %d resource(s), %d left in journal.\n", len(entries)-len(remaining), len(remaining))
+			if len(remaining) != 0 {
+				return errors.New("some resources were not deleted")
+			}
+			return nil
+		},
+	}
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		panic(err)
+	}
+	cmd.Flags().StringVar(&options.journal, "journal", "", "Cleanup journal file")
+	cmd.Flags().DurationVar(&options.timeout.Duration, "cleanup-timeout", config.Spec.Timeouts.Cleanup.Duration, "The time to wait for resources to be deleted")
+	cmd.Flags().StringSliceVar(&options.clusters, "cluster", nil, "Register cluster (format <kubeconfig path>:[context name])")
+	clientcmd.BindOverrideFlags(&options.kubeConfigOverrides, cmd.Flags(), clientcmd.RecommendedConfigOverrideFlags("kube-"))
+	if err := cmd.MarkFlagRequired("journal"); err != nil {
+		log.Println("WARNING", err)
+	}
+	return cmd
+}
+
+// configs returns the configs of the default cluster and of the registered clusters.
+func (o options) configs() ([]*rest.Config, error) {
+	defaultConfig, err := restutils.DefaultConfig(o.kubeConfigOverrides)
+	if err != nil {
+		return nil, err
+	}
+	configs := []*rest.Config{defaultConfig}
+	for _, cluster := range o.clusters {
+		parts := strings.Split(cluster, ":")
+		if len(parts) > 2 {
+			return nil, fmt.Errorf("failed to decode cluster argument %s", cluster)
+		}
+		var overrides clientcmd.ConfigOverrides
+		if len(parts) == 2 {
+			overrides.CurrentContext = parts[1]
+		}
+		config, err := restutils.Config(parts[0], overrides)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
@@ -0,0 +1,82 @@
+package cleanup
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/commands/root"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Execute(t *testing.T) {
+	basePath := "../../../testdata/commands/cleanup"
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+		out     string
+	}{{
+		name: "help",
+		args: []string{
+			"cleanup",
+			"--help",
+		},
+		out:     filepath.Join(basePath, "help.txt"),
+		wantErr: false,
+	}, {
+		name: "without journal",
+		args: []string{
+			"cleanup",
+		},
+		wantErr: true,
+	}, {
+		name: "empty journal",
+		args: []string{
+			"cleanup",
+			"--journal",
+			filepath.Join(t.TempDir(), "journal.jsonl"),
+		},
+		out:     filepath.Join(basePath, "empty.txt"),
+		wantErr: false,
+	}, {
+		name: "unknow flag",
+		args: []string{
+			"cleanup",
+			"--foo",
+		},
+		wantErr: true,
+	}, {
+		name: "unknow arg",
+		args: []string{
+			"cleanup",
+			"foo",
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := root.Command()
+			cmd.AddCommand(Command())
+			assert.NotNil(t, cmd)
+			cmd.SetArgs(tt.args)
+			out := bytes.NewBufferString("")
+			cmd.SetOut(out)
+			err := cmd.Execute()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			actual, err := io.ReadAll(out)
+			assert.NoError(t, err)
+			if tt.out != "" {
+				expected, err := os.ReadFile(tt.out)
+				assert.NoError(t, err)
+				assert.Equal(t, string(expected), string(actual))
+			}
+		})
+	}
+}
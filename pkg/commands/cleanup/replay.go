@@ -0,0 +1,80 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
+	"github.com/kyverno/chainsaw/pkg/cleanup/journal"
+	"github.com/kyverno/chainsaw/pkg/client"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// replay deletes the journal entries with the clients of their clusters (by API server address),
+// it returns the entries that could not be deleted.
+func replay(ctx context.Context, clients map[string]client.Client, timeout time.Duration, entries ...journal.Entry) ([]journal.Entry, []error) {
+	deleted := &prunedEntries{entries: map[journal.Entry]struct{}{}}
+	c := cleaner.New(timeout, nil, metav1.DeletePropagationBackground, nil, deleted)
+	var errs []error
+	for _, entry := range entries {
+		inner, ok := clients[entry.Cluster]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: no client for cluster %s", entry, entry.Cluster))
+			continue
+		}
+		var object unstructured.Unstructured
+		object.SetAPIVersion(entry.APIVersion)
+		object.SetKind(entry.Kind)
+		object.SetNamespace(entry.Namespace)
+		object.SetName(entry.Name)
+		if err := inner.Get(ctx, client.Key(&object), &object); err != nil {
+			if !kerrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("%s: %w", entry, err))
+				continue
+			}
+			deleted.Prune(entry)
+			continue
+		}
+		// an object with the same name was created since, the recorded one is gone
+		if entry.UID != "" && object.GetUID() != entry.UID {
+			deleted.Prune(entry)
+			continue
+		}
+		c.Add(client.WithCluster(inner, entry.Cluster), &object)
+	}
+	errs = append(errs, c.Run(ctx)...)
+	var remaining []journal.Entry
+	for _, entry := range entries {
+		if !deleted.has(entry) {
+			remaining = append(remaining, entry)
+		}
+	}
+	return remaining, errs
+}
+
+// prunedEntries is a journal collecting pruned entries, entries are identified regardless of their uid.
+type prunedEntries struct {
+	lock    sync.Mutex
+	entries map[journal.Entry]struct{}
+}
+
+func (p *prunedEntries) Record(journal.Entry) {}
+
+func (p *prunedEntries) Prune(entry journal.Entry) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	entry.UID = ""
+	p.entries[entry] = struct{}{}
+}
+
+func (p *prunedEntries) has(entry journal.Entry) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	entry.UID = ""
+	_, ok := p.entries[entry]
+	return ok
+}
@@ -0,0 +1,66 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/cleanup/journal"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// fakeClient returns a client serving objects by name with the given uids until they are deleted,
+// objects with a failed deletion are never deleted.
+func fakeClient(uids map[string]types.UID, failed ...string) *tclient.FakeClient {
+	var lock sync.Mutex
+	deleted := map[string]bool{}
+	return &tclient.FakeClient{
+		GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			lock.Lock()
+			defer lock.Unlock()
+			uid, ok := uids[key.Name]
+			if !ok || deleted[key.Name] {
+				return kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+			}
+			obj.SetUID(uid)
+			return nil
+		},
+		DeleteFn: func(_ context.Context, _ int, obj client.Object, _ ...client.DeleteOption) error {
+			for _, name := range failed {
+				if name == obj.GetName() {
+					return errors.New("dummy")
+				}
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			deleted[obj.GetName()] = true
+			return nil
+		},
+	}
+}
+
+func Test_replay(t *testing.T) {
+	entry := func(cluster, name string, uid types.UID) journal.Entry {
+		return journal.Entry{Cluster: cluster, APIVersion: "v1", Kind: "ConfigMap", Namespace: "foo", Name: name, UID: uid}
+	}
+	deleted := entry("https://a", "deleted", "1")
+	gone := entry("https://a", "gone", "2")
+	recreated := entry("https://a", "recreated", "3")
+	failed := entry("https://a", "failed", "4")
+	other := entry("https://b", "other", "5")
+	unknown := entry("https://c", "unknown", "6")
+	clients := map[string]client.Client{
+		"https://a": fakeClient(map[string]types.UID{"deleted": "1", "recreated": "33", "failed": "4"}, "failed"),
+		"https://b": fakeClient(map[string]types.UID{"other": "5"}),
+	}
+	remaining, errs := replay(context.TODO(), clients, time.Second, deleted, gone, recreated, failed, other, unknown)
+	assert.Equal(t, []journal.Entry{failed, unknown}, remaining)
+	assert.Len(t, errs, 2)
+}
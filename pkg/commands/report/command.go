@@ -0,0 +1,28 @@
+package report
+
+import (
+	"github.com/kyverno/chainsaw/pkg/commands/report/diff"
+	"github.com/kyverno/chainsaw/pkg/commands/report/merge"
+	"github.com/kyverno/chainsaw/pkg/commands/report/rebuild"
+	"github.com/kyverno/chainsaw/pkg/commands/report/stats"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "report",
+		Short:        "Work with test reports",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(
+		diff.Command(),
+		merge.Command(),
+		rebuild.Command(),
+		stats.Command(),
+	)
+	return cmd
+}
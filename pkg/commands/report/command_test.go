@@ -0,0 +1,180 @@
+package report
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/commands/root"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Execute(t *testing.T) {
+	basePath := "../../../testdata/commands/report"
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+		out     string
+	}{{
+		name: "help",
+		args: []string{
+			"report",
+			"--help",
+		},
+		out:     filepath.Join(basePath, "help.txt"),
+		wantErr: false,
+	}, {
+		name: "report",
+		args: []string{
+			"report",
+		},
+		out:     filepath.Join(basePath, "help.txt"),
+		wantErr: false,
+	}, {
+		name: "unknow flag",
+		args: []string{
+			"report",
+			"--foo",
+		},
+		wantErr: true,
+	}, {
+		name: "unknow arg",
+		args: []string{
+			"report",
+			"foo",
+		},
+		wantErr: true,
+	}, {
+		name: "rebuild without events",
+		args: []string{
+			"report",
+			"rebuild",
+		},
+		wantErr: true,
+	}, {
+		name: "rebuild with missing events",
+		args: []string{
+			"report",
+			"rebuild",
+			"--events",
+			filepath.Join(basePath, "missing.ndjson"),
+		},
+		wantErr: true,
+	}, {
+		name: "rebuild with unknown format",
+		args: []string{
+			"report",
+			"rebuild",
+			"--events",
+			filepath.Join(basePath, "events.ndjson"),
+			"--format",
+			"foo",
+		},
+		wantErr: true,
+	}, {
+		name: "rebuild",
+		args: []string{
+			"report",
+			"rebuild",
+			"--events",
+			filepath.Join(basePath, "events.ndjson"),
+			"--format",
+			"XML",
+			"--path",
+			t.TempDir(),
+		},
+		out:     filepath.Join(basePath, "rebuild.txt"),
+		wantErr: false,
+	}, {
+		name: "merge without report",
+		args: []string{
+			"report",
+			"merge",
+		},
+		wantErr: true,
+	}, {
+		name: "merge",
+		args: []string{
+			"report",
+			"merge",
+			filepath.Join(basePath, "base.json"),
+			filepath.Join(basePath, "head.xml"),
+			"--path",
+			t.TempDir(),
+		},
+		out:     filepath.Join(basePath, "merge.txt"),
+		wantErr: false,
+	}, {
+		name: "diff with one report",
+		args: []string{
+			"report",
+			"diff",
+			filepath.Join(basePath, "base.json"),
+		},
+		wantErr: true,
+	}, {
+		name: "diff",
+		args: []string{
+			"report",
+			"diff",
+			filepath.Join(basePath, "base.json"),
+			filepath.Join(basePath, "head.xml"),
+		},
+		out:     filepath.Join(basePath, "diff.txt"),
+		wantErr: false,
+	}, {
+		name: "diff with new failures",
+		args: []string{
+			"report",
+			"diff",
+			filepath.Join(basePath, "base.json"),
+			filepath.Join(basePath, "head.xml"),
+			"--fail-on-new-failures",
+		},
+		wantErr: true,
+	}, {
+		name: "stats with missing report",
+		args: []string{
+			"report",
+			"stats",
+			filepath.Join(basePath, "missing.json"),
+		},
+		wantErr: true,
+	}, {
+		name: "stats",
+		args: []string{
+			"report",
+			"stats",
+			filepath.Join(basePath, "base.json"),
+			filepath.Join(basePath, "head.xml"),
+		},
+		out:     filepath.Join(basePath, "stats.txt"),
+		wantErr: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := root.Command()
+			cmd.AddCommand(Command())
+			assert.NotNil(t, cmd)
+			cmd.SetArgs(tt.args)
+			out := bytes.NewBufferString("")
+			cmd.SetOut(out)
+			err := cmd.Execute()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			actual, err := io.ReadAll(out)
+			assert.NoError(t, err)
+			if tt.out != "" {
+				expected, err := os.ReadFile(tt.out)
+				assert.NoError(t, err)
+				assert.Equal(t, string(expected), string(actual))
+			}
+		})
+	}
+}
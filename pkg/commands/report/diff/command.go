@@ -0,0 +1,60 @@
+package diff
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/kyverno/chainsaw/pkg/report"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	failOnNewFailures bool
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "diff <base> <head>",
+		Short:        "Compare test reports from two runs",
+		Long:         "Compare JSON or JUnit test reports from two runs and show newly failing, newly passing and flaky tests.\nA test is flaky when it both passed and failed in one of the runs,\ntests from merged reports are compared per source.",
+		Example:      "  chainsaw report diff main.json pr.json",
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			base, err := report.Load(args[0])
+			if err != nil {
+				return err
+			}
+			head, err := report.Load(args[1])
+			if err != nil {
+				return err
+			}
+			diffs := report.Diff(*base, *head)
+			out := cmd.OutOrStdout()
+			if len(diffs) == 0 {
+				fmt.Fprintln(out, "No difference found")
+				return nil
+			}
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "STATUS\tTEST\tBASE\tHEAD")
+			var newFailures int
+			for _, diff := range diffs {
+				if diff.Status == report.DiffNewlyFailing {
+					newFailures++
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", diff.Status, diff.Test, diff.Base, diff.Head)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			if options.failOnNewFailures && newFailures != 0 {
+				return errors.New("found newly failing tests")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&options.failOnNewFailures, "fail-on-new-failures", false, "Exit with an error when tests are newly failing")
+	return cmd
+}
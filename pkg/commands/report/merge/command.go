@@ -0,0 +1,52 @@
+package merge
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/kyverno/chainsaw/pkg/report"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	format string
+	path   string
+	name   string
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "merge <report>...",
+		Short:        "Merge test reports from several runs",
+		Long:         "Merge JSON and JUnit test reports from several runs into a single report.\nJUnit reports are detected with the .xml extension.\nTests are labelled with the name of the file they come from, without the extension.",
+		Example:      "  chainsaw report merge cluster-a.json cluster-b.json --format HTML",
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var runs []report.Run
+			for _, file := range args {
+				in, err := report.Load(file)
+				if err != nil {
+					return err
+				}
+				runs = append(runs, report.Run{
+					Source: strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)),
+					Report: *in,
+				})
+			}
+			out := report.Merge(options.name, runs...)
+			if err := report.Write(out, v1alpha2.ReportFormatType(options.format), options.path, options.name); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Merged %d report(s) with %d test(s) (%d passed, %d failed, %d skipped)\n", len(runs), len(out.Tests), out.Summary.Passed, out.Summary.Failed, out.Summary.Skipped)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&options.format, "format", string(v1alpha2.JSONFormat), "Test report format (JSON|XML|CSV|HTML)")
+	cmd.Flags().StringVar(&options.path, "path", "", "The path of the report to create")
+	cmd.Flags().StringVar(&options.name, "name", "chainsaw-report", "The name of the report to create")
+	return cmd
+}
@@ -0,0 +1,55 @@
+package rebuild
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/kyverno/chainsaw/pkg/report"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	events string
+	format string
+	path   string
+	name   string
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "rebuild",
+		Short:        "Rebuild a test report from an event log",
+		Long:         "Rebuild a test report from the event log written by `chainsaw test --report-events`.\nTests, steps and operations that never completed (the run was interrupted) are reported as failed.",
+		Example:      "  chainsaw report rebuild --events chainsaw-events.ndjson --format XML",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			events, err := report.LoadEvents(options.events)
+			if err != nil {
+				return err
+			}
+			out, err := report.Rebuild(events)
+			if err != nil {
+				return err
+			}
+			if options.name == "" {
+				options.name = out.Name
+			}
+			if err := report.Write(out, v1alpha2.ReportFormatType(options.format), options.path, options.name); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Rebuilt report with %d test(s) (%d passed, %d failed, %d skipped)\n", len(out.Tests), out.Summary.Passed, out.Summary.Failed, out.Summary.Skipped)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&options.events, "events", "", "The event log to rebuild the report from")
+	cmd.Flags().StringVar(&options.format, "format", string(v1alpha2.JSONFormat), "Test report format (JSON|XML|CSV|HTML)")
+	cmd.Flags().StringVar(&options.path, "path", "", "The path of the report to create")
+	cmd.Flags().StringVar(&options.name, "name", "", "The name of the report to create (defaults to the name recorded in the event log)")
+	if err := cmd.MarkFlagRequired("events"); err != nil {
+		log.Println("WARNING", err)
+	}
+	return cmd
+}
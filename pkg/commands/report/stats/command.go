@@ -0,0 +1,37 @@
+package stats
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/kyverno/chainsaw/pkg/report"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "stats <report>...",
+		Short:        "Show pass rate and duration percentiles per test",
+		Long:         "Show pass rate and duration percentiles per test from JSON or JUnit test reports.\nWhen several reports are given they are considered runs of the same suite,\ntests from merged reports are grouped by the source they were labelled with.",
+		Example:      "  chainsaw report stats run-1.json run-2.json run-3.xml",
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var runs []report.Run
+			for _, file := range args {
+				in, err := report.Load(file)
+				if err != nil {
+					return err
+				}
+				runs = append(runs, report.Run{Report: *in})
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "TEST\tRUNS\tPASS RATE\tP50\tP90\tP99")
+			for _, stats := range report.Stats(report.Merge("", runs...)) {
+				fmt.Fprintf(w, "%s\t%s\t%.1f%%\t%s\t%s\t%s\n", stats.Test, stats.Outcomes, stats.PassRate*100, stats.P50, stats.P90, stats.P99)
+			}
+			return w.Flush()
+		},
+	}
+	return cmd
+}
@@ -3,13 +3,16 @@ package commands
 import (
 	"github.com/kyverno/chainsaw/pkg/commands/assert"
 	"github.com/kyverno/chainsaw/pkg/commands/build"
+	"github.com/kyverno/chainsaw/pkg/commands/cleanup"
 	"github.com/kyverno/chainsaw/pkg/commands/create"
 	"github.com/kyverno/chainsaw/pkg/commands/docs"
 	"github.com/kyverno/chainsaw/pkg/commands/export"
 	"github.com/kyverno/chainsaw/pkg/commands/lint"
 	"github.com/kyverno/chainsaw/pkg/commands/migrate"
 	"github.com/kyverno/chainsaw/pkg/commands/renovate"
+	"github.com/kyverno/chainsaw/pkg/commands/report"
 	"github.com/kyverno/chainsaw/pkg/commands/root"
+	"github.com/kyverno/chainsaw/pkg/commands/template"
 	"github.com/kyverno/chainsaw/pkg/commands/test"
 	"github.com/kyverno/chainsaw/pkg/commands/version"
 	"github.com/spf13/cobra"
@@ -20,12 +23,15 @@ func RootCommand() *cobra.Command {
 	cmd.AddCommand(
 		assert.Command(),
 		build.Command(),
+		cleanup.Command(),
 		create.Command(),
 		docs.Command(),
 		export.Command(),
 		lint.Command(),
 		migrate.Command(),
 		renovate.Command(),
+		report.Command(),
+		template.Command(),
 		test.Command(),
 		version.Command(),
 	)
@@ -0,0 +1,26 @@
+package template
+
+import (
+	"github.com/kyverno/chainsaw/pkg/commands/template/list"
+	"github.com/kyverno/chainsaw/pkg/commands/template/pull"
+	"github.com/kyverno/chainsaw/pkg/commands/template/push"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "template",
+		Short:        "Manage remote step templates",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(
+		list.Command(),
+		pull.Command(),
+		push.Command(),
+	)
+	return cmd
+}
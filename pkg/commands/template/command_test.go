@@ -0,0 +1,99 @@
+package template
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/commands/root"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Execute(t *testing.T) {
+	basePath := "../../../testdata/commands/template"
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+		out     string
+	}{{
+		name: "help",
+		args: []string{
+			"template",
+			"--help",
+		},
+		out:     filepath.Join(basePath, "help.txt"),
+		wantErr: false,
+	}, {
+		name: "template",
+		args: []string{
+			"template",
+		},
+		out:     filepath.Join(basePath, "help.txt"),
+		wantErr: false,
+	}, {
+		name: "unknow flag",
+		args: []string{
+			"template",
+			"--foo",
+		},
+		wantErr: true,
+	}, {
+		name: "unknow arg",
+		args: []string{
+			"template",
+			"foo",
+		},
+		wantErr: true,
+	}, {
+		name: "pull without reference",
+		args: []string{
+			"template",
+			"pull",
+		},
+		wantErr: true,
+	}, {
+		name: "push without reference",
+		args: []string{
+			"template",
+			"push",
+			"create.yaml",
+		},
+		wantErr: true,
+	}, {
+		name: "list",
+		args: []string{
+			"template",
+			"list",
+			"--cache-dir",
+			"../../../testdata/commands/template/cache",
+		},
+		out:     filepath.Join(basePath, "list.txt"),
+		wantErr: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := root.Command()
+			cmd.AddCommand(Command())
+			assert.NotNil(t, cmd)
+			cmd.SetArgs(tt.args)
+			out := bytes.NewBufferString("")
+			cmd.SetOut(out)
+			err := cmd.Execute()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			actual, err := io.ReadAll(out)
+			assert.NoError(t, err)
+			if tt.out != "" {
+				expected, err := os.ReadFile(tt.out)
+				assert.NoError(t, err)
+				assert.Equal(t, string(expected), string(actual))
+			}
+		})
+	}
+}
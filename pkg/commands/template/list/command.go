@@ -0,0 +1,33 @@
+package list
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/kyverno/chainsaw/pkg/loaders/steptemplate/remote"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options remote.Options
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List step templates pinned in the local cache",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			entries, err := remote.NewCache(options).List()
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "REFERENCE\tDIGEST\tPULLED")
+			for _, entry := range entries {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Reference, entry.Digest, entry.Pulled.Format("2006-01-02T15:04:05Z"))
+			}
+			return w.Flush()
+		},
+	}
+	cmd.Flags().StringVar(&options.Dir, "cache-dir", "", "Step templates cache directory (defaults to the user cache directory)")
+	return cmd
+}
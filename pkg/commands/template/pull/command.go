@@ -0,0 +1,34 @@
+package pull
+
+import (
+	"fmt"
+
+	"github.com/kyverno/chainsaw/pkg/loaders/steptemplate/remote"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options remote.Options
+	cmd := &cobra.Command{
+		Use:          "pull <reference>...",
+		Short:        "Pull remote step templates into the local cache",
+		Example:      "  chainsaw template pull oci://ghcr.io/org/templates/create:v1\n  chainsaw template pull 'git::https://github.com/org/repo.git//templates/create.yaml?ref=v1'",
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			cache := remote.NewCache(options)
+			for _, ref := range args {
+				entry, err := cache.Pull(cmd.Context(), ref)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(out, "Pulled %s (%s)\n", entry.Reference, entry.Digest)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&options.Dir, "cache-dir", "", "Step templates cache directory (defaults to the user cache directory)")
+	cmd.Flags().BoolVar(&options.Insecure, "insecure", false, "Use plain http to talk to registries")
+	return cmd
+}
@@ -0,0 +1,45 @@
+package push
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kyverno/chainsaw/pkg/loaders/steptemplate"
+	"github.com/kyverno/chainsaw/pkg/loaders/steptemplate/remote"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options remote.Options
+	cmd := &cobra.Command{
+		Use:          "push <file> <reference>",
+		Short:        "Push a step template to an OCI registry",
+		Example:      "  chainsaw template push templates/create.yaml oci://localhost:5000/templates/create:v1",
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			content, err := os.ReadFile(filepath.Clean(args[0]))
+			if err != nil {
+				return err
+			}
+			templates, err := steptemplate.Parse(content, false)
+			if err != nil {
+				return fmt.Errorf("invalid step template %s: %w", args[0], err)
+			}
+			if len(templates) != 1 {
+				return fmt.Errorf("expected exactly one step template in %s, found %d", args[0], len(templates))
+			}
+			entry, err := remote.NewCache(options).Push(cmd.Context(), args[1], content)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Pushed %s (%s)\n", entry.Reference, entry.Digest)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&options.Dir, "cache-dir", "", "Step templates cache directory (defaults to the user cache directory)")
+	cmd.Flags().BoolVar(&options.Insecure, "insecure", false, "Use plain http to talk to registries")
+	return cmd
+}
@@ -10,10 +10,13 @@ import (
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
 	"github.com/kyverno/chainsaw/pkg/discovery"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
 	"github.com/kyverno/chainsaw/pkg/loaders/config"
+	"github.com/kyverno/chainsaw/pkg/loaders/steptemplate/remote"
 	"github.com/kyverno/chainsaw/pkg/loaders/values"
 	"github.com/kyverno/chainsaw/pkg/runner"
 	"github.com/kyverno/chainsaw/pkg/runner/failer"
+	"github.com/kyverno/chainsaw/pkg/runner/graph"
 	flagutils "github.com/kyverno/chainsaw/pkg/utils/flag"
 	fsutils "github.com/kyverno/chainsaw/pkg/utils/fs"
 	restutils "github.com/kyverno/chainsaw/pkg/utils/rest"
@@ -43,9 +46,13 @@ type options struct {
 	failFast                    bool
 	parallel                    int
 	repeatCount                 int
+	retryMaxAttempts            int
+	retryBackoff                metav1.Duration
+	retryOn                     []string
 	reportFormat                string
 	reportPath                  string
 	reportName                  string
+	reportEvents                string
 	namespace                   string
 	deletionPropagationPolicy   string
 	fullName                    bool
@@ -55,12 +62,20 @@ type options struct {
 	kubeConfigOverrides         clientcmd.ConfigOverrides
 	forceTerminationGracePeriod metav1.Duration
 	delayBeforeCleanup          metav1.Duration
+	forceFinalize               metav1.Duration
+	cleanupJournal              string
 	selector                    []string
 	noCluster                   bool
 	pauseOnFailure              bool
 	values                      []string
 	clusters                    []string
 	remarshal                   bool
+	verbosity                   int
+	preflight                   bool
+	templateCacheDir            string
+	templateOffline             bool
+	templateInsecure            bool
+	graph                       bool
 }
 
 func Command() *cobra.Command {
@@ -130,12 +145,32 @@ func Command() *cobra.Command {
 			if flagutils.IsSet(flags, "fail-fast") {
 				configuration.Spec.Execution.FailFast = options.failFast
 			}
+			if flagutils.IsSet(flags, "preflight") {
+				configuration.Spec.Preflight.Enabled = options.preflight
+			}
 			if flagutils.IsSet(flags, "parallel") {
 				configuration.Spec.Execution.Parallel = &options.parallel
 			}
 			if flagutils.IsSet(flags, "repeat-count") {
 				configuration.Spec.Execution.RepeatCount = &options.repeatCount
 			}
+			if flagutils.IsSet(flags, "retry-max-attempts") || flagutils.IsSet(flags, "retry-backoff") || flagutils.IsSet(flags, "retry-on") {
+				if configuration.Spec.Execution.Retry == nil {
+					configuration.Spec.Execution.Retry = &v1alpha2.RetryPolicy{}
+				}
+				if flagutils.IsSet(flags, "retry-max-attempts") {
+					configuration.Spec.Execution.Retry.MaxAttempts = options.retryMaxAttempts
+				}
+				if flagutils.IsSet(flags, "retry-backoff") {
+					configuration.Spec.Execution.Retry.Backoff = &options.retryBackoff
+				}
+				if flagutils.IsSet(flags, "retry-on") {
+					configuration.Spec.Execution.Retry.On = nil
+					for _, on := range options.retryOn {
+						configuration.Spec.Execution.Retry.On = append(configuration.Spec.Execution.Retry.On, v1alpha1.RetryOn(on))
+					}
+				}
+			}
 			if flagutils.IsSet(flags, "report-format") {
 				if configuration.Spec.Report == nil {
 					configuration.Spec.Report = &v1alpha2.ReportOptions{}
@@ -154,6 +189,12 @@ func Command() *cobra.Command {
 				}
 				configuration.Spec.Report.Name = options.reportName
 			}
+			if flagutils.IsSet(flags, "report-events") {
+				if configuration.Spec.Report == nil {
+					configuration.Spec.Report = &v1alpha2.ReportOptions{}
+				}
+				configuration.Spec.Report.Events = options.reportEvents
+			}
 			if flagutils.IsSet(flags, "namespace") {
 				configuration.Spec.Namespace.Name = options.namespace
 			}
@@ -175,6 +216,12 @@ func Command() *cobra.Command {
 			if flagutils.IsSet(flags, "cleanup-delay") {
 				configuration.Spec.Cleanup.DelayBeforeCleanup = &options.delayBeforeCleanup
 			}
+			if flagutils.IsSet(flags, "force-finalize") {
+				configuration.Spec.Cleanup.ForceFinalize = &options.forceFinalize
+			}
+			if flagutils.IsSet(flags, "cleanup-journal") {
+				configuration.Spec.Cleanup.Journal = options.cleanupJournal
+			}
 			if flagutils.IsSet(flags, "cluster") {
 				for _, cluster := range options.clusters {
 					parts1 := strings.Split(cluster, "=")
@@ -214,12 +261,18 @@ func Command() *cobra.Command {
 			fmt.Fprintf(out, "- TestDirs %v\n", options.testDirs)
 			fmt.Fprintf(out, "- SkipDelete %v\n", configuration.Spec.Cleanup.SkipDelete)
 			fmt.Fprintf(out, "- FailFast %v\n", configuration.Spec.Execution.FailFast)
+			if configuration.Spec.Preflight.Enabled {
+				fmt.Fprintf(out, "- Preflight %v\n", configuration.Spec.Preflight.Enabled)
+			}
 			if configuration.Spec.Report != nil {
 				fmt.Fprintf(out, "- ReportFormat '%v'\n", configuration.Spec.Report.Format)
 				fmt.Fprintf(out, "- ReportName '%v'\n", configuration.Spec.Report.Name)
 				if configuration.Spec.Report.Path != "" {
 					fmt.Fprintf(out, "- ReportPath '%v'\n", configuration.Spec.Report.Path)
 				}
+				if configuration.Spec.Report.Events != "" {
+					fmt.Fprintf(out, "- ReportEvents '%v'\n", configuration.Spec.Report.Events)
+				}
 			}
 			fmt.Fprintf(out, "- Namespace '%v'\n", configuration.Spec.Namespace.Name)
 			fmt.Fprintf(out, "- FullName %v\n", configuration.Spec.Discovery.FullName)
@@ -238,12 +291,27 @@ func Command() *cobra.Command {
 			if configuration.Spec.Execution.RepeatCount != nil {
 				fmt.Fprintf(out, "- RepeatCount %v\n", *configuration.Spec.Execution.RepeatCount)
 			}
+			if retry := configuration.Spec.Execution.Retry; retry != nil {
+				fmt.Fprintf(out, "- RetryMaxAttempts %v\n", retry.MaxAttempts)
+				if retry.Backoff != nil {
+					fmt.Fprintf(out, "- RetryBackoff %v\n", retry.Backoff.Duration)
+				}
+				if len(retry.On) != 0 {
+					fmt.Fprintf(out, "- RetryOn %v\n", retry.On)
+				}
+			}
 			if configuration.Spec.Execution.ForceTerminationGracePeriod != nil {
 				fmt.Fprintf(out, "- ForceTerminationGracePeriod %v\n", configuration.Spec.Execution.ForceTerminationGracePeriod.Duration)
 			}
 			if configuration.Spec.Cleanup.DelayBeforeCleanup != nil {
 				fmt.Fprintf(out, "- DelayBeforeCleanup %v\n", configuration.Spec.Cleanup.DelayBeforeCleanup.Duration)
 			}
+			if configuration.Spec.Cleanup.ForceFinalize != nil {
+				fmt.Fprintf(out, "- ForceFinalize %v\n", configuration.Spec.Cleanup.ForceFinalize.Duration)
+			}
+			if configuration.Spec.Cleanup.Journal != "" {
+				fmt.Fprintf(out, "- CleanupJournal %s\n", configuration.Spec.Cleanup.Journal)
+			}
 			if len(options.selector) != 0 {
 				fmt.Fprintf(out, "- Selector %v\n", options.selector)
 			}
@@ -272,7 +340,15 @@ func Command() *cobra.Command {
 				}
 				selector = parsed
 			}
-			tests, err := discovery.DiscoverTests(configuration.Spec.Discovery.TestFile, selector, options.remarshal, options.testDirs...)
+			if options.templateOffline {
+				fmt.Fprintf(out, "- TemplateOffline %v\n", options.templateOffline)
+			}
+			remote.SetDefault(remote.NewCache(remote.Options{
+				Dir:      options.templateCacheDir,
+				Offline:  options.templateOffline,
+				Insecure: options.templateInsecure,
+			}))
+			tests, err := discovery.DiscoverTests(cmd.Context(), configuration.Spec.Discovery.TestFile, selector, options.remarshal, options.testDirs...)
 			if err != nil {
 				return err
 			}
@@ -285,6 +361,13 @@ func Command() *cobra.Command {
 					testToRun = append(testToRun, test)
 				}
 			}
+			if options.graph {
+				dependencies, err := graph.Build(testToRun...)
+				if err != nil {
+					return err
+				}
+				return dependencies.WriteDot(out)
+			}
 			// loading tests
 			fmt.Fprintln(out, "Loading values...")
 			values, err := values.Load(options.values...)
@@ -302,12 +385,14 @@ func Command() *cobra.Command {
 				restConfig = cfg
 			}
 			ctx := failer.IntoContext(context.Background(), failer.New(options.pauseOnFailure))
+			ctx = logging.IntoVerbosity(ctx, logging.Verbosity(options.verbosity))
 			summary, err := runner.Run(ctx, restConfig, clock, configuration.Spec, values, testToRun...)
 			if summary != nil {
 				fmt.Fprintln(out, "Tests Summary...")
 				fmt.Fprintln(out, "- Passed  tests", summary.Passed())
 				fmt.Fprintln(out, "- Failed  tests", summary.Failed())
 				fmt.Fprintln(out, "- Skipped tests", summary.Skipped())
+				fmt.Fprintln(out, "- Flaky   tests", summary.Flaky())
 			}
 			if err != nil {
 				fmt.Fprintln(out, "Done with error.")
@@ -342,8 +427,12 @@ func Command() *cobra.Command {
 	cmd.Flags().StringVar(&options.excludeTestRegex, "exclude-test-regex", "", "Regular expression to exclude tests")
 	// execution options
 	cmd.Flags().BoolVar(&options.failFast, "fail-fast", false, "Stop the test upon encountering the first failure")
+	cmd.Flags().BoolVar(&options.preflight, "preflight", false, "Check configured clusters health before running tests")
 	cmd.Flags().IntVar(&options.parallel, "parallel", 0, "The maximum number of tests to run at once")
 	cmd.Flags().IntVar(&options.repeatCount, "repeat-count", 1, "Number of times to repeat each test")
+	cmd.Flags().IntVar(&options.retryMaxAttempts, "retry-max-attempts", 1, "Maximum number of times a failed test runs, tests passing after a retry are reported as flaky")
+	cmd.Flags().DurationVar(&options.retryBackoff.Duration, "retry-backoff", 0, "Delay before the first retry of a failed test, doubled for every following retry")
+	cmd.Flags().StringSliceVar(&options.retryOn, "retry-on", nil, "Only retry failed tests on the given error classes (Timeout|Conflict|Unavailable)")
 	cmd.Flags().DurationVar(&options.forceTerminationGracePeriod.Duration, "force-termination-grace-period", 0, "If specified, overrides termination grace periods in applicable resources")
 	// namespace options
 	cmd.Flags().StringVar(&options.namespace, "namespace", "", "Namespace to use for tests")
@@ -352,13 +441,16 @@ func Command() *cobra.Command {
 	// cleanup options
 	cmd.Flags().BoolVar(&options.skipDelete, "skip-delete", false, "If set, do not delete the resources after running the tests")
 	cmd.Flags().DurationVar(&options.delayBeforeCleanup.Duration, "cleanup-delay", 0, "Adds a delay between the time a test ends and the time cleanup starts")
+	cmd.Flags().DurationVar(&options.forceFinalize.Duration, "force-finalize", 0, "Removes the finalizers of resources still being deleted after this grace period")
+	cmd.Flags().StringVar(&options.cleanupJournal, "cleanup-journal", "", "Records the resources registered for cleanup in a file until they are deleted")
 	// deletion options
 	cmd.Flags().StringVar(&options.deletionPropagationPolicy, "deletion-propagation-policy", "Background", "The deletion propagation policy (Foreground|Background|Orphan)")
 	// error options
 	// reporting options
-	cmd.Flags().StringVar(&options.reportFormat, "report-format", "", "Test report format (JSON|XML|nil)")
+	cmd.Flags().StringVar(&options.reportFormat, "report-format", "", "Test report format (JSON|XML|CSV|HTML|nil)")
 	cmd.Flags().StringVar(&options.reportName, "report-name", "chainsaw-report", "The name of the report to create")
 	cmd.Flags().StringVar(&options.reportPath, "report-path", "", "The path of the report to create")
+	cmd.Flags().StringVar(&options.reportEvents, "report-events", "", "The path of a file where report events are streamed as they happen")
 	// multi-cluster options
 	cmd.Flags().StringSliceVar(&options.clusters, "cluster", nil, "Register cluster (format <cluster name>=<kubeconfig path>:[context name])")
 	// pause options
@@ -369,9 +461,16 @@ func Command() *cobra.Command {
 	cmd.Flags().StringSliceVar(&options.selector, "selector", nil, "Selector (label query) to filter on")
 	// external values
 	cmd.Flags().StringSliceVar(&options.values, "values", nil, "Values passed to the tests")
+	// remote step templates
+	cmd.Flags().StringVar(&options.templateCacheDir, "template-cache-dir", "", "Remote step templates cache directory (defaults to the user cache directory)")
+	cmd.Flags().BoolVar(&options.templateOffline, "template-offline", false, "Only resolve remote step templates from the cache")
+	cmd.Flags().BoolVar(&options.templateInsecure, "template-insecure", false, "Use plain http to fetch remote step templates from registries")
+	// dependencies
+	cmd.Flags().BoolVar(&options.graph, "graph", false, "Print the tests dependency graph (DOT format) and exit without running tests")
 	// others
 	cmd.Flags().BoolVar(&options.noColor, "no-color", false, "Removes output colors")
 	cmd.Flags().BoolVar(&options.remarshal, "remarshal", false, "Remarshals tests yaml to apply anchors before parsing")
+	cmd.Flags().IntVar(&options.verbosity, "verbosity", int(logging.DefaultVerbosity), "Verbosity of the function traces (0 quiet, 1 info, 2 debug)")
 	if err := cmd.MarkFlagFilename("config"); err != nil {
 		panic(err)
 	}
@@ -11,6 +11,7 @@ const (
 	configFolder  = "config"
 	crdsFolder    = "crds"
 	schemasFolder = "schemas/json"
+	reportFolder  = "schemas/report"
 )
 
 //go:embed crds
@@ -45,13 +46,18 @@ func _schemas() (fs.FS, error) {
 	return _sub(schemasFs, schemasFolder)
 }
 
+func _reportSchemas() (fs.FS, error) {
+	return _sub(schemasFs, reportFolder)
+}
+
 func _sub(f embed.FS, dir string) (fs.FS, error) {
 	return fs.Sub(f, dir)
 }
 
 var (
-	config     = sync.OnceValues(_config)
-	ConfigFile = sync.OnceValues(func() ([]byte, error) { return _configFile(nil) })
-	Crds       = sync.OnceValues(_crds)
-	Schemas    = sync.OnceValues(_schemas)
+	config        = sync.OnceValues(_config)
+	ConfigFile    = sync.OnceValues(func() ([]byte, error) { return _configFile(nil) })
+	Crds          = sync.OnceValues(_crds)
+	Schemas       = sync.OnceValues(_schemas)
+	ReportSchemas = sync.OnceValues(_reportSchemas)
 )
@@ -56,6 +56,15 @@ func TestSchemas(t *testing.T) {
 	}
 }
 
+func TestReportSchemas(t *testing.T) {
+	data, err := ReportSchemas()
+	assert.NoError(t, err)
+	file, err := fs.Stat(data, "report-chainsaw-v1.json")
+	assert.NoError(t, err)
+	assert.NotNil(t, file)
+	assert.False(t, file.IsDir())
+}
+
 func TestConfigFile(t *testing.T) {
 	data, err := ConfigFile()
 	assert.NoError(t, err)
@@ -1,6 +1,7 @@
 package discovery
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -115,7 +116,7 @@ func TestDiscoverTests(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := DiscoverTests(tt.fileName, nil, false, tt.paths...)
+			got, err := DiscoverTests(context.TODO(), tt.fileName, nil, false, tt.paths...)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -146,7 +147,7 @@ func TestHelpDiscoverTests(t *testing.T) {
 	}}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			tests, err := discoverTests("chainsaw-test.yaml", nil, false, tc.folders...)
+			tests, err := discoverTests(context.TODO(), "chainsaw-test.yaml", nil, false, tc.folders...)
 			if tc.expectError {
 				assert.Error(t, err, "Expected an error but got none")
 			} else {
@@ -163,6 +164,6 @@ func TestDiscoverTests_UnreadableFolder(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to change directory permissions: %v", err)
 	}
-	_, err = DiscoverTests("chainsaw-test.yaml", nil, false, tempDir)
+	_, err = DiscoverTests(context.TODO(), "chainsaw-test.yaml", nil, false, tempDir)
 	assert.Error(t, err, "Expected an error for unreadable folder")
 }
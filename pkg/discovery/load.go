@@ -1,6 +1,7 @@
 package discovery
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -43,7 +44,7 @@ func tryLoadTestFiles(fileName string, path string, remarshal bool) ([]*v1alpha1
 	return tryLoadTestFile(filepath.Join(path, fileName+".yml"), remarshal)
 }
 
-func LoadTest(fileName string, path string, remarshal bool) ([]Test, error) {
+func LoadTest(ctx context.Context, fileName string, path string, remarshal bool) ([]Test, error) {
 	// first, try to load a test manifest
 	if path == "" {
 		return nil, errors.New("path must be specified")
@@ -59,20 +60,16 @@ func LoadTest(fileName string, path string, remarshal bool) ([]Test, error) {
 				for step := range apiTest.Spec.Steps {
 					step := &apiTest.Spec.Steps[step]
 					if step.Use != nil && step.Use.Template != "" {
-						steptpl, err := steptemplate.Load(filepath.Join(path, step.Use.Template), remarshal)
+						steptpl, err := steptemplate.LoadReference(ctx, path, step.Use.Template, remarshal)
 						if err != nil {
 							return nil, err
 						}
 						if len(steptpl) != 1 {
 							return nil, errors.New("step template not found or multiple templates exist")
 						}
-						step.WorkDir = filepath.Dir(filepath.Join(path, step.Use.Template))
-						step.Use = nil
-						step.Bindings = append(step.Bindings, steptpl[0].Spec.Bindings...)
-						step.Try = append(step.Try, steptpl[0].Spec.Try...)
-						step.Catch = append(step.Catch, steptpl[0].Spec.Catch...)
-						step.Finally = append(step.Finally, steptpl[0].Spec.Finally...)
-						step.Cleanup = append(step.Cleanup, steptpl[0].Spec.Cleanup...)
+						if err := applyStepTemplate(step, path, steptpl[0]); err != nil {
+							return nil, err
+						}
 					}
 				}
 			}
@@ -1,6 +1,7 @@
 package discovery
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -224,6 +225,62 @@ func TestLoadTest(t *testing.T) {
 			},
 		}},
 		wantErr: false,
+	}, {
+		name:     "step template",
+		fileName: "chainsaw-test.yaml",
+		path:     filepath.Join(basePath, "step-template"),
+		want: []Test{{
+			BasePath: "../../testdata/discovery/step-template",
+			Test: &model.Test{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "chainsaw.kyverno.io/v1alpha1",
+					Kind:       "Test",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: v1alpha1.TestSpec{
+					Steps: []v1alpha1.TestStep{{
+						Name:    "create configmap",
+						WorkDir: "../../testdata/discovery/step-template/templates",
+						TestStepSpec: v1alpha1.TestStepSpec{
+							Bindings: []v1alpha1.Binding{{
+								Name:  "file",
+								Value: v1alpha1.Any{Value: "configmap.yaml"},
+							}, {
+								Name:  "replicas",
+								Value: v1alpha1.Any{Value: float64(1)},
+							}, {
+								Name:  "resource",
+								Value: v1alpha1.Any{Value: "($file)"},
+							}},
+							Try: []v1alpha1.Operation{{
+								Apply: &v1alpha1.Apply{
+									ActionResourceRef: v1alpha1.ActionResourceRef{
+										FileRef: v1alpha1.FileRef{
+											File: "($resource)",
+										},
+									},
+								},
+							}},
+							Outputs: []v1alpha1.Output{{
+								Binding: v1alpha1.Binding{
+									Name:  "created",
+									Value: v1alpha1.Any{Value: "($resource)"},
+								},
+							}},
+						},
+					}},
+				},
+			},
+		}},
+		wantErr: false,
+	}, {
+		name:     "step template with invalid inputs",
+		fileName: "chainsaw-test.yaml",
+		path:     filepath.Join(basePath, "step-template-missing-input"),
+		want:     nil,
+		wantErr:  true,
 	}, {
 		name:     "empty test",
 		fileName: "",
@@ -323,7 +380,7 @@ func TestLoadTest(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := LoadTest(tt.fileName, tt.path, false)
+			got, err := LoadTest(context.TODO(), tt.fileName, tt.path, false)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
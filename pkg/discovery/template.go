@@ -0,0 +1,103 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/expressions"
+	"github.com/kyverno/chainsaw/pkg/loaders/steptemplate/remote"
+	"go.uber.org/multierr"
+)
+
+// applyStepTemplate inlines a step template in a test step.
+// The values passed with `use.with` are validated against the template inputs and registered
+// as bindings before the template bindings, the template outputs are returned to the test.
+func applyStepTemplate(step *v1alpha1.TestStep, path string, template *v1alpha1.StepTemplate) error {
+	inputs, err := resolveInputs(template.Spec.Inputs, step.Use.With)
+	if err != nil {
+		return fmt.Errorf("invalid use of step template %s: %w", step.Use.Template, err)
+	}
+	// remote templates have no folder of their own, files they refer to are resolved from the test folder
+	if !remote.IsRemote(step.Use.Template) {
+		step.WorkDir = filepath.Dir(filepath.Join(path, step.Use.Template))
+	}
+	step.Use = nil
+	step.Bindings = append(step.Bindings, inputs...)
+	step.Bindings = append(step.Bindings, template.Spec.Bindings...)
+	step.Try = append(step.Try, template.Spec.Try...)
+	step.Catch = append(step.Catch, template.Spec.Catch...)
+	step.Finally = append(step.Finally, template.Spec.Finally...)
+	step.Cleanup = append(step.Cleanup, template.Spec.Cleanup...)
+	step.Outputs = append(step.Outputs, template.Spec.Outputs...)
+	return nil
+}
+
+func resolveInputs(inputs []v1alpha1.StepTemplateInput, with []v1alpha1.Binding) ([]v1alpha1.Binding, error) {
+	declared := map[string]v1alpha1.StepTemplateInput{}
+	for _, input := range inputs {
+		declared[input.Name] = input
+	}
+	values := map[string]*v1alpha1.Binding{}
+	var errs []error
+	for _, value := range with {
+		name := string(value.Name)
+		input, ok := declared[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown input %q", name))
+			continue
+		}
+		values[name] = nil
+		if err := checkInputType(input, value.Value); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		binding := value
+		values[name] = &binding
+	}
+	var bindings []v1alpha1.Binding
+	for _, input := range inputs {
+		if value, ok := values[input.Name]; ok {
+			if value != nil {
+				bindings = append(bindings, *value)
+			}
+		} else if input.Default != nil {
+			bindings = append(bindings, v1alpha1.Binding{
+				Name:  v1alpha1.Expression(input.Name),
+				Value: *input.Default,
+			})
+		} else if input.Required {
+			errs = append(errs, fmt.Errorf("missing required input %q", input.Name))
+		}
+	}
+	if err := multierr.Combine(errs...); err != nil {
+		return nil, err
+	}
+	return bindings, nil
+}
+
+// checkInputType checks literal values against the input type,
+// expressions are only known at execution time and are not checked.
+func checkInputType(input v1alpha1.StepTemplateInput, value v1alpha1.Any) error {
+	var ok bool
+	switch value := value.Value.(type) {
+	case string:
+		if expression := expressions.Parse(context.TODO(), value); expression != nil && expression.Engine != "" {
+			return nil
+		}
+		ok = input.Type == v1alpha1.StepTemplateInputString
+	case bool:
+		ok = input.Type == v1alpha1.StepTemplateInputBoolean
+	case int, int32, int64, float32, float64:
+		ok = input.Type == v1alpha1.StepTemplateInputNumber
+	case map[string]any:
+		ok = input.Type == v1alpha1.StepTemplateInputObject
+	case []any:
+		ok = input.Type == v1alpha1.StepTemplateInputArray
+	}
+	if ok || input.Type == "" || input.Type == v1alpha1.StepTemplateInputAny {
+		return nil
+	}
+	return fmt.Errorf("input %q must be of type %s", input.Name, input.Type)
+}
@@ -0,0 +1,106 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_resolveInputs(t *testing.T) {
+	inputs := []v1alpha1.StepTemplateInput{{
+		Name:     "name",
+		Type:     v1alpha1.StepTemplateInputString,
+		Required: true,
+	}, {
+		Name:    "replicas",
+		Type:    v1alpha1.StepTemplateInputNumber,
+		Default: &v1alpha1.Any{Value: float64(1)},
+	}, {
+		Name: "labels",
+		Type: v1alpha1.StepTemplateInputObject,
+	}}
+	tests := []struct {
+		name    string
+		inputs  []v1alpha1.StepTemplateInput
+		with    []v1alpha1.Binding
+		want    []v1alpha1.Binding
+		wantErr string
+	}{{
+		name: "none",
+	}, {
+		name:   "defaults",
+		inputs: inputs,
+		with: []v1alpha1.Binding{{
+			Name:  "name",
+			Value: v1alpha1.Any{Value: "foo"},
+		}},
+		want: []v1alpha1.Binding{{
+			Name:  "name",
+			Value: v1alpha1.Any{Value: "foo"},
+		}, {
+			Name:  "replicas",
+			Value: v1alpha1.Any{Value: float64(1)},
+		}},
+	}, {
+		name:   "override",
+		inputs: inputs,
+		with: []v1alpha1.Binding{{
+			Name:  "replicas",
+			Value: v1alpha1.Any{Value: float64(3)},
+		}, {
+			Name:  "name",
+			Value: v1alpha1.Any{Value: "($name)"},
+		}, {
+			Name:  "labels",
+			Value: v1alpha1.Any{Value: map[string]any{"foo": "bar"}},
+		}},
+		want: []v1alpha1.Binding{{
+			Name:  "name",
+			Value: v1alpha1.Any{Value: "($name)"},
+		}, {
+			Name:  "replicas",
+			Value: v1alpha1.Any{Value: float64(3)},
+		}, {
+			Name:  "labels",
+			Value: v1alpha1.Any{Value: map[string]any{"foo": "bar"}},
+		}},
+	}, {
+		name:    "missing required",
+		inputs:  inputs,
+		wantErr: `missing required input "name"`,
+	}, {
+		name:   "unknown input",
+		inputs: inputs,
+		with: []v1alpha1.Binding{{
+			Name:  "name",
+			Value: v1alpha1.Any{Value: "foo"},
+		}, {
+			Name:  "foo",
+			Value: v1alpha1.Any{Value: "bar"},
+		}},
+		wantErr: `unknown input "foo"`,
+	}, {
+		name:   "bad type",
+		inputs: inputs,
+		with: []v1alpha1.Binding{{
+			Name:  "name",
+			Value: v1alpha1.Any{Value: true},
+		}, {
+			Name:  "replicas",
+			Value: v1alpha1.Any{Value: "two"},
+		}},
+		wantErr: `input "name" must be of type string; input "replicas" must be of type number`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveInputs(tt.inputs, tt.with)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,20 +1,208 @@
 package client
 
 import (
-	"code.byted.org/inf/superkruise/pkg/clientcache"
-	"k8s.io/client-go/rest"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"sync"
 	"time"
+
+	"code.byted.org/inf/superkruise/pkg/clientcache"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 )
 
-var once sync.Once
+const (
+	dataClusterPollInterval = 100 * time.Millisecond
+	dataClusterReadyTimeout = 30 * time.Second
+)
+
+// DataClusterFactories manages the superkruise client factories used to reach
+// data clusters, one factory per control cluster.
+// Factories are started on first use and released by Close.
+type DataClusterFactories struct {
+	lock       sync.Mutex
+	ctx        context.Context
+	cancel     context.CancelFunc
+	closed     bool
+	factories  map[string]*dataClusterFactory
+	newFactory func(string) clientcache.ClientFactoryInterface
+}
+
+type dataClusterFactory struct {
+	clientcache.ClientFactoryInterface
+	lock     sync.Mutex
+	clusters map[string]struct{}
+}
+
+func (f *dataClusterFactory) CreateClient(cluster string, opts *cache.Options) (clientcache.ClientInterface, error) {
+	client, err := f.ClientFactoryInterface.CreateClient(cluster, opts)
+	if err == nil {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		f.clusters[cluster] = struct{}{}
+	}
+	return client, err
+}
+
+func (f *dataClusterFactory) DestoryClient(cluster string) {
+	f.ClientFactoryInterface.DestoryClient(cluster)
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.clusters, cluster)
+}
+
+func (f *dataClusterFactory) close() {
+	f.lock.Lock()
+	clusters := f.clusters
+	f.clusters = map[string]struct{}{}
+	f.lock.Unlock()
+	for cluster := range clusters {
+		f.ClientFactoryInterface.DestoryClient(cluster)
+	}
+}
+
+func NewDataClusterFactories() *DataClusterFactories {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &DataClusterFactories{
+		ctx:       ctx,
+		cancel:    cancel,
+		factories: map[string]*dataClusterFactory{},
+		newFactory: func(name string) clientcache.ClientFactoryInterface {
+			return new(clientcache.ClientFactoryShop).Generate(name)
+		},
+	}
+}
+
+// Get returns the factory bound to the control cluster cfg points to,
+// the factory is started the first time it is requested.
+func (m *DataClusterFactories) Get(cfg *rest.Config) (clientcache.ClientFactoryInterface, error) {
+	if m == nil {
+		return nil, errors.New("no data cluster factories available")
+	}
+	if cfg == nil {
+		return nil, errors.New("control cluster rest config is nil")
+	}
+	key := cfg.Host
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.closed {
+		return nil, errors.New("data cluster factories are closed")
+	}
+	if f, ok := m.factories[key]; ok {
+		return f, nil
+	}
+	name := clientcache.ClientFactoryNameDefault
+	if len(m.factories) != 0 {
+		name = fmt.Sprintf("%s-%d", name, len(m.factories))
+	}
+	f := &dataClusterFactory{
+		ClientFactoryInterface: m.newFactory(name),
+		clusters:               map[string]struct{}{},
+	}
+	if f.ClientFactoryInterface == nil {
+		return nil, fmt.Errorf("failed to create data cluster client factory for %s", key)
+	}
+	// Start blocks for the lifetime of the factory and has no stop hook,
+	// requests made with the config it receives are cancelled by Close
+	go f.Start(withContext(m.ctx, cfg), 0)
+	m.factories[key] = f
+	return f, nil
+}
+
+// Close destroys the clients created through the managed factories and stops them,
+// later calls to Get fail.
+func (m *DataClusterFactories) Close() {
+	m.lock.Lock()
+	factories := m.factories
+	m.factories = map[string]*dataClusterFactory{}
+	m.closed = true
+	m.lock.Unlock()
+	for _, f := range factories {
+		f.close()
+	}
+	m.cancel()
+}
 
-func InitDataClusterClient(cfg *rest.Config) clientcache.ClientFactoryInterface {
-	client := new(clientcache.ClientFactoryShop)
-	f := client.Generate(clientcache.ClientFactoryNameDefault)
-	once.Do(func() {
-		go f.Start(cfg, 0)
-		time.Sleep(5 * time.Second)
+// withContext returns a copy of cfg whose requests are cancelled when ctx is done.
+func withContext(ctx context.Context, cfg *rest.Config) *rest.Config {
+	cfg = rest.CopyConfig(cfg)
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &contextRoundTripper{ctx: ctx, delegate: rt}
 	})
-	return f
+	return cfg
+}
+
+type contextRoundTripper struct {
+	ctx      context.Context
+	delegate http.RoundTripper
+}
+
+func (rt *contextRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := rt.ctx.Err(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(req.Context())
+	stop := context.AfterFunc(rt.ctx, cancel)
+	release := func() {
+		stop()
+		cancel()
+	}
+	resp, err := rt.delegate.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		release()
+		return nil, err
+	}
+	// the request context must outlive the call for streamed bodies like watches
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// WaitForDataCluster waits until the factory knows how to reach the data cluster.
+// It honors the deadline of ctx and falls back to a default timeout.
+func WaitForDataCluster(ctx context.Context, f clientcache.ClientFactoryInterface, cluster string) (*rest.Config, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, dataClusterReadyTimeout)
+		defer cancel()
+	}
+	var config *rest.Config
+	err := wait.PollUntilContextCancel(ctx, dataClusterPollInterval, true, func(context.Context) (bool, error) {
+		config = f.GetRestConfigByClusterName(cluster)
+		return config != nil, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("data cluster %s is not ready: %w", cluster, err)
+	}
+	return config, nil
+}
+
+type dataClusterFactoriesKey struct{}
+
+func DataClusterFactoriesFromContext(ctx context.Context) *DataClusterFactories {
+	if ctx != nil {
+		if v, ok := ctx.Value(dataClusterFactoriesKey{}).(*DataClusterFactories); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func IntoContext(ctx context.Context, factories *DataClusterFactories) context.Context {
+	return context.WithValue(ctx, dataClusterFactoriesKey{}, factories)
 }
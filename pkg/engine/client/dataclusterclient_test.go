@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"code.byted.org/inf/superkruise/pkg/clientcache"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+type fakeFactory struct {
+	lock      sync.Mutex
+	started   chan struct{}
+	config    *rest.Config
+	configs   map[string]*rest.Config
+	destroyed []string
+}
+
+func (f *fakeFactory) Start(config *rest.Config, _ int) {
+	f.config = config
+	close(f.started)
+}
+
+func (f *fakeFactory) CreateClient(string, *cache.Options) (clientcache.ClientInterface, error) {
+	return nil, nil
+}
+
+func (f *fakeFactory) DestoryClient(cluster string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.destroyed = append(f.destroyed, cluster)
+}
+
+func (f *fakeFactory) GetClient(string) clientcache.ClientInterface {
+	return nil
+}
+
+func (f *fakeFactory) GetRestConfigByClusterName(cluster string) *rest.Config {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.configs[cluster]
+}
+
+func (f *fakeFactory) setConfig(cluster string, config *rest.Config) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.configs[cluster] = config
+}
+
+func newFakeFactory() *fakeFactory {
+	return &fakeFactory{
+		started: make(chan struct{}),
+		configs: map[string]*rest.Config{},
+	}
+}
+
+func TestDataClusterFactories(t *testing.T) {
+	var created []*fakeFactory
+	m := NewDataClusterFactories()
+	m.newFactory = func(string) clientcache.ClientFactoryInterface {
+		f := newFakeFactory()
+		created = append(created, f)
+		return f
+	}
+	_, err := m.Get(nil)
+	assert.Error(t, err)
+	foo, err := m.Get(&rest.Config{Host: "foo"})
+	assert.NoError(t, err)
+	again, err := m.Get(&rest.Config{Host: "foo"})
+	assert.NoError(t, err)
+	assert.Same(t, foo, again)
+	bar, err := m.Get(&rest.Config{Host: "bar"})
+	assert.NoError(t, err)
+	assert.NotSame(t, foo, bar)
+	assert.Len(t, created, 2)
+	<-created[0].started
+	<-created[1].started
+	_, err = foo.CreateClient("a", nil)
+	assert.NoError(t, err)
+	_, err = foo.CreateClient("b", nil)
+	assert.NoError(t, err)
+	foo.DestoryClient("b")
+	m.Close()
+	assert.ElementsMatch(t, []string{"b", "a"}, created[0].destroyed)
+	assert.Empty(t, created[1].destroyed)
+	_, err = m.Get(&rest.Config{Host: "foo"})
+	assert.Error(t, err)
+}
+
+func TestDataClusterFactories_Close(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	f := newFakeFactory()
+	m := NewDataClusterFactories()
+	m.newFactory = func(string) clientcache.ClientFactoryInterface {
+		return f
+	}
+	_, err := m.Get(&rest.Config{Host: server.URL})
+	assert.NoError(t, err)
+	<-f.started
+	transport, err := rest.TransportFor(f.config)
+	assert.NoError(t, err)
+	client := &http.Client{Transport: transport}
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	m.Close()
+	_, err = client.Get(server.URL)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestDataClusterFactories_Nil(t *testing.T) {
+	var m *DataClusterFactories
+	_, err := m.Get(&rest.Config{})
+	assert.Error(t, err)
+	assert.Nil(t, DataClusterFactoriesFromContext(context.Background()))
+	m = NewDataClusterFactories()
+	assert.Same(t, m, DataClusterFactoriesFromContext(IntoContext(context.Background(), m)))
+}
+
+func TestWaitForDataCluster(t *testing.T) {
+	f := newFakeFactory()
+	config := &rest.Config{Host: "a"}
+	go func() {
+		time.Sleep(2 * dataClusterPollInterval)
+		f.setConfig("a", config)
+	}()
+	got, err := WaitForDataCluster(context.Background(), f, "a")
+	assert.NoError(t, err)
+	assert.Same(t, config, got)
+	ctx, cancel := context.WithTimeout(context.Background(), 3*dataClusterPollInterval)
+	defer cancel()
+	_, err = WaitForDataCluster(ctx, f, "b")
+	assert.Error(t, err)
+}
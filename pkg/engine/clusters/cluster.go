@@ -2,9 +2,11 @@ package clusters
 
 import (
 	"context"
+	"errors"
 	"sync"
 
 	appsv1alpha1 "code.byted.org/inf/superkruise/api/apps/v1alpha1"
+	"code.byted.org/inf/superkruise/pkg/clientcache"
 	skutil "code.byted.org/inf/superkruise/pkg/superkruiseutil"
 	"github.com/kyverno/chainsaw/pkg/client"
 	"github.com/kyverno/chainsaw/pkg/client/simple"
@@ -88,21 +90,38 @@ type fromDataCluster struct {
 	client   func() (*rest.Config, client.Client, error)
 }
 
-func NewDataCluster(defaultCluster Cluster, clusterName string) Cluster {
-	cfg, err := defaultCluster.Config()
-	if err != nil {
-		panic(err)
-	}
-	f := engineclient.InitDataClusterClient(cfg)
+func NewDataCluster(ctx context.Context, factories *engineclient.DataClusterFactories, defaultCluster Cluster, clusterName string) Cluster {
+	factory := sync.OnceValues(func() (clientcache.ClientFactoryInterface, error) {
+		if defaultCluster == nil {
+			return nil, errors.New("control cluster is nil")
+		}
+		cfg, err := defaultCluster.Config()
+		if err != nil {
+			return nil, err
+		}
+		return factories.Get(cfg)
+	})
 	resolver := func() (*rest.Config, error) {
-		return f.GetRestConfigByClusterName(clusterName), nil
+		f, err := factory()
+		if err != nil {
+			return nil, err
+		}
+		return engineclient.WaitForDataCluster(ctx, f, clusterName)
 	}
 	client := func() (*rest.Config, client.Client, error) {
+		f, err := factory()
+		if err != nil {
+			return nil, nil, err
+		}
+		config, err := engineclient.WaitForDataCluster(ctx, f, clusterName)
+		if err != nil {
+			return nil, nil, err
+		}
 		cc, err := f.CreateClient(clusterName, &cache.Options{})
 		if err != nil {
 			return nil, nil, err
 		}
-		return f.GetRestConfigByClusterName(clusterName), cc, nil
+		return config, cc, nil
 	}
 	return &fromDataCluster{
 		resolver: resolver,
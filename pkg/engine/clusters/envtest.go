@@ -0,0 +1,67 @@
+package clusters
+
+import (
+	"sync"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/client/simple"
+	engineclient "github.com/kyverno/chainsaw/pkg/engine/client"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+type fromEnvtest struct {
+	lock    sync.Mutex
+	env     *envtest.Environment
+	config  *rest.Config
+	err     error
+	started bool
+	stopped bool
+}
+
+// NewEphemeralCluster returns a cluster backed by a local envtest control plane.
+// The control plane starts the first time the cluster is used, the returned
+// function stops it.
+func NewEphemeralCluster(crds []string, binaryAssetsDirectory string) (Cluster, func() error) {
+	c := &fromEnvtest{
+		env: &envtest.Environment{
+			CRDDirectoryPaths:     crds,
+			ErrorIfCRDPathMissing: true,
+			BinaryAssetsDirectory: binaryAssetsDirectory,
+		},
+	}
+	return c, c.stop
+}
+
+func (c *fromEnvtest) Config() (*rest.Config, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !c.started {
+		c.started = true
+		c.config, c.err = c.env.Start()
+	}
+	return c.config, c.err
+}
+
+func (c *fromEnvtest) Build() (*rest.Config, client.Client, error) {
+	config, err := c.Config()
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := simple.New(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	client = engineclient.New(client)
+	return config, client, nil
+}
+
+func (c *fromEnvtest) stop() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !c.started || c.stopped {
+		return nil
+	}
+	c.stopped = true
+	return c.env.Stop()
+}
@@ -0,0 +1,141 @@
+package clusters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	engineclient "github.com/kyverno/chainsaw/pkg/engine/client"
+)
+
+// ProviderRequest describes a cluster declared in a test or a step.
+type ProviderRequest struct {
+	// Name is the name the cluster is registered under.
+	Name string
+	// BasePath is the folder relative paths are resolved from.
+	BasePath string
+	// Cluster is the cluster definition.
+	Cluster v1alpha1.Cluster
+	// Evaluate resolves an expression against the current bindings.
+	Evaluate func(context.Context, string) (string, error)
+	// Lookup returns an already registered cluster.
+	Lookup func(string) Cluster
+	// Cleanup registers a function to run when the owner of the cluster ends.
+	Cleanup func(func() error)
+	// DataClusters gives access to the data clusters of a control cluster.
+	DataClusters *engineclient.DataClusterFactories
+}
+
+// ClusterProvider creates clusters from their definition.
+type ClusterProvider interface {
+	// Accepts returns true if the provider knows how to create the cluster.
+	Accepts(v1alpha1.Cluster) bool
+	// Provide creates the cluster.
+	Provide(context.Context, ProviderRequest) (Cluster, error)
+}
+
+var (
+	defaultProvidersLock sync.Mutex
+	defaultProviders     = []ClusterProvider{
+		ephemeralProvider{},
+		vClusterProvider{},
+		dataClusterProvider{},
+		kubeconfigProvider{},
+	}
+)
+
+// RegisterDefaultProvider adds a provider to all registries created afterwards,
+// it takes precedence over the providers registered before it.
+func RegisterDefaultProvider(provider ClusterProvider) {
+	defaultProvidersLock.Lock()
+	defer defaultProvidersLock.Unlock()
+	defaultProviders = append([]ClusterProvider{provider}, defaultProviders...)
+}
+
+func DefaultProviders() []ClusterProvider {
+	defaultProvidersLock.Lock()
+	defer defaultProvidersLock.Unlock()
+	return append([]ClusterProvider(nil), defaultProviders...)
+}
+
+func evaluate(ctx context.Context, request ProviderRequest, in string) (string, error) {
+	if request.Evaluate == nil {
+		return in, nil
+	}
+	return request.Evaluate(ctx, in)
+}
+
+type vClusterProvider struct{}
+
+func (vClusterProvider) Accepts(cluster v1alpha1.Cluster) bool {
+	return len(cluster.VClusterName) > 0 && len(cluster.VClusterNameSpace) > 0
+}
+
+func (vClusterProvider) Provide(ctx context.Context, request ProviderRequest) (Cluster, error) {
+	name, err := evaluate(ctx, request, request.Cluster.VClusterName)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := evaluate(ctx, request, request.Cluster.VClusterNameSpace)
+	if err != nil {
+		return nil, err
+	}
+	return NewVCluster(ctx, name, namespace), nil
+}
+
+type dataClusterProvider struct{}
+
+func (dataClusterProvider) Accepts(cluster v1alpha1.Cluster) bool {
+	return len(cluster.DataClusterName) > 0
+}
+
+func (dataClusterProvider) Provide(ctx context.Context, request ProviderRequest) (Cluster, error) {
+	name, err := evaluate(ctx, request, request.Cluster.DataClusterName)
+	if err != nil {
+		return nil, err
+	}
+	if request.Lookup == nil {
+		return nil, errors.New("no cluster registry to look up the control cluster")
+	}
+	control := request.Lookup(request.Cluster.ControlClusterName)
+	if control == nil {
+		return nil, fmt.Errorf("control cluster not found: %q", request.Cluster.ControlClusterName)
+	}
+	return NewDataCluster(ctx, request.DataClusters, control, name), nil
+}
+
+type kubeconfigProvider struct{}
+
+func (kubeconfigProvider) Accepts(cluster v1alpha1.Cluster) bool {
+	return true
+}
+
+func (kubeconfigProvider) Provide(_ context.Context, request ProviderRequest) (Cluster, error) {
+	kubeconfig := filepath.Join(request.BasePath, request.Cluster.Kubeconfig)
+	return NewClusterFromKubeconfig(kubeconfig, request.Cluster.Context), nil
+}
+
+type ephemeralProvider struct{}
+
+func (ephemeralProvider) Accepts(cluster v1alpha1.Cluster) bool {
+	return cluster.Ephemeral != nil
+}
+
+func (ephemeralProvider) Provide(_ context.Context, request ProviderRequest) (Cluster, error) {
+	if request.Cleanup == nil {
+		return nil, errors.New("ephemeral clusters can only be declared in the configuration, a test or a step")
+	}
+	var crds []string
+	for _, crd := range request.Cluster.Ephemeral.CRDs {
+		if !filepath.IsAbs(crd) {
+			crd = filepath.Join(request.BasePath, crd)
+		}
+		crds = append(crds, crd)
+	}
+	cluster, stop := NewEphemeralCluster(crds, request.Cluster.Ephemeral.BinaryAssetsDirectory)
+	request.Cleanup(stop)
+	return cluster, nil
+}
@@ -0,0 +1,142 @@
+package clusters
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeProvider struct {
+	accepts bool
+	cluster Cluster
+	err     error
+}
+
+func (p fakeProvider) Accepts(v1alpha1.Cluster) bool {
+	return p.accepts
+}
+
+func (p fakeProvider) Provide(context.Context, ProviderRequest) (Cluster, error) {
+	return p.cluster, p.err
+}
+
+func Test_registry_Provide(t *testing.T) {
+	custom := NewClusterFromKubeconfig("custom", "")
+	tests := []struct {
+		name      string
+		providers []ClusterProvider
+		request   ProviderRequest
+		want      Cluster
+		wantErr   string
+	}{{
+		name:    "no provider",
+		request: ProviderRequest{Name: "foo"},
+		wantErr: `no provider found for cluster "foo"`,
+	}, {
+		name:      "custom provider",
+		providers: []ClusterProvider{fakeProvider{accepts: true, cluster: custom}},
+		request:   ProviderRequest{Name: "foo"},
+		want:      custom,
+	}, {
+		name:      "provider error",
+		providers: []ClusterProvider{fakeProvider{accepts: true, err: errors.New("dummy")}},
+		request:   ProviderRequest{Name: "foo"},
+		wantErr:   `failed to create cluster "foo": dummy`,
+	}, {
+		name:      "skip provider",
+		providers: []ClusterProvider{fakeProvider{accepts: false, err: errors.New("dummy")}, kubeconfigProvider{}},
+		request:   ProviderRequest{Name: "foo", Cluster: v1alpha1.Cluster{Kubeconfig: "kubeconfig"}},
+		want:      nil,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Registry = registry{providers: tt.providers}
+			got, err := c.Provide(context.TODO(), tt.request)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				if tt.want != nil {
+					assert.Same(t, tt.want, got)
+				} else {
+					assert.NotNil(t, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_registry_RegisterProvider(t *testing.T) {
+	custom := NewClusterFromKubeconfig("custom", "")
+	c := NewRegistry(nil)
+	got, err := c.Provide(context.TODO(), ProviderRequest{Name: "foo"})
+	assert.NoError(t, err)
+	assert.NotSame(t, custom, got)
+	c = c.RegisterProvider(fakeProvider{accepts: true, cluster: custom})
+	got, err = c.Provide(context.TODO(), ProviderRequest{Name: "foo"})
+	assert.NoError(t, err)
+	assert.Same(t, custom, got)
+}
+
+func Test_dataClusterProvider(t *testing.T) {
+	provider := dataClusterProvider{}
+	assert.False(t, provider.Accepts(v1alpha1.Cluster{}))
+	assert.True(t, provider.Accepts(v1alpha1.Cluster{DataClusterName: "foo"}))
+	_, err := provider.Provide(context.TODO(), ProviderRequest{
+		Cluster: v1alpha1.Cluster{DataClusterName: "foo", ControlClusterName: "control"},
+		Lookup:  func(string) Cluster { return nil },
+	})
+	assert.EqualError(t, err, `control cluster not found: "control"`)
+	_, err = provider.Provide(context.TODO(), ProviderRequest{
+		Cluster: v1alpha1.Cluster{DataClusterName: "foo"},
+		Evaluate: func(context.Context, string) (string, error) {
+			return "", errors.New("dummy")
+		},
+	})
+	assert.EqualError(t, err, "dummy")
+}
+
+func Test_vClusterProvider(t *testing.T) {
+	provider := vClusterProvider{}
+	assert.False(t, provider.Accepts(v1alpha1.Cluster{VClusterName: "foo"}))
+	assert.True(t, provider.Accepts(v1alpha1.Cluster{VClusterName: "foo", VClusterNameSpace: "bar"}))
+	_, err := provider.Provide(context.TODO(), ProviderRequest{
+		Cluster: v1alpha1.Cluster{VClusterName: "foo", VClusterNameSpace: "bar"},
+		Evaluate: func(context.Context, string) (string, error) {
+			return "", errors.New("dummy")
+		},
+	})
+	assert.EqualError(t, err, "dummy")
+}
+
+func Test_ephemeralProvider(t *testing.T) {
+	provider := ephemeralProvider{}
+	assert.False(t, provider.Accepts(v1alpha1.Cluster{}))
+	assert.True(t, provider.Accepts(v1alpha1.Cluster{Ephemeral: &v1alpha1.EphemeralCluster{}}))
+	request := ProviderRequest{
+		BasePath: "base",
+		Cluster: v1alpha1.Cluster{
+			Ephemeral: &v1alpha1.EphemeralCluster{
+				CRDs: []string{"crds", "/abs/crds"},
+			},
+		},
+	}
+	_, err := provider.Provide(context.TODO(), request)
+	assert.Error(t, err)
+	var cleanups []func() error
+	request.Cleanup = func(f func() error) {
+		cleanups = append(cleanups, f)
+	}
+	got, err := provider.Provide(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.Len(t, cleanups, 1)
+	cluster, ok := got.(*fromEnvtest)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"base/crds", "/abs/crds"}, cluster.env.CRDDirectoryPaths)
+	// stopping a cluster that never started is a no-op
+	assert.NoError(t, cleanups[0]())
+}
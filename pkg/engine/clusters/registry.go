@@ -1,6 +1,9 @@
 package clusters
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/kyverno/chainsaw/pkg/client"
 	"k8s.io/client-go/rest"
 )
@@ -11,6 +14,8 @@ type Registry interface {
 	Register(string, Cluster) Registry
 	Lookup(string) Cluster
 	Build(Cluster) (*rest.Config, client.Client, error)
+	RegisterProvider(ClusterProvider) Registry
+	Provide(context.Context, ProviderRequest) (Cluster, error)
 }
 
 type clientFactory = func(Cluster) (*rest.Config, client.Client, error)
@@ -25,12 +30,14 @@ func defaultClientFactory(cluster Cluster) (*rest.Config, client.Client, error)
 type registry struct {
 	clientFactory clientFactory
 	clusters      map[string]Cluster
+	providers     []ClusterProvider
 }
 
 func NewRegistry(f clientFactory) Registry {
 	return registry{
 		clientFactory: f,
 		clusters:      map[string]Cluster{},
+		providers:     DefaultProviders(),
 	}
 }
 
@@ -43,7 +50,35 @@ func (c registry) Register(name string, cluster Cluster) Registry {
 	return registry{
 		clientFactory: c.clientFactory,
 		clusters:      values,
+		providers:     c.providers,
+	}
+}
+
+func (c registry) RegisterProvider(provider ClusterProvider) Registry {
+	providers := make([]ClusterProvider, 0, len(c.providers)+1)
+	providers = append(providers, provider)
+	providers = append(providers, c.providers...)
+	return registry{
+		clientFactory: c.clientFactory,
+		clusters:      c.clusters,
+		providers:     providers,
+	}
+}
+
+func (c registry) Provide(ctx context.Context, request ProviderRequest) (Cluster, error) {
+	if request.Lookup == nil {
+		request.Lookup = c.Lookup
+	}
+	for _, provider := range c.providers {
+		if provider.Accepts(request.Cluster) {
+			cluster, err := provider.Provide(ctx, request)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create cluster %q: %w", request.Name, err)
+			}
+			return cluster, nil
+		}
 	}
+	return nil, fmt.Errorf("no provider found for cluster %q", request.Name)
 }
 
 func (c registry) Lookup(name string) Cluster {
@@ -19,6 +19,7 @@ func TestNewRegistry(t *testing.T) {
 		want: registry{
 			clientFactory: nil,
 			clusters:      map[string]Cluster{},
+			providers:     DefaultProviders(),
 		},
 	}}
 	for _, tt := range tests {
@@ -2,8 +2,9 @@ package engine
 
 import (
 	"context"
+	"fmt"
+
 	"github.com/kyverno/chainsaw/pkg/mutate"
-	"path/filepath"
 
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/engine/bindings"
@@ -21,34 +22,35 @@ func WithBindings(ctx context.Context, tc Context, variables ...v1alpha1.Binding
 	return tc, nil
 }
 
-func WithClusters(ctx context.Context, tc Context, basePath string, c map[string]v1alpha1.Cluster) Context {
+// WithClusters provides the clusters and registers them in the context.
+// Cleanup registers the functions run when the scope declaring the clusters ends, clusters that need
+// to be torn down (ephemeral clusters) can't be declared when it is nil.
+func WithClusters(ctx context.Context, tc Context, basePath string, c map[string]v1alpha1.Cluster, cleanup func(func() error)) (Context, error) {
+	evaluate := func(ctx context.Context, in string) (string, error) {
+		out, err := mutate.Mutate(ctx, nil, mutate.Parse(ctx, in), nil, tc.Bindings())
+		if err != nil {
+			return "", err
+		}
+		if out, ok := out.(string); ok {
+			return out, nil
+		}
+		return "", fmt.Errorf("expression didn't evaluate to a string: %s", in)
+	}
 	for name, cluster := range c {
-		if len(cluster.VClusterName) > 0 && len(cluster.VClusterNameSpace) > 0 {
-			vClusterName, err := mutate.Mutate(ctx, nil, mutate.Parse(ctx, cluster.VClusterName), nil, tc.Bindings())
-			if err != nil {
-				panic(err)
-			}
-			vClusterNameSpace, err := mutate.Mutate(ctx, nil, mutate.Parse(ctx, cluster.VClusterNameSpace), nil, tc.Bindings())
-			if err != nil {
-				panic(err)
-			}
-			ck := clusters.NewVCluster(ctx, vClusterName.(string), vClusterNameSpace.(string))
-			tc = tc.WithCluster(ctx, name, ck)
-		} else if len(cluster.DataClusterName) > 0 {
-			get, err := mutate.Mutate(ctx, nil, mutate.Parse(ctx, cluster.DataClusterName), nil, tc.Bindings())
-			if err != nil {
-				panic(err)
-			}
-			controlClusterName := cluster.ControlClusterName
-			ck := clusters.NewDataCluster(tc.Cluster(controlClusterName), get.(string))
-			tc = tc.WithCluster(ctx, name, ck)
-		} else {
-			kubeconfig := filepath.Join(basePath, cluster.Kubeconfig)
-			cluster := clusters.NewClusterFromKubeconfig(kubeconfig, cluster.Context)
-			tc = tc.WithCluster(ctx, name, cluster)
+		ck, err := tc.Clusters().Provide(ctx, clusters.ProviderRequest{
+			Name:         name,
+			BasePath:     basePath,
+			Cluster:      cluster,
+			Evaluate:     evaluate,
+			Cleanup:      cleanup,
+			DataClusters: tc.DataClusters(),
+		})
+		if err != nil {
+			return tc, err
 		}
+		tc = tc.WithCluster(ctx, name, ck)
 	}
-	return tc
+	return tc, nil
 }
 
 func WithCurrentCluster(ctx context.Context, tc Context, name string) (Context, error) {
@@ -7,6 +7,7 @@ import (
 	"github.com/kyverno/chainsaw/pkg/client"
 	"github.com/kyverno/chainsaw/pkg/client/dryrun"
 	apibindings "github.com/kyverno/chainsaw/pkg/engine/bindings"
+	engineclient "github.com/kyverno/chainsaw/pkg/engine/client"
 	"github.com/kyverno/chainsaw/pkg/engine/clusters"
 	"github.com/kyverno/chainsaw/pkg/model"
 	"k8s.io/client-go/rest"
@@ -14,18 +15,21 @@ import (
 
 type TestContext struct {
 	*model.Summary
-	bindings binding.Bindings
-	cluster  clusters.Cluster
-	clusters clusters.Registry
-	dryRun   bool
+	bindings     binding.Bindings
+	cluster      clusters.Cluster
+	clusterName  string
+	clusters     clusters.Registry
+	dataClusters *engineclient.DataClusterFactories
+	dryRun       bool
 }
 
 func MakeContext(bindings binding.Bindings, registry clusters.Registry) TestContext {
 	return TestContext{
-		Summary:  &model.Summary{},
-		bindings: bindings,
-		clusters: registry,
-		cluster:  nil,
+		Summary:      &model.Summary{},
+		bindings:     bindings,
+		clusters:     registry,
+		cluster:      nil,
+		dataClusters: engineclient.NewDataClusterFactories(),
 	}
 }
 
@@ -45,16 +49,28 @@ func (tc *TestContext) Clusters() clusters.Registry {
 	return tc.clusters
 }
 
+func (tc *TestContext) DataClusters() *engineclient.DataClusterFactories {
+	return tc.dataClusters
+}
+
 func (tc *TestContext) CurrentCluster() clusters.Cluster {
 	return tc.cluster
 }
 
+func (tc *TestContext) CurrentClusterName() string {
+	return tc.clusterName
+}
+
 func (tc *TestContext) CurrentClusterClient() (*rest.Config, client.Client, error) {
-	config, client, err := tc.clusters.Build(tc.cluster)
-	if err == nil && client != nil && tc.DryRun() {
-		client = dryrun.New(client)
+	config, c, err := tc.clusters.Build(tc.cluster)
+	if err == nil && c != nil && config != nil {
+		// the cluster address identifies where objects were created in the cleanup journal
+		c = client.WithCluster(c, config.Host)
+	}
+	if err == nil && c != nil && tc.DryRun() {
+		c = dryrun.New(c)
 	}
-	return config, client, err
+	return config, c, err
 }
 
 func (tc *TestContext) DryRun() bool {
@@ -73,6 +89,7 @@ func (tc TestContext) WithCluster(ctx context.Context, name string, cluster clus
 
 func (tc TestContext) WithCurrentCluster(ctx context.Context, name string) TestContext {
 	tc.cluster = tc.Cluster(name)
+	tc.clusterName = name
 	return tc
 }
 
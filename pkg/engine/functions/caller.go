@@ -14,12 +14,64 @@ var Caller = sync.OnceValue(func() interpreter.FunctionCaller {
 	funcs = append(funcs, template.GetFunctions(context.Background())...)
 	funcs = append(funcs, GetFunctions()...)
 	funcs = append(funcs, GetInnerFunc()...)
+	funcs = append(funcs, GetContextFunctions(context.Background())...)
 	return interpreter.NewFunctionCaller(funcs...)
 })
 
+type contextFunctionTable struct {
+	// arguments returns the arguments of a context aware function once they are checked
+	// against the function specs, the same way the interpreter does.
+	arguments interpreter.FunctionCaller
+	handlers  map[string]func(context.Context, []any) (any, error)
+}
+
+var contextFunctions = sync.OnceValue(func() contextFunctionTable {
+	entries := getContextFunctions()
+	table := contextFunctionTable{
+		handlers: make(map[string]func(context.Context, []any) (any, error), len(entries)),
+	}
+	funcs := make([]jpfunctions.FunctionEntry, 0, len(entries))
+	for _, entry := range entries {
+		funcs = append(funcs, jpfunctions.FunctionEntry{
+			Name:      entry.Name,
+			Arguments: entry.Arguments,
+			Handler: func(arguments []any) (any, error) {
+				return arguments, nil
+			},
+		})
+		table.handlers[entry.Name] = entry.Handler
+	}
+	table.arguments = interpreter.NewFunctionCaller(funcs...)
+	return table
+})
+
+// CallerWithContext returns a function caller where context aware functions
+// are bound to ctx, so that they honor its deadline and cancellation.
+func CallerWithContext(ctx context.Context) interpreter.FunctionCaller {
+	return contextCaller{ctx: ctx}
+}
+
+type contextCaller struct {
+	ctx context.Context
+}
+
+func (c contextCaller) CallFunction(name string, arguments []any) (any, error) {
+	table := contextFunctions()
+	handler, ok := table.handlers[name]
+	if !ok {
+		return Caller().CallFunction(name, arguments)
+	}
+	resolved, err := table.arguments.CallFunction(name, arguments)
+	if err != nil {
+		return nil, err
+	}
+	return handler(c.ctx, resolved.([]any))
+}
+
 var InnerCaller = sync.OnceValue(func() interpreter.FunctionCaller {
 	var funcs []jpfunctions.FunctionEntry
 	funcs = append(funcs, template.GetFunctions(context.Background())...)
 	funcs = append(funcs, GetFunctions()...)
+	funcs = append(funcs, bindContext(context.Background(), getDataClusterFunctions()...)...)
 	return interpreter.NewFunctionCaller(funcs...)
 })
@@ -0,0 +1,74 @@
+package functions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jmespath-community/go-jmespath/pkg/interpreter"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+)
+
+func TestCallerWithContext(t *testing.T) {
+	tests := []struct {
+		name      string
+		function  string
+		arguments []any
+		want      any
+		wantErr   string
+	}{{
+		name:     "unknown function",
+		function: "foo",
+		wantErr:  "unknown function: foo",
+	}, {
+		name:      "function",
+		function:  trimSpace,
+		arguments: []any{" foo "},
+		want:      "foo",
+	}, {
+		name:     "context function with missing arguments",
+		function: allDataClusterWait,
+		wantErr:  "invalid arity",
+	}, {
+		name:      "context function with invalid arguments",
+		function:  allDataClusterWait,
+		arguments: []any{(*rest.Config)(nil), "a", "v1", "ConfigMap", "default", "", "length(items)", 1.0},
+		wantErr:   "invalid type",
+	}, {
+		name:      "context function",
+		function:  allDataClusterWait,
+		arguments: []any{(*rest.Config)(nil), "a", "v1", "ConfigMap", "default", "", "length(items)", "1", "-1s"},
+		wantErr:   "invalid interval: -1s",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caller := CallerWithContext(context.TODO())
+			got, err := caller.CallFunction(tt.function, tt.arguments)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				// errors match the ones of the default caller
+				_, want := Caller().CallFunction(tt.function, tt.arguments)
+				assert.Equal(t, want, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestInnerCaller(t *testing.T) {
+	unknown := func(caller interpreter.FunctionCaller, name string) bool {
+		_, err := caller.CallFunction(name, nil)
+		return err != nil && err.Error() == "unknown function: "+name
+	}
+	for _, function := range GetFunctions() {
+		assert.False(t, unknown(InnerCaller(), function.Name), function.Name)
+	}
+	for _, function := range getDataClusterFunctions() {
+		assert.False(t, unknown(InnerCaller(), function.Name), function.Name)
+	}
+	for _, function := range getWaitFunctions() {
+		assert.True(t, unknown(InnerCaller(), function.Name), function.Name)
+	}
+}
@@ -1,6 +1,7 @@
 package functions
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
@@ -25,6 +26,7 @@ var (
 	allDataClusterInformerClean   = experimental("data_cluster_clean")
 	allDataClusterList            = experimental("data_cluster_list")
 	allDataClusterWait            = experimental("data_cluster_wait")
+	allDataClusterPatch           = experimental("data_cluster_patch")
 	allDataClusterServerVersion   = experimental("data_cluster_server_version")
 	allDataClusterCreateNamespace = experimental("data_cluster_create_namespace")
 	allDataClusterDeleteNamespace = experimental("data_cluster_delete_namespace")
@@ -115,7 +117,69 @@ func GetFunctions() []functions.FunctionEntry {
 			}
 			return nil, nil
 		},
+	}}
+}
+
+func GetInnerFunc() []functions.FunctionEntry {
+	return []functions.FunctionEntry{{
+		Name: "table_print",
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpAny}},
+			{Types: []functions.JpType{functions.JpString}, Optional: true},
+		},
+		Handler: tableFormat,
+	}}
+}
+
+// contextFunctionEntry is a function entry whose handler receives the context of the evaluation.
+type contextFunctionEntry struct {
+	Name      string
+	Arguments []functions.ArgSpec
+	Handler   func(context.Context, []any) (any, error)
+}
+
+// GetContextFunctions returns the context aware functions bound to ctx.
+func GetContextFunctions(ctx context.Context) []functions.FunctionEntry {
+	return bindContext(ctx, getContextFunctions()...)
+}
+
+func getContextFunctions() []contextFunctionEntry {
+	return append(getWaitFunctions(), getDataClusterFunctions()...)
+}
+
+// getWaitFunctions returns the functions polling until a condition is met,
+// they are not available to the expressions they evaluate.
+func getWaitFunctions() []contextFunctionEntry {
+	return []contextFunctionEntry{{
+		Name: k8sWait,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpAny}},
+			{Types: []functions.JpType{functions.JpString}, Optional: true},
+			{Types: []functions.JpType{functions.JpString}, Optional: true},
+			{Types: []functions.JpType{functions.JpString}, Optional: true},
+			{Types: []functions.JpType{functions.JpString}, Optional: true},
+			{Types: []functions.JpType{functions.JpString}, Optional: true},
+		},
+		Handler: jpKubernetesWait,
 	}, {
+		Name: allDataClusterWait,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpAny}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpString}, Optional: true},
+		},
+		Handler: jpAllDataClusterWait,
+	}}
+}
+
+func getDataClusterFunctions() []contextFunctionEntry {
+	return []contextFunctionEntry{{
 		Name: allDataClusterInformerInit,
 		Arguments: []functions.ArgSpec{
 			{Types: []functions.JpType{functions.JpAny}},
@@ -144,8 +208,22 @@ func GetFunctions() []functions.FunctionEntry {
 			{Types: []functions.JpType{functions.JpString}},
 			{Types: []functions.JpType{functions.JpString}, Optional: true},
 			{Types: []functions.JpType{functions.JpString}, Optional: true},
+			{Types: []functions.JpType{functions.JpNumber, functions.JpString}, Optional: true},
 		},
 		Handler: jpAllDataClusterList,
+	}, {
+		Name: allDataClusterPatch,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpAny}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpAny}},
+			{Types: []functions.JpType{functions.JpString}, Optional: true},
+		},
+		Handler: jpAllDataClusterPatch,
 	}, {
 		Name: allDataClusterServerVersion,
 		Arguments: []functions.ArgSpec{
@@ -201,37 +279,20 @@ func GetFunctions() []functions.FunctionEntry {
 	}}
 }
 
-func GetInnerFunc() []functions.FunctionEntry {
-	return []functions.FunctionEntry{{
-		Name: k8sWait,
-		Arguments: []functions.ArgSpec{
-			{Types: []functions.JpType{functions.JpAny}},
-			{Types: []functions.JpType{functions.JpString}, Optional: true},
-			{Types: []functions.JpType{functions.JpString}, Optional: true},
-			{Types: []functions.JpType{functions.JpString}, Optional: true},
-			{Types: []functions.JpType{functions.JpString}, Optional: true},
-			{Types: []functions.JpType{functions.JpString}, Optional: true},
-		},
-		Handler: jpKubernetesWait,
-	}, {
-		Name: allDataClusterWait,
-		Arguments: []functions.ArgSpec{
-			{Types: []functions.JpType{functions.JpAny}},
-			{Types: []functions.JpType{functions.JpString}},
-			{Types: []functions.JpType{functions.JpString}},
-			{Types: []functions.JpType{functions.JpString}},
-			{Types: []functions.JpType{functions.JpString}},
-			{Types: []functions.JpType{functions.JpString}},
-			{Types: []functions.JpType{functions.JpString}},
-			{Types: []functions.JpType{functions.JpString}},
-		},
-		Handler: jpAllDataClusterWait,
-	}, {
-		Name: "table_print",
-		Arguments: []functions.ArgSpec{
-			{Types: []functions.JpType{functions.JpAny}},
-			{Types: []functions.JpType{functions.JpString}, Optional: true},
-		},
-		Handler: tableFormat,
-	}}
+func bindContext(ctx context.Context, entries ...contextFunctionEntry) []functions.FunctionEntry {
+	out := make([]functions.FunctionEntry, 0, len(entries))
+	for _, entry := range entries {
+		out = append(out, functions.FunctionEntry{
+			Name:      entry.Name,
+			Arguments: entry.Arguments,
+			Handler:   withContext(ctx, entry.Handler),
+		})
+	}
+	return out
+}
+
+func withContext(ctx context.Context, handler func(context.Context, []any) (any, error)) functions.JpFunction {
+	return func(arguments []any) (any, error) {
+		return handler(ctx, arguments)
+	}
 }
@@ -1,6 +1,7 @@
 package functions
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,3 +10,7 @@ import (
 func TestGetFunctions(t *testing.T) {
 	assert.Equal(t, 9, len(GetFunctions()))
 }
+
+func TestGetContextFunctions(t *testing.T) {
+	assert.Equal(t, 12, len(GetContextFunctions(context.Background())))
+}
@@ -3,11 +3,11 @@ package functions
 import (
 	"context"
 	"errors"
-	"fmt"
 	"strings"
 	"time"
 
 	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/functions/tracectx"
 	"github.com/kyverno/kyverno-json/pkg/engine/template"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -135,8 +135,7 @@ func jpKubernetesList(arguments []any) (any, error) {
 	return list.UnstructuredContent(), nil
 }
 
-func jpKubernetesWait(arguments []any) (any, error) {
-	fmt.Println("jpKubernetesWait", arguments)
+func jpKubernetesWait(ctx context.Context, arguments []any) (any, error) {
 	var path, expect string
 	if len(arguments) >= 5 {
 		if err := getArg(arguments, 4, &path); err != nil {
@@ -148,11 +147,11 @@ func jpKubernetesWait(arguments []any) (any, error) {
 			return nil, err
 		}
 	}
-	fmt.Println("jpKubernetesWait path:", path, "expect:", expect)
-	err := wait.PollUntilContextTimeout(context.TODO(), 1*time.Second, 60*time.Second, true, func(ctx context.Context) (done bool, err error) {
+	trace := tracectx.New(ctx).Step("jpKubernetesWait args:", path, expect)
+	err := wait.PollUntilContextTimeout(ctx, 1*time.Second, 60*time.Second, true, func(ctx context.Context) (done bool, err error) {
 		list, err := jpKubernetesList(arguments)
 		if err != nil {
-			fmt.Println("jpKubernetesWait jpKubernetesList err:", err)
+			trace.InfoF("jpKubernetesWait jpKubernetesList err: %s", err)
 			return false, err
 		}
 		//marshal, err := json.Marshal(list)
@@ -166,10 +165,10 @@ func jpKubernetesWait(arguments []any) (any, error) {
 
 		search, err := template.Execute(ctx, path, list, nil, template.WithFunctionCaller(InnerCaller()))
 		if err != nil {
-			fmt.Println("jpKubernetesWait Search err:", err)
+			trace.InfoF("jpKubernetesWait Search err: %s", err)
 			return false, err
 		}
-		fmt.Println("jpKubernetesWait search result:", fmt.Sprintf("%v", search), "expect:", expect)
+		trace.DebugF("jpKubernetesWait search result: %v expect: %s", search, expect)
 
 		return search == expect, nil
 	})
@@ -2,19 +2,23 @@ package functions
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"code.byted.org/inf/superkruise/pkg/clientcache"
+	chainsawclient "github.com/kyverno/chainsaw/pkg/client"
 	"github.com/kyverno/chainsaw/pkg/engine/client"
 	"github.com/kyverno/chainsaw/pkg/engine/functions/tracectx"
 	"github.com/kyverno/kyverno-json/pkg/engine/template"
+	"go.uber.org/multierr"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -22,20 +26,21 @@ import (
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func createClusterClient(cluster string, f clientcache.ClientFactoryInterface) {
-	//fmt.Println("before CreateClient", cluster, time.Now())
-	for {
-		_, err := f.CreateClient(cluster, &cache.Options{})
-		if err != nil {
-			time.Sleep(1 * time.Second)
-		} else {
-			break
-		}
+func createClusterClient(ctx context.Context, cluster string, f clientcache.ClientFactoryInterface) error {
+	if _, err := client.WaitForDataCluster(ctx, f, cluster); err != nil {
+		return err
+	}
+	if _, err := f.CreateClient(cluster, &cache.Options{}); err != nil {
+		return fmt.Errorf("cluster: %s, err: %w", cluster, err)
 	}
-	//fmt.Println("after CreateClient", cluster, time.Now())
+	return nil
+}
+
+func dataClusterFactory(ctx context.Context, cfg *rest.Config) (clientcache.ClientFactoryInterface, error) {
+	return client.DataClusterFactoriesFromContext(ctx).Get(cfg)
 }
 
-func jpAllDataClusterInformerInit(arguments []any) (any, error) {
+func jpAllDataClusterInformerInit(ctx context.Context, arguments []any) (any, error) {
 	var cfg *rest.Config
 	var clusters, apiVersion, kind string
 	var namespace string
@@ -56,21 +61,22 @@ func jpAllDataClusterInformerInit(arguments []any) (any, error) {
 			return nil, err
 		}
 	}
-	fmt.Println("jpAllDataClusterInformerInit args:", clusters, apiVersion, kind, namespace)
 
-	f := client.InitDataClusterClient(cfg)
+	f, err := dataClusterFactory(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
 	all := strings.Split(clusters, ",")
-	ctx := tracectx.Context{}
-	err := ParallelRun(ctx.Do().Step("jpAllDataClusterInformerInit"), len(all), len(all), func(i int) error {
+	trace := tracectx.New(ctx)
+	err = ParallelRun(trace.Do().Step("jpAllDataClusterInformerInit args:", clusters, apiVersion, kind, namespace), len(all), len(all), func(i int) error {
 		cluster := all[i]
-		createClusterClient(cluster, f)
-		return nil
+		return createClusterClient(ctx, cluster, f)
 	})
 
 	return clusters, err
 }
 
-func jpAllDataClusterInformerCleanup(arguments []any) (any, error) {
+func jpAllDataClusterInformerCleanup(ctx context.Context, arguments []any) (any, error) {
 	var cfg *rest.Config
 	var clusters, apiVersion, kind string
 	var namespace string
@@ -91,12 +97,14 @@ func jpAllDataClusterInformerCleanup(arguments []any) (any, error) {
 			return nil, err
 		}
 	}
-	fmt.Println("jpAllDataClusterInformerCleanup args:", clusters, apiVersion, kind, namespace)
 
-	f := client.InitDataClusterClient(cfg)
+	f, err := dataClusterFactory(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
 	all := strings.Split(clusters, ",")
-	ctx := tracectx.Context{}
-	err := ParallelRun(ctx.Do().Step("jpAllDataClusterInformerCleanup"), len(all), len(all), func(i int) error {
+	trace := tracectx.New(ctx)
+	err = ParallelRun(trace.Do().Step("jpAllDataClusterInformerCleanup args:", clusters, apiVersion, kind, namespace), len(all), len(all), func(i int) error {
 		cluster := all[i]
 		f.DestoryClient(cluster)
 		return nil
@@ -105,11 +113,16 @@ func jpAllDataClusterInformerCleanup(arguments []any) (any, error) {
 	return clusters, err
 }
 
-func jpAllDataClusterList(arguments []any) (any, error) {
+// DataClusterAnnotation is set on every item returned by data_cluster_list,
+// it holds the name of the data cluster the item was listed from.
+const DataClusterAnnotation = "chainsaw.kyverno.io/data-cluster"
+
+func jpAllDataClusterList(ctx context.Context, arguments []any) (any, error) {
 	var cfg *rest.Config
 	var clusters, apiVersion, kind string
 	var namespace string
 	var label string
+	var tolerate int
 	if err := getArg(arguments, 0, &cfg); err != nil {
 		return nil, err
 	}
@@ -132,43 +145,95 @@ func jpAllDataClusterList(arguments []any) (any, error) {
 			return nil, err
 		}
 	}
-	fmt.Println("jpAllDataClusterList Args:", clusters, apiVersion, kind, label, namespace)
+	if len(arguments) >= 7 {
+		value, err := getIntArg(arguments, 6)
+		if err != nil {
+			return nil, err
+		}
+		tolerate = value
+	}
 	var list unstructured.UnstructuredList
 	list.SetAPIVersion(apiVersion)
 	list.SetKind(kind)
 
 	all := strings.Split(clusters, ",")
-	ctx := tracectx.Context{}
-	err := ParallelRun(ctx.Do().Step("jpAllDataClusterList Args:", clusters, apiVersion, kind, label, namespace),
-		len(all), len(all), func(i int) error {
-			cluster := all[i]
-			client, err := getDataClusterClient(cluster, arguments)
+	trace := tracectx.New(ctx)
+	items, unreachable, err := dataClusterListAll(trace.Do().Step("jpAllDataClusterList Args:", clusters, apiVersion, kind, label, namespace, tolerate),
+		all, tolerate, func(cluster string) ([]unstructured.Unstructured, error) {
+			client, err := getDataClusterClient(ctx, cluster, arguments)
 			if err != nil {
-				return err
+				return nil, err
 			}
-
-			dataList, err := dataK8sList(client, arguments[1:])
+			dataList, err := dataK8sList(ctx, client, arguments[1:min(len(arguments), 6)])
 			if err == nil {
-				//fmt.Println("jpAllDataClusterList get", Prettify(dataList))
-				list.Items = append(list.Items, dataList.Items...)
-				return nil
+				return dataList.Items, nil
 			}
 			if apierrors.IsNotFound(err) {
-				fmt.Println("jpAllDataClusterList not found", "not found")
-				return nil
+				trace.InfoF("jpAllDataClusterList %s: not found", cluster)
+				return nil, nil
 			}
-			return err
+			return nil, err
 		})
-	//fmt.Println("jpAllDataClusterList all", Prettify(list))
-	return list.UnstructuredContent(), err
+	list.Items = items
+	out := list.UnstructuredContent()
+	if len(unreachable) != 0 {
+		out["unreachable"] = unreachable
+	}
+	return out, err
+}
+
+// dataClusterListAll lists items from all clusters concurrently, every item is
+// annotated with its source cluster. Up to tolerate clusters can fail, they
+// are returned as unreachable instead of failing the whole listing.
+func dataClusterListAll(
+	trace *tracectx.Context,
+	clusters []string,
+	tolerate int,
+	list func(string) ([]unstructured.Unstructured, error),
+) ([]unstructured.Unstructured, map[string]any, error) {
+	items := make([][]unstructured.Unstructured, len(clusters))
+	errs := make([]error, len(clusters))
+	// each goroutine only writes its own index, errors are handled below
+	if err := ParallelRun(trace, len(clusters), len(clusters), func(i int) error {
+		items[i], errs[i] = list(clusters[i])
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	var out []unstructured.Unstructured
+	var failures []error
+	unreachable := map[string]any{}
+	for i, cluster := range clusters {
+		if errs[i] != nil {
+			unreachable[cluster] = errs[i].Error()
+			failures = append(failures, fmt.Errorf("cluster: %s, err: %w", cluster, errs[i]))
+			continue
+		}
+		for _, item := range items[i] {
+			annotations := item.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[DataClusterAnnotation] = cluster
+			item.SetAnnotations(annotations)
+			out = append(out, item)
+		}
+	}
+	if len(failures) > tolerate {
+		return out, unreachable, multierr.Combine(failures...)
+	}
+	for cluster, err := range unreachable {
+		trace.InfoF("jpAllDataClusterList %s: tolerated unreachable cluster: %s", cluster, err)
+	}
+	return out, unreachable, nil
 }
 
-func jpAllDataClusterPatch(arguments []any) (any, error) {
+func jpAllDataClusterPatch(ctx context.Context, arguments []any) (any, error) {
 	var cfg *rest.Config
 	var clusters, apiVersion, kind string
 	var namespace string
 	var label string
-	var newspec string
+	var patchType string
 	if err := getArg(arguments, 0, &cfg); err != nil {
 		return nil, err
 	}
@@ -181,94 +246,251 @@ func jpAllDataClusterPatch(arguments []any) (any, error) {
 	if err := getArg(arguments, 3, &kind); err != nil {
 		return nil, err
 	}
-	if len(arguments) >= 5 {
-		if err := getArg(arguments, 4, &namespace); err != nil {
-			return nil, err
-		}
+	if err := getArg(arguments, 4, &namespace); err != nil {
+		return nil, err
 	}
-	if len(arguments) >= 6 {
-		if err := getArg(arguments, 5, &label); err != nil {
-			return nil, err
-		}
+	if err := getArg(arguments, 5, &label); err != nil {
+		return nil, err
 	}
-	if len(arguments) >= 7 {
-		if err := getArg(arguments, 6, &newspec); err != nil {
+	newspec, err := getArgAt(arguments, 6)
+	if err != nil {
+		return nil, err
+	}
+	if len(arguments) >= 8 {
+		if err := getArg(arguments, 7, &patchType); err != nil {
 			return nil, err
 		}
 	}
-	fmt.Println("jpAllDataClusterPatch Args:", clusters, apiVersion, kind, label, namespace)
+	patch, err := newDataClusterPatch(newspec, patchType)
+	if err != nil {
+		return nil, err
+	}
 
 	all := strings.Split(clusters, ",")
-	ctx := tracectx.Context{}
-	err := ParallelRun(ctx.Do().Step("jpAllDataClusterPatch Args:", clusters, apiVersion, kind, label, namespace),
+	patched := make([][]any, len(all))
+	trace := tracectx.New(ctx)
+	err = ParallelRun(trace.Do().Step("jpAllDataClusterPatch Args:", clusters, apiVersion, kind, label, namespace, patchType),
 		len(all), len(all), func(i int) error {
 			cluster := all[i]
-			client, err := getDataClusterClient(cluster, arguments)
+			client, err := getDataClusterClient(ctx, cluster, arguments)
 			if err != nil {
 				return err
 			}
 
-			dataList, err := dataK8sList(client, arguments[1:])
+			dataList, err := dataK8sList(ctx, client, arguments[1:6])
 			if err == nil {
-				err = ParallelRun(ctx.Do().Step("dataClusterPatch:"),
-					len(dataList.Items), len(dataList.Items), func(i int) error {
-						item := dataList.Items[i]
-						return client.Patch(context.Background(), &item, ctrlclient.Merge)
-					})
-				return err
+				items, err := dataClusterPatch(ctx, client, dataList.Items, patch)
+				patched[i] = items
+				if err != nil {
+					return fmt.Errorf("cluster: %s, err: %w", cluster, err)
+				}
+				return nil
 			}
 			if apierrors.IsNotFound(err) {
 				return nil
 			}
 			return err
 		})
-	return nil, err
+	var out []any
+	for _, items := range patched {
+		out = append(out, items...)
+	}
+	return out, err
 }
 
-func jpAllDataClusterWait(arguments []any) (any, error) {
-	var path, expect string
-	if len(arguments) >= 7 {
-		if err := getArg(arguments, 6, &path); err != nil {
+func newDataClusterPatch(newspec any, patchType string) (ctrlclient.Patch, error) {
+	var pt types.PatchType
+	switch strings.ToLower(patchType) {
+	case "", "merge":
+		pt = types.MergePatchType
+	case "json":
+		pt = types.JSONPatchType
+	case "strategic":
+		pt = types.StrategicMergePatchType
+	default:
+		return nil, fmt.Errorf("unsupported patch type: %s", patchType)
+	}
+	var data []byte
+	switch newspec := newspec.(type) {
+	case nil:
+		return nil, errors.New("patch is nil")
+	case string:
+		data = []byte(newspec)
+	case []byte:
+		data = newspec
+	default:
+		bytes, err := json.Marshal(newspec)
+		if err != nil {
 			return nil, err
 		}
+		data = bytes
 	}
-	if len(arguments) >= 8 {
-		if err := getArg(arguments, 7, &expect); err != nil {
+	if !json.Valid(data) {
+		return nil, errors.New("patch is not valid json")
+	}
+	return ctrlclient.RawPatch(pt, data), nil
+}
+
+func dataClusterPatch(ctx context.Context, client chainsawclient.Client, items []unstructured.Unstructured, patch ctrlclient.Patch) ([]any, error) {
+	var patched []any
+	for i := range items {
+		item := items[i]
+		if err := client.Patch(ctx, &item, patch); err != nil {
+			return patched, fmt.Errorf("%s/%s: %w", item.GetNamespace(), item.GetName(), err)
+		}
+		patched = append(patched, item.UnstructuredContent())
+	}
+	return patched, nil
+}
+
+const (
+	defaultDataClusterWaitTimeout  = 60 * time.Second
+	defaultDataClusterWaitInterval = 1 * time.Second
+)
+
+type dataClusterWaitStatus struct {
+	matched bool
+	result  any
+	err     error
+}
+
+func jpAllDataClusterWait(ctx context.Context, arguments []any) (any, error) {
+	var cfg *rest.Config
+	var clusters, path, expect string
+	interval := defaultDataClusterWaitInterval
+	if err := getArg(arguments, 0, &cfg); err != nil {
+		return nil, err
+	}
+	if err := getArg(arguments, 1, &clusters); err != nil {
+		return nil, err
+	}
+	if err := getArg(arguments, 6, &path); err != nil {
+		return nil, err
+	}
+	if err := getArg(arguments, 7, &expect); err != nil {
+		return nil, err
+	}
+	if len(arguments) >= 9 {
+		var value string
+		if err := getArg(arguments, 8, &value); err != nil {
 			return nil, err
 		}
+		if value != "" {
+			parsed, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, err
+			}
+			if parsed <= 0 {
+				return nil, fmt.Errorf("invalid interval: %s", value)
+			}
+			interval = parsed
+		}
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultDataClusterWaitTimeout)
+		defer cancel()
 	}
 
 	path = strings.ReplaceAll(path, "`", "'")
-	fmt.Println("jpAllDataClusterWait", path, expect)
-	err := wait.PollUntilContextTimeout(context.TODO(), 1*time.Second, 60*time.Second, true, func(ctx context.Context) (done bool, err error) {
-		list, err := jpAllDataClusterList(arguments)
-		if err != nil {
-			fmt.Println("jpAllDataClusterWait list err:", err)
-			return false, err
+	trace := tracectx.New(ctx).Step("jpAllDataClusterWait args:", clusters, path, expect, interval)
+	all := strings.Split(clusters, ",")
+	statuses := make([]dataClusterWaitStatus, len(all))
+	err := wait.PollUntilContextCancel(ctx, interval, true, func(ctx context.Context) (bool, error) {
+		var pending []int
+		for i := range all {
+			if !statuses[i].matched {
+				pending = append(pending, i)
+			}
 		}
-		path = strings.ReplaceAll(path, "`", "'")
-		search, err := template.Execute(ctx, path, list, nil, template.WithFunctionCaller(InnerCaller()))
-		if err != nil {
-			fmt.Println("jpAllDataClusterWait search err:", err)
-			return false, err
+		// errors are recorded per cluster and retried, they don't abort the wait
+		_ = ParallelRun(trace.Do(), len(pending), len(pending), func(i int) error {
+			idx := pending[i]
+			statuses[idx] = dataClusterWaitPoll(ctx, all[idx], arguments, path, expect)
+			return nil
+		})
+		for i := range statuses {
+			if !statuses[i].matched {
+				return false, nil
+			}
 		}
-		fmt.Println("jpAllDataClusterWait search result:", search, "expect:", expect)
-
-		return search == expect, nil
+		return true, nil
 	})
+	if err != nil {
+		return nil, dataClusterWaitError(all, statuses, err)
+	}
+	return dataClusterWaitResult(all, statuses), nil
+}
 
-	return err == nil, err
+func dataClusterWaitError(clusters []string, statuses []dataClusterWaitStatus, err error) error {
+	var pending []string
+	for i, cluster := range clusters {
+		status := statuses[i]
+		switch {
+		case status.matched:
+		case status.err != nil:
+			pending = append(pending, fmt.Sprintf("%s (error: %s)", cluster, status.err))
+		default:
+			pending = append(pending, fmt.Sprintf("%s (result: %v)", cluster, status.result))
+		}
+	}
+	return fmt.Errorf("data clusters not matched [%s]: %w", strings.Join(pending, ", "), err)
 }
 
-func jpGetDataClusterClient(arguments []any) (any, error) {
+func dataClusterWaitPoll(ctx context.Context, cluster string, arguments []any, path, expect string) dataClusterWaitStatus {
+	client, err := getDataClusterClient(ctx, cluster, arguments)
+	if err != nil {
+		return dataClusterWaitStatus{err: err}
+	}
+	list, err := dataK8sList(ctx, client, arguments[1:6])
+	if err != nil {
+		return dataClusterWaitStatus{err: err}
+	}
+	search, err := template.Execute(ctx, path, list.UnstructuredContent(), nil, template.WithFunctionCaller(InnerCaller()))
+	if err != nil {
+		return dataClusterWaitStatus{err: err}
+	}
+	return dataClusterWaitStatus{
+		matched: search == expect,
+		result:  search,
+	}
+}
+
+func dataClusterWaitResult(clusters []string, statuses []dataClusterWaitStatus) map[string]any {
+	matched := true
+	pending := []any{}
+	perCluster := map[string]any{}
+	for i, cluster := range clusters {
+		status := statuses[i]
+		entry := map[string]any{
+			"matched": status.matched,
+			"result":  status.result,
+		}
+		if status.err != nil {
+			entry["error"] = status.err.Error()
+		}
+		perCluster[cluster] = entry
+		if !status.matched {
+			matched = false
+			pending = append(pending, cluster)
+		}
+	}
+	return map[string]any{
+		"matched":  matched,
+		"pending":  pending,
+		"clusters": perCluster,
+	}
+}
+
+func jpGetDataClusterClient(ctx context.Context, arguments []any) (any, error) {
 	var cluster string
 	if err := getArg(arguments, 1, &cluster); err != nil {
 		return nil, err
 	}
-	return getDataClusterClient(cluster, arguments)
+	return getDataClusterClient(ctx, cluster, arguments)
 }
 
-func getDataClusterClient(cluster string, arguments []any) (clientcache.ClientInterface, error) {
+func getDataClusterClient(ctx context.Context, cluster string, arguments []any) (clientcache.ClientInterface, error) {
 	var cfg *rest.Config
 	var apiVersion, kind string
 	if err := getArg(arguments, 0, &cfg); err != nil {
@@ -283,12 +505,15 @@ func getDataClusterClient(cluster string, arguments []any) (clientcache.ClientIn
 	if err := getArg(arguments, 3, &kind); err != nil {
 		return nil, err
 	}
-	fmt.Println("getDataClusterClient args:", cluster, apiVersion, kind)
+	trace := tracectx.New(ctx)
+	trace.DebugF("getDataClusterClient args: %s %s %s", cluster, apiVersion, kind)
 
-	f := client.InitDataClusterClient(cfg)
+	f, err := dataClusterFactory(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
 	var client clientcache.ClientInterface
-	ctx := tracectx.Context{}
-	err := ParallelRun(ctx.Do(), 1, 1, func(i int) error {
+	err = ParallelRun(trace.Do(), 1, 1, func(i int) error {
 		var dataList unstructured.UnstructuredList
 		dataList.SetAPIVersion(apiVersion)
 		dataList.SetKind(kind)
@@ -303,7 +528,7 @@ func getDataClusterClient(cluster string, arguments []any) (clientcache.ClientIn
 	return client, err
 }
 
-func jpAllDataClusterServerVersion(arguments []any) (any, error) {
+func jpAllDataClusterServerVersion(ctx context.Context, arguments []any) (any, error) {
 	var cfg *rest.Config
 	var clusters string
 	if err := getArg(arguments, 0, &cfg); err != nil {
@@ -316,11 +541,14 @@ func jpAllDataClusterServerVersion(arguments []any) (any, error) {
 		return nil, err
 	}
 
-	var versions []string
-	f := client.InitDataClusterClient(cfg)
+	f, err := dataClusterFactory(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
 	all := strings.Split(clusters, ",")
-	ctx := tracectx.Context{}
-	err := ParallelRun(ctx.Do().Step("jpAllDataClusterServerVersion"), len(all), len(all), func(i int) error {
+	perCluster := make([]string, len(all))
+	trace := tracectx.New(ctx)
+	err = ParallelRun(trace.Do().Step("jpAllDataClusterServerVersion"), len(all), len(all), func(i int) error {
 		cluster := all[i]
 		//config := f.GetRestConfigByClusterName(cluster)
 		//if config == nil {
@@ -331,26 +559,31 @@ func jpAllDataClusterServerVersion(arguments []any) (any, error) {
 		//	return err
 		//}
 
-		client, err := getDataClientSet(f, cluster)
+		client, err := getDataClientSet(ctx, f, cluster)
 		if err != nil {
 			return err
 		}
 		version, err := client.ServerVersion()
 		if err != nil {
-			fmt.Println("jpAllDataClusterServerVersion", cluster, err.Error())
 			return err
 		}
 
-		fmt.Println("jpAllDataClusterServerVersion", cluster, Prettify(version))
-		versions = append(versions, cluster, Prettify(version))
+		trace.InfoF("jpAllDataClusterServerVersion %s %s", cluster, Prettify(version))
+		perCluster[i] = Prettify(version)
 		return nil
 	})
+	var versions []string
+	for i, cluster := range all {
+		if perCluster[i] != "" {
+			versions = append(versions, cluster, perCluster[i])
+		}
+	}
 
 	//fmt.Println("jpAllDataClusterServerVersion", Prettify(versions))
 	return Prettify(versions), err
 }
 
-func jpAllDataClusterCreateNamespace(arguments []any) (any, error) {
+func jpAllDataClusterCreateNamespace(ctx context.Context, arguments []any) (any, error) {
 	var cfg *rest.Config
 	var clusters, namespace string
 	if err := getArg(arguments, 0, &cfg); err != nil {
@@ -367,19 +600,22 @@ func jpAllDataClusterCreateNamespace(arguments []any) (any, error) {
 		return nil, err
 	}
 
-	f := client.InitDataClusterClient(cfg)
+	f, err := dataClusterFactory(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
 	all := strings.Split(clusters, ",")
-	ctx := tracectx.Context{}
-	err := ParallelRun(ctx.Do().Step("jpAllDataClusterCreateNamespace args:", clusters, namespace),
+	trace := tracectx.New(ctx)
+	err = ParallelRun(trace.Do().Step("jpAllDataClusterCreateNamespace args:", clusters, namespace),
 		len(all), len(all), func(i int) error {
 			cluster := all[i]
-			client, err := getDataClientSet(f, cluster)
+			client, err := getDataClientSet(ctx, f, cluster)
 			if err != nil {
 				return err
 			}
 			ns := &v1.Namespace{}
 			ns.Name = namespace
-			_, err = client.CoreV1().Namespaces().Create(context.TODO(), ns, metav1.CreateOptions{})
+			_, err = client.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
 
 			if err != nil {
 				return fmt.Errorf("cluster: %s, err: %w", cluster, err)
@@ -390,10 +626,10 @@ func jpAllDataClusterCreateNamespace(arguments []any) (any, error) {
 	return clusters, err
 }
 
-func getDataClientSet(f clientcache.ClientFactoryInterface, cluster string) (*clientset.Clientset, error) {
-	config := f.GetRestConfigByClusterName(cluster)
-	if config == nil {
-		return nil, fmt.Errorf("GetRestConfigByClusterName of %s return nil", cluster)
+func getDataClientSet(ctx context.Context, f clientcache.ClientFactoryInterface, cluster string) (*clientset.Clientset, error) {
+	config, err := client.WaitForDataCluster(ctx, f, cluster)
+	if err != nil {
+		return nil, err
 	}
 	client, err := clientset.NewForConfig(config)
 	if err != nil {
@@ -402,7 +638,7 @@ func getDataClientSet(f clientcache.ClientFactoryInterface, cluster string) (*cl
 	return client, nil
 }
 
-func jpAllDataClusterDeleteNamespace(arguments []any) (any, error) {
+func jpAllDataClusterDeleteNamespace(ctx context.Context, arguments []any) (any, error) {
 	var cfg *rest.Config
 	var clusters, namespace string
 	if err := getArg(arguments, 0, &cfg); err != nil {
@@ -419,18 +655,21 @@ func jpAllDataClusterDeleteNamespace(arguments []any) (any, error) {
 		return nil, err
 	}
 
-	f := client.InitDataClusterClient(cfg)
+	f, err := dataClusterFactory(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
 	all := strings.Split(clusters, ",")
-	ctx := tracectx.Context{}
-	err := ParallelRun(ctx.Do().Step("jpAllDataClusterDeleteNamespace args:", clusters, namespace),
+	trace := tracectx.New(ctx)
+	err = ParallelRun(trace.Do().Step("jpAllDataClusterDeleteNamespace args:", clusters, namespace),
 		len(all), len(all), func(i int) error {
 			cluster := all[i]
-			client, err := getDataClientSet(f, cluster)
+			client, err := getDataClientSet(ctx, f, cluster)
 			if err != nil {
 				return err
 			}
 
-			err = client.CoreV1().Namespaces().Delete(context.TODO(), namespace, metav1.DeleteOptions{})
+			err = client.CoreV1().Namespaces().Delete(ctx, namespace, metav1.DeleteOptions{})
 			if err != nil {
 				return fmt.Errorf("delete ns in cluster: %s, err: %w", cluster, err)
 			}
@@ -440,7 +679,7 @@ func jpAllDataClusterDeleteNamespace(arguments []any) (any, error) {
 	return nil, err
 }
 
-func jpDataKubernetesGet(arguments []any) (any, error) {
+func jpDataKubernetesGet(ctx context.Context, arguments []any) (any, error) {
 	var client clientcache.ClientInterface
 	var apiVersion, kind string
 	var key ctrlclient.ObjectKey
@@ -462,18 +701,18 @@ func jpDataKubernetesGet(arguments []any) (any, error) {
 	var obj unstructured.Unstructured
 	obj.SetAPIVersion(apiVersion)
 	obj.SetKind(kind)
-	if err := client.Get(context.Background(), key, &obj); err != nil {
+	if err := client.Get(ctx, key, &obj); err != nil {
 		return nil, err
 	}
 	return obj.UnstructuredContent(), nil
 }
 
-func jpDataKubernetesList(arguments []any) (any, error) {
+func jpDataKubernetesList(ctx context.Context, arguments []any) (any, error) {
 	var client clientcache.ClientInterface
 	if err := getArg(arguments, 0, &client); err != nil {
 		return nil, err
 	}
-	list, err := dataK8sList(client, arguments)
+	list, err := dataK8sList(ctx, client, arguments)
 	if err == nil {
 		return list.UnstructuredContent(), err
 	} else {
@@ -481,7 +720,7 @@ func jpDataKubernetesList(arguments []any) (any, error) {
 	}
 }
 
-func dataK8sList(client clientcache.ClientInterface, arguments []any) (*unstructured.UnstructuredList, error) {
+func dataK8sList(ctx context.Context, client clientcache.ClientInterface, arguments []any) (*unstructured.UnstructuredList, error) {
 	var apiVersion, kind, label, namespace string
 	if err := getArg(arguments, 1, &apiVersion); err != nil {
 		return nil, err
@@ -509,7 +748,7 @@ func dataK8sList(client clientcache.ClientInterface, arguments []any) (*unstruct
 	selector := NewLabelSelector(strings.Split(label, ",")...)
 	listOptions = append(listOptions, ctrlclient.MatchingLabelsSelector{Selector: selector})
 
-	err := client.List(context.Background(), &list, listOptions...)
+	err := client.List(ctx, &list, listOptions...)
 	if err == nil {
 		//fmt.Println("jpDataKubernetesList", Prettify(list))
 		return &list, nil
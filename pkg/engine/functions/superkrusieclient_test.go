@@ -0,0 +1,253 @@
+package functions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/functions/tracectx"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_newDataClusterPatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		newspec   any
+		patchType string
+		wantType  types.PatchType
+		wantData  string
+		wantErr   bool
+	}{{
+		name:    "nil",
+		wantErr: true,
+	}, {
+		name:     "merge string",
+		newspec:  `{"spec":{"replicas":2}}`,
+		wantType: types.MergePatchType,
+		wantData: `{"spec":{"replicas":2}}`,
+	}, {
+		name:      "merge object",
+		newspec:   map[string]any{"spec": map[string]any{"replicas": 2.0}},
+		patchType: "merge",
+		wantType:  types.MergePatchType,
+		wantData:  `{"spec":{"replicas":2}}`,
+	}, {
+		name:      "json",
+		newspec:   []any{map[string]any{"op": "remove", "path": "/spec/paused"}},
+		patchType: "json",
+		wantType:  types.JSONPatchType,
+		wantData:  `[{"op":"remove","path":"/spec/paused"}]`,
+	}, {
+		name:      "strategic",
+		newspec:   `{"spec":{"replicas":2}}`,
+		patchType: "Strategic",
+		wantType:  types.StrategicMergePatchType,
+		wantData:  `{"spec":{"replicas":2}}`,
+	}, {
+		name:      "bad type",
+		newspec:   `{}`,
+		patchType: "apply",
+		wantErr:   true,
+	}, {
+		name:    "bad json",
+		newspec: `{`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newDataClusterPatch(tt.newspec, tt.patchType)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantType, got.Type())
+			data, err := got.Data(nil)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.wantData, string(data))
+		})
+	}
+}
+
+func Test_dataClusterPatch(t *testing.T) {
+	item := func(name string) unstructured.Unstructured {
+		var obj unstructured.Unstructured
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetNamespace("default")
+		obj.SetName(name)
+		return obj
+	}
+	patch := ctrlclient.RawPatch(types.MergePatchType, []byte(`{"data":{"foo":"bar"}}`))
+	t.Run("success", func(t *testing.T) {
+		client := &tclient.FakeClient{
+			PatchFn: func(_ context.Context, _ int, obj ctrlclient.Object, p ctrlclient.Patch, _ ...ctrlclient.PatchOption) error {
+				assert.Equal(t, patch, p)
+				return unstructured.SetNestedField(obj.(*unstructured.Unstructured).Object, "bar", "data", "foo")
+			},
+		}
+		got, err := dataClusterPatch(context.TODO(), client, []unstructured.Unstructured{item("a"), item("b")}, patch)
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+		for _, obj := range got {
+			value, _, _ := unstructured.NestedString(obj.(map[string]any), "data", "foo")
+			assert.Equal(t, "bar", value)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		client := &tclient.FakeClient{
+			PatchFn: func(_ context.Context, call int, _ ctrlclient.Object, _ ctrlclient.Patch, _ ...ctrlclient.PatchOption) error {
+				if call == 1 {
+					return errors.New("dummy")
+				}
+				return nil
+			},
+		}
+		got, err := dataClusterPatch(context.TODO(), client, []unstructured.Unstructured{item("a"), item("b")}, patch)
+		assert.EqualError(t, err, "default/b: dummy")
+		assert.Len(t, got, 1)
+	})
+}
+
+func Test_jpAllDataClusterWait_Interval(t *testing.T) {
+	arguments := []any{(*rest.Config)(nil), "a", "v1", "ConfigMap", "default", "", "length(items)", "1", "not-a-duration"}
+	_, err := jpAllDataClusterWait(context.TODO(), arguments)
+	assert.EqualError(t, err, `time: invalid duration "not-a-duration"`)
+	arguments[8] = "-1s"
+	_, err = jpAllDataClusterWait(context.TODO(), arguments)
+	assert.EqualError(t, err, "invalid interval: -1s")
+}
+
+func Test_dataClusterWaitResult(t *testing.T) {
+	got := dataClusterWaitResult(
+		[]string{"a", "b"},
+		[]dataClusterWaitStatus{
+			{matched: true, result: "1"},
+			{err: errors.New("dummy")},
+		},
+	)
+	assert.Equal(t, map[string]any{
+		"matched": false,
+		"pending": []any{"b"},
+		"clusters": map[string]any{
+			"a": map[string]any{"matched": true, "result": "1"},
+			"b": map[string]any{"matched": false, "result": nil, "error": "dummy"},
+		},
+	}, got)
+	got = dataClusterWaitResult([]string{"a"}, []dataClusterWaitStatus{{matched: true, result: "1"}})
+	assert.Equal(t, true, got["matched"])
+	assert.Equal(t, []any{}, got["pending"])
+}
+
+func Test_dataClusterWaitError(t *testing.T) {
+	err := dataClusterWaitError(
+		[]string{"a", "b", "c"},
+		[]dataClusterWaitStatus{
+			{matched: true, result: "1"},
+			{err: errors.New("dummy")},
+			{result: "0"},
+		},
+		context.DeadlineExceeded,
+	)
+	assert.EqualError(t, err, "data clusters not matched [b (error: dummy), c (result: 0)]: context deadline exceeded")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func Test_dataClusterListAll(t *testing.T) {
+	item := func(name string) unstructured.Unstructured {
+		var obj unstructured.Unstructured
+		obj.SetName(name)
+		return obj
+	}
+	list := func(cluster string) ([]unstructured.Unstructured, error) {
+		switch cluster {
+		case "down-1", "down-2":
+			return nil, errors.New("unreachable")
+		case "empty":
+			return nil, nil
+		default:
+			return []unstructured.Unstructured{item(cluster + "-a"), item(cluster + "-b")}, nil
+		}
+	}
+	tests := []struct {
+		name            string
+		clusters        []string
+		tolerate        int
+		wantItems       map[string]string
+		wantUnreachable []string
+		wantErr         bool
+	}{{
+		name:      "all reachable",
+		clusters:  []string{"a", "b", "empty"},
+		wantItems: map[string]string{"a-a": "a", "a-b": "a", "b-a": "b", "b-b": "b"},
+	}, {
+		name:            "unreachable not tolerated",
+		clusters:        []string{"a", "down-1"},
+		wantItems:       map[string]string{"a-a": "a", "a-b": "a"},
+		wantUnreachable: []string{"down-1"},
+		wantErr:         true,
+	}, {
+		name:            "unreachable tolerated",
+		clusters:        []string{"a", "down-1"},
+		tolerate:        1,
+		wantItems:       map[string]string{"a-a": "a", "a-b": "a"},
+		wantUnreachable: []string{"down-1"},
+	}, {
+		name:            "too many unreachable",
+		clusters:        []string{"a", "down-1", "down-2"},
+		tolerate:        1,
+		wantItems:       map[string]string{"a-a": "a", "a-b": "a"},
+		wantUnreachable: []string{"down-1", "down-2"},
+		wantErr:         true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, unreachable, err := dataClusterListAll(tracectx.New(context.TODO()), tt.clusters, tt.tolerate, list)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			got := map[string]string{}
+			for _, item := range items {
+				got[item.GetName()] = item.GetAnnotations()[DataClusterAnnotation]
+			}
+			assert.Equal(t, tt.wantItems, got)
+			assert.Len(t, unreachable, len(tt.wantUnreachable))
+			for _, cluster := range tt.wantUnreachable {
+				assert.Contains(t, unreachable, cluster)
+			}
+		})
+	}
+}
+
+func Test_getIntArg(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     any
+		want    int
+		wantErr bool
+	}{
+		{name: "number", arg: float64(2), want: 2},
+		{name: "string", arg: "3", want: 3},
+		{name: "empty", arg: "", want: 0},
+		{name: "invalid string", arg: "abc", wantErr: true},
+		{name: "invalid type", arg: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getIntArg([]any{tt.arg}, 0)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package tracectx
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 	"strings"
-	"time"
 
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/pkg/ext/output/color"
 	"github.com/mohae/deepcopy"
 )
 
@@ -13,6 +15,15 @@ type Context struct {
 	InvokeTraces []*InvokeTrace
 	StepTraces   []*StepTrace
 	Parent       *Context
+	// ctx carries the logger and the recorder of the calling test step,
+	// it is unexported so that deepcopy leaves it alone.
+	ctx context.Context
+}
+
+// New returns a trace context bound to ctx, output goes to the logger and
+// recorder of ctx instead of stdout.
+func New(ctx context.Context) *Context {
+	return &Context{ctx: ctx}
 }
 
 func (c *Context) String() string {
@@ -50,6 +61,10 @@ func (c *InvokeTrace) String() string {
 	return fmt.Sprintf("%s:%d %s", c.File, c.Line, c.Name)
 }
 
+func (c *InvokeTrace) location() string {
+	return fmt.Sprintf("%v:%v", c.File, c.Line)
+}
+
 type StepTrace struct {
 	File string
 	Line int
@@ -63,12 +78,18 @@ func (c *StepTrace) String() string {
 
 func (c *Context) Step(name string, args ...interface{}) *Context {
 	_, file, line, _ := runtime.Caller(1)
-	c.StepTraces = append(c.StepTraces, &StepTrace{file, line, name, args})
+	step := &StepTrace{file, line, name, args}
+	c.StepTraces = append(c.StepTraces, step)
+	if recorder := RecorderFromContext(c.ctx); recorder != nil {
+		recorder.AddTrace(step.String())
+	}
+	logging.LogV(c.ctx, logging.DebugVerbosity, logging.Function, logging.LogStatus, nil, step)
 	return c
 }
+
 func (c *Context) By(name string, f func()) {
 	_, file, line, _ := runtime.Caller(1)
-	fmt.Printf("%s\n %s:%d\n", name, file, line)
+	logging.LogV(c.ctx, logging.InfoVerbosity, logging.Function, logging.RunStatus, color.BoldFgCyan, message(fmt.Sprintf("%s\n %s:%d", name, file, line)))
 	f()
 }
 func (c *Context) trace(file string, line int, funcName string) {
@@ -81,6 +102,7 @@ func (c *Context) Do() *Context {
 	//newStepTrace = append(newStepTrace, c.StepTraces...)
 	newc.StepTraces = []*StepTrace{}
 	newc.Parent = c
+	newc.ctx = c.ctx
 	pc, file, line, _ := runtime.Caller(1)
 	funcName := strings.Split(runtime.FuncForPC(pc).Name(), ".")
 	if len(newc.InvokeTraces) > 0 {
@@ -90,20 +112,24 @@ func (c *Context) Do() *Context {
 	return newc
 }
 
-func nowStamp() string {
-	return time.Now().Format(time.StampMicro)
-}
 func (c *Context) InfoF(format string, args ...interface{}) {
-	fmt.Printf("Info "+nowStamp()+": "+format+"\n", args...)
+	lines := []string{fmt.Sprintf(format, args...)}
 	dep := len(c.InvokeTraces)
 	if dep > 0 {
-		file := c.InvokeTraces[dep-1].File
-		line := c.InvokeTraces[dep-1].Line
-		fmt.Printf("%v:%v\n", file, line)
+		lines = append(lines, c.InvokeTraces[dep-1].location())
 	}
 	if dep > 1 {
-		file := c.InvokeTraces[0].File
-		line := c.InvokeTraces[0].Line
-		fmt.Printf("%v:%v\n", file, line)
+		lines = append(lines, c.InvokeTraces[0].location())
 	}
+	logging.LogV(c.ctx, logging.InfoVerbosity, logging.Function, logging.LogStatus, nil, message(strings.Join(lines, "\n")))
+}
+
+func (c *Context) DebugF(format string, args ...interface{}) {
+	logging.LogV(c.ctx, logging.DebugVerbosity, logging.Function, logging.LogStatus, nil, message(fmt.Sprintf(format, args...)))
+}
+
+type message string
+
+func (m message) String() string {
+	return string(m)
 }
@@ -0,0 +1,59 @@
+package tracectx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRecorder struct {
+	traces []string
+}
+
+func (r *fakeRecorder) AddTrace(in ...string) {
+	r.traces = append(r.traces, in...)
+}
+
+func TestContext_Step(t *testing.T) {
+	logger := &tlogging.FakeLogger{}
+	recorder := &fakeRecorder{}
+	ctx := logging.IntoContext(context.Background(), logger)
+	ctx = IntoContext(ctx, recorder)
+	ctx = logging.IntoVerbosity(ctx, logging.DebugVerbosity)
+	trace := New(ctx).Do().Step("foo", "bar")
+	assert.Len(t, recorder.traces, 1)
+	assert.Contains(t, recorder.traces[0], "foo[bar]")
+	assert.Len(t, logger.Logs, 1)
+	assert.Contains(t, logger.Logs[0], "FUNCTION: LOG")
+	// the bound context survives deep copies
+	trace.Do().InfoF("hello %s", "world")
+	assert.Len(t, logger.Logs, 2)
+	assert.Contains(t, logger.Logs[1], "hello world")
+}
+
+func TestContext_Verbosity(t *testing.T) {
+	logger := &tlogging.FakeLogger{}
+	ctx := logging.IntoContext(context.Background(), logger)
+	ctx = logging.IntoVerbosity(ctx, logging.InfoVerbosity)
+	trace := New(ctx)
+	trace.Step("foo")
+	trace.DebugF("debug")
+	assert.Len(t, logger.Logs, 0)
+	trace.InfoF("info")
+	assert.Len(t, logger.Logs, 1)
+	logger = &tlogging.FakeLogger{}
+	trace = New(logging.IntoVerbosity(logging.IntoContext(context.Background(), logger), logging.QuietVerbosity))
+	trace.InfoF("info")
+	trace.By("by", func() {})
+	assert.Len(t, logger.Logs, 0)
+}
+
+func TestContext_NoContext(t *testing.T) {
+	var trace Context
+	assert.NotPanics(t, func() {
+		trace.Do().Step("foo").InfoF("bar")
+	})
+}
@@ -0,0 +1,26 @@
+package tracectx
+
+import (
+	"context"
+)
+
+// Recorder collects the trace steps recorded while evaluating functions,
+// the step report implements it.
+type Recorder interface {
+	AddTrace(in ...string)
+}
+
+type recorderKey struct{}
+
+func RecorderFromContext(ctx context.Context) Recorder {
+	if ctx != nil {
+		if v, ok := ctx.Value(recorderKey{}).(Recorder); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func IntoContext(ctx context.Context, recorder Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, recorder)
+}
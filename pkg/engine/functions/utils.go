@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/selection"
 	"reflect"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -48,6 +49,26 @@ func getArg[T any](arguments []any, index int, out *T) error {
 	}
 }
 
+func getIntArg(arguments []any, index int) (int, error) {
+	arg, err := getArgAt(arguments, index)
+	if err != nil {
+		return 0, err
+	}
+	switch value := arg.(type) {
+	case float64:
+		return int(value), nil
+	case int:
+		return value, nil
+	case string:
+		if value == "" {
+			return 0, nil
+		}
+		return strconv.Atoi(value)
+	default:
+		return 0, errors.New("invalid type")
+	}
+}
+
 // Prettify returns the string representation of a value.
 func Prettify(i interface{}) string {
 	var buf bytes.Buffer
@@ -176,23 +197,25 @@ func ParallelRun(ctx *tracectx.Context, max int, parallel int, toRun func(int) e
 	if max == 0 {
 		return nil
 	}
-	errChan := make(chan string)
+	var lock sync.Mutex
 	var errors []string
-	var wg1 sync.WaitGroup
-	wg1.Add(1)
-	go func() {
-		for err := range errChan {
-			errors = append(errors, err)
-		}
-		wg1.Done()
-	}()
+	addError := func(err string) {
+		lock.Lock()
+		defer lock.Unlock()
+		errors = append(errors, err)
+	}
+	hasErrors := func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(errors) > 0
+	}
 
 	waitChan := make(chan struct{}, parallel)
 	var wg sync.WaitGroup
 
 	//stepTimer := prometheus.NewTimer(costStep)
 	for i := 0; i < max; i++ {
-		if len(errors) > 0 {
+		if hasErrors() {
 			break
 		}
 		waitChan <- struct{}{}
@@ -207,20 +230,18 @@ func ParallelRun(ctx *tracectx.Context, max int, parallel int, toRun func(int) e
 						pcName := runtime.FuncForPC(pc).Name()
 						stack = append(stack, fmt.Sprintf("%s\n%s:%d\n", pcName, file, line))
 					}
-					errChan <- ctx.Do().Step("panic", fmt.Sprintf("%v\n%s", msg, strings.Join(stack, ""))).String()
+					addError(ctx.Do().Step("panic", fmt.Sprintf("%v\n%s", msg, strings.Join(stack, ""))).String())
 				}
 				wg.Done()
 				<-waitChan
 			}()
 			err := toRun(idx)
 			if err != nil {
-				errChan <- ctx.Do().Step("error", err.Error()).String()
+				addError(ctx.Do().Step("error", err.Error()).String())
 			}
 		}(i)
 	}
 	wg.Wait()
-	close(errChan)
-	wg1.Wait()
 
 	if len(errors) != 0 {
 		return fmt.Errorf("%s", strings.Join(errors, "\n"))
@@ -37,6 +37,13 @@ func (l *logger) Log(operation Operation, status Status, color *color.Color, arg
 	a := l.formatLog(operation, status, color, args)
 	l.t.Log(fmt.Sprint(a...))
 	l.report(operation, status, args...)
+	if recorder, ok := l.t.(ErrorRecorder); ok {
+		for _, arg := range args {
+			if section, ok := arg.(errSection); ok {
+				recorder.RecordError(section.err)
+			}
+		}
+	}
 }
 
 func (l *logger) formatLog(operation Operation, status Status, color *color.Color, args []fmt.Stringer) []any {
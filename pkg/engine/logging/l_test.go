@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -155,3 +156,22 @@ func Test_logger_WithResource(t *testing.T) {
 		})
 	}
 }
+
+type recordingTLogger struct {
+	tlogging.FakeTLogger
+	errs []error
+}
+
+func (r *recordingTLogger) RecordError(err error) {
+	r.errs = append(r.errs, err)
+}
+
+func Test_logger_Log_recordErrors(t *testing.T) {
+	mockT := &recordingTLogger{}
+	logger := NewLogger(mockT, tclock.NewFakePassiveClock(time.Now()), "testName", "stepName", nil)
+	err := errors.New("dummy")
+	logger.Log("OPERATION", "STATUS", nil, Section("SECTION", "arg"), ErrSection(err))
+	assert.Equal(t, []error{err}, mockT.errs)
+	assert.Len(t, mockT.Messages, 1)
+	assert.Contains(t, mockT.Messages[0], "=== ERROR\ndummy")
+}
@@ -11,24 +11,36 @@ type (
 )
 
 const (
-	Apply    Operation = "APPLY"
-	Assert   Operation = "ASSERT"
-	Catch    Operation = "CATCH"
-	Cleanup  Operation = "CLEANUP"
-	Command  Operation = "CMD"
-	Create   Operation = "CREATE"
-	Delete   Operation = "DELETE"
-	Error    Operation = "ERROR"
-	Finally  Operation = "FINALLY"
-	Get      Operation = "GET"
-	Internal Operation = "INTERNAL"
-	Patch    Operation = "PATCH"
-	Script   Operation = "SCRIPT"
-	Sleep    Operation = "SLEEP"
-	Stderr   Operation = "STDERR"
-	Stdout   Operation = "STDOUT"
-	Try      Operation = "TRY"
-	Update   Operation = "UPDATE"
+	Apply     Operation = "APPLY"
+	Assert    Operation = "ASSERT"
+	Catch     Operation = "CATCH"
+	Cleanup   Operation = "CLEANUP"
+	Command   Operation = "CMD"
+	Create    Operation = "CREATE"
+	Delete    Operation = "DELETE"
+	Describe  Operation = "DESCRIBE"
+	Error     Operation = "ERROR"
+	Events    Operation = "EVENTS"
+	FanOut    Operation = "FANOUT"
+	Finally   Operation = "FINALLY"
+	Function  Operation = "FUNCTION"
+	Get       Operation = "GET"
+	Internal  Operation = "INTERNAL"
+	Leaks     Operation = "LEAKS"
+	Logs      Operation = "LOGS"
+	Patch     Operation = "PATCH"
+	Preflight Operation = "PREFLIGHT"
+	Proxy     Operation = "PROXY"
+	Retry     Operation = "RETRY"
+	Script    Operation = "SCRIPT"
+	Setup     Operation = "SETUP"
+	Sleep     Operation = "SLEEP"
+	Stderr    Operation = "STDERR"
+	Stdout    Operation = "STDOUT"
+	Teardown  Operation = "TEARDOWN"
+	Try       Operation = "TRY"
+	Update    Operation = "UPDATE"
+	Wait      Operation = "WAIT"
 )
 
 const (
@@ -26,6 +26,12 @@ func Section(name string, args ...any) fmt.Stringer {
 	}
 }
 
+// errSection is a section keeping the error it was created from.
+type errSection struct {
+	fmt.Stringer
+	err error
+}
+
 func ErrSection(err error) fmt.Stringer {
 	var errs []string
 	for _, err := range multierr.Errors(err) {
@@ -39,5 +45,8 @@ func ErrSection(err error) fmt.Stringer {
 		}
 	}
 	slices.Sort(errs)
-	return Section("ERROR", strings.Join(errs, "\n"))
+	return errSection{
+		Stringer: Section("ERROR", strings.Join(errs, "\n")),
+		err:      err,
+	}
 }
@@ -4,3 +4,8 @@ type TLogger interface {
 	Log(args ...any)
 	Helper()
 }
+
+// ErrorRecorder is implemented by tests keeping the errors logged on their behalf.
+type ErrorRecorder interface {
+	RecordError(error)
+}
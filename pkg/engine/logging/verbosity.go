@@ -0,0 +1,40 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyverno/pkg/ext/output/color"
+)
+
+type Verbosity int
+
+const (
+	QuietVerbosity Verbosity = iota
+	InfoVerbosity
+	DebugVerbosity
+)
+
+const DefaultVerbosity = InfoVerbosity
+
+type verbosityKey struct{}
+
+func VerbosityFromContext(ctx context.Context) Verbosity {
+	if ctx != nil {
+		if v, ok := ctx.Value(verbosityKey{}).(Verbosity); ok {
+			return v
+		}
+	}
+	return DefaultVerbosity
+}
+
+func IntoVerbosity(ctx context.Context, verbosity Verbosity) context.Context {
+	return context.WithValue(ctx, verbosityKey{}, verbosity)
+}
+
+// LogV logs only when the verbosity carried by ctx is at least verbosity.
+func LogV(ctx context.Context, verbosity Verbosity, operation Operation, status Status, color *color.Color, args ...fmt.Stringer) {
+	if VerbosityFromContext(ctx) >= verbosity {
+		Log(ctx, operation, status, color, args...)
+	}
+}
@@ -0,0 +1,53 @@
+package logging
+
+import (
+	"context"
+	"testing"
+
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerbosityFromContext(t *testing.T) {
+	assert.Equal(t, DefaultVerbosity, VerbosityFromContext(nil))
+	assert.Equal(t, DefaultVerbosity, VerbosityFromContext(context.Background()))
+	assert.Equal(t, DebugVerbosity, VerbosityFromContext(IntoVerbosity(context.Background(), DebugVerbosity)))
+}
+
+func TestLogV(t *testing.T) {
+	tests := []struct {
+		name      string
+		verbosity Verbosity
+		level     Verbosity
+		want      int
+	}{{
+		name:      "quiet",
+		verbosity: QuietVerbosity,
+		level:     InfoVerbosity,
+		want:      0,
+	}, {
+		name:      "info",
+		verbosity: InfoVerbosity,
+		level:     InfoVerbosity,
+		want:      1,
+	}, {
+		name:      "debug hidden",
+		verbosity: InfoVerbosity,
+		level:     DebugVerbosity,
+		want:      0,
+	}, {
+		name:      "debug",
+		verbosity: DebugVerbosity,
+		level:     DebugVerbosity,
+		want:      1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &tlogging.FakeLogger{}
+			ctx := IntoContext(context.Background(), logger)
+			ctx = IntoVerbosity(ctx, tt.verbosity)
+			LogV(ctx, tt.level, Function, LogStatus, nil)
+			assert.Len(t, logger.Logs, tt.want)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package collect
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmespath-community/go-jmespath/pkg/binding"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+type describeOperation struct {
+	client    client.Client
+	namespace string
+	collector v1alpha1.Describe
+}
+
+// NewDescribe creates an operation describing resources and their events,
+// they are exposed in the `descriptions` output.
+func NewDescribe(client client.Client, namespace string, collector v1alpha1.Describe) operations.Operation {
+	return &describeOperation{
+		client:    client,
+		namespace: namespace,
+		collector: collector,
+	}
+}
+
+func (o *describeOperation) Exec(ctx context.Context, bindings binding.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = binding.NewBindings()
+	}
+	logger := internal.GetLogger(ctx, nil)
+	defer func() {
+		internal.LogEnd(logger, logging.Describe, _err)
+	}()
+	q, err := newQuery(ctx, o.client, bindings, o.collector.ObjectType, o.collector.ActionObjectSelector, o.namespace)
+	if err != nil {
+		return nil, err
+	}
+	internal.LogStart(logger, logging.Describe, logging.Section("RESOURCE", q.String()))
+	items, err := q.list(ctx, o.client)
+	if err != nil {
+		return nil, err
+	}
+	showEvents := o.collector.ShowEvents == nil || *o.collector.ShowEvents
+	// events are listed once per namespace and matched with the objects they involve
+	events := map[string][]unstructured.Unstructured{}
+	descriptions := make([]any, 0, len(items))
+	var texts []string
+	for _, item := range items {
+		description := map[string]any{
+			"resource": item.UnstructuredContent(),
+		}
+		var related []unstructured.Unstructured
+		if showEvents {
+			namespaced, ok := events[item.GetNamespace()]
+			if !ok {
+				eq := query{gvk: corev1.SchemeGroupVersion.WithKind("Event"), namespace: item.GetNamespace()}
+				if namespaced, err = eq.list(ctx, o.client); err != nil {
+					return nil, err
+				}
+				events[item.GetNamespace()] = namespaced
+			}
+			for _, event := range namespaced {
+				uid, _, _ := unstructured.NestedString(event.Object, "involvedObject", "uid")
+				if uid != "" && uid == string(item.GetUID()) {
+					related = append(related, event)
+				}
+			}
+			sortEvents(related)
+			description["events"] = objects(related)
+		}
+		text, err := describe(item, related, showEvents)
+		if err != nil {
+			return nil, err
+		}
+		descriptions = append(descriptions, description)
+		texts = append(texts, text)
+	}
+	if len(items) == 0 {
+		texts = append(texts, "No resources found\n")
+	}
+	record(ctx, logger, logging.Describe, strings.Join(texts, "\n"))
+	return outputs.Outputs{"descriptions": descriptions}, nil
+}
+
+func describe(item unstructured.Unstructured, events []unstructured.Unstructured, showEvents bool) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Name:         %s\n", item.GetName())
+	if item.GetNamespace() != "" {
+		fmt.Fprintf(&b, "Namespace:    %s\n", item.GetNamespace())
+	}
+	fmt.Fprintf(&b, "API Version:  %s\n", item.GetAPIVersion())
+	fmt.Fprintf(&b, "Kind:         %s\n", item.GetKind())
+	content := item.DeepCopy()
+	unstructured.RemoveNestedField(content.Object, "apiVersion")
+	unstructured.RemoveNestedField(content.Object, "kind")
+	unstructured.RemoveNestedField(content.Object, "metadata", "name")
+	unstructured.RemoveNestedField(content.Object, "metadata", "namespace")
+	unstructured.RemoveNestedField(content.Object, "metadata", "managedFields")
+	data, err := yaml.Marshal(content.Object)
+	if err != nil {
+		return "", err
+	}
+	b.Write(data)
+	if showEvents {
+		if len(events) == 0 {
+			b.WriteString("Events:       <none>\n")
+		} else {
+			text, err := renderEvents(events, false)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString("Events:\n")
+			for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+				b.WriteString("  " + line + "\n")
+			}
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,254 @@
+package collect
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/recorder"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func Test_describeOperation(t *testing.T) {
+	pod := object("v1", "Pod", "chainsaw", "foo", nil)
+	pod.SetUID(types.UID("1"))
+	pod.Object["spec"] = map[string]any{"nodeName": "node"}
+	c := fakeClient(
+		pod,
+		event("chainsaw", "a", "1", "Scheduled", time.Now()),
+		event("chainsaw", "b", "2", "Killing", time.Now()),
+	)
+	collector := v1alpha1.Describe{
+		ActionObject: v1alpha1.ActionObject{
+			ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+		},
+	}
+	r := fakeRecorder{}
+	ctx := recorder.IntoContext(logging.IntoContext(context.TODO(), &tlogging.FakeLogger{}), r)
+	got, err := NewDescribe(c, "chainsaw", collector).Exec(ctx, nil)
+	assert.NoError(t, err)
+	descriptions := got["descriptions"].([]any)
+	assert.Len(t, descriptions, 1)
+	description := descriptions[0].(map[string]any)
+	assert.Equal(t, pod.Object, description["resource"])
+	assert.Len(t, description["events"], 1)
+	assert.Contains(t, r[recorder.Stdout], "Name:         foo\nNamespace:    chainsaw\n")
+	assert.Contains(t, r[recorder.Stdout], "nodeName: node")
+	assert.Contains(t, r[recorder.Stdout], "Scheduled")
+	assert.NotContains(t, r[recorder.Stdout], "Killing")
+	// without events
+	collector.ShowEvents = new(bool)
+	r = fakeRecorder{}
+	ctx = recorder.IntoContext(logging.IntoContext(context.TODO(), &tlogging.FakeLogger{}), r)
+	got, err = NewDescribe(c, "chainsaw", collector).Exec(ctx, nil)
+	assert.NoError(t, err)
+	assert.NotContains(t, got["descriptions"].([]any)[0].(map[string]any), "events")
+	assert.NotContains(t, r[recorder.Stdout], "Events:")
+}
+
+func Test_describeOperation_requests(t *testing.T) {
+	pods := v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"}
+	tests := []struct {
+		name         string
+		collector    v1alpha1.Describe
+		wantRequests []string
+		wantErr      bool
+	}{{
+		name:    "empty",
+		wantErr: true,
+	}, {
+		name: "without resource",
+		collector: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "foo"},
+				},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "with resource",
+		collector: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{ObjectType: pods},
+		},
+		wantRequests: []string{"list v1/Pod -n chainsaw", "list v1/Event -n chainsaw"},
+	}, {
+		name: "with clustered resource",
+		collector: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Namespace"},
+			},
+		},
+		wantRequests: []string{"list v1/Namespace"},
+	}, {
+		name: "with name",
+		collector: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "foo"},
+				},
+			},
+		},
+		wantRequests: []string{"get v1/Pod foo -n chainsaw", "list v1/Event -n chainsaw"},
+	}, {
+		name: "with namespace",
+		collector: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Namespace: "bar"},
+				},
+			},
+		},
+		wantRequests: []string{"list v1/Pod -n bar", "list v1/Event -n bar"},
+	}, {
+		name: "with name and namespace",
+		collector: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "foo", Namespace: "bar"},
+				},
+			},
+		},
+		wantRequests: []string{"get v1/Pod foo -n bar", "list v1/Event -n bar"},
+	}, {
+		name: "with selector",
+		collector: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					Selector: "foo=bar",
+				},
+			},
+		},
+		wantRequests: []string{"list v1/Pod -n chainsaw -l foo=bar", "list v1/Event -n chainsaw"},
+	}, {
+		name: "with name and selector",
+		collector: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "foo"},
+					Selector:   "foo=bar",
+				},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "with namespace and selector",
+		collector: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Namespace: "bar"},
+					Selector:   "foo=bar",
+				},
+			},
+		},
+		wantRequests: []string{"list v1/Pod -n bar -l foo=bar", "list v1/Event -n bar"},
+	}, {
+		name: "with show-events marked as false",
+		collector: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "foo"},
+				},
+			},
+			ShowEvents: ptr.To(false),
+		},
+		wantRequests: []string{"get v1/Pod foo -n chainsaw"},
+	}, {
+		name: "with all namespaces",
+		collector: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Namespace: "*"},
+				},
+			},
+			ShowEvents: ptr.To(false),
+		},
+		wantRequests: []string{"list v1/Pod"},
+	}, {
+		name: "missing resource",
+		collector: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "baz"},
+				},
+			},
+		},
+		wantRequests: []string{"get v1/Pod baz -n chainsaw"},
+		wantErr:      true,
+	}, {
+		name: "bad name",
+		collector: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "($bad)"},
+				},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "bad namespace",
+		collector: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Namespace: "($bad)"},
+				},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "bad selector",
+		collector: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					Selector: "($bad)",
+				},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "invalid selector",
+		collector: v1alpha1.Describe{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					Selector: "=",
+				},
+			},
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			c := recordingClient(&requests,
+				object("v1", "Pod", "chainsaw", "foo", map[string]string{"foo": "bar"}),
+				object("v1", "Pod", "bar", "foo", map[string]string{"foo": "bar"}),
+			)
+			ctx := recorder.IntoContext(logging.IntoContext(context.TODO(), &tlogging.FakeLogger{}), fakeRecorder{})
+			_, err := NewDescribe(c, "chainsaw", tt.collector).Exec(ctx, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRequests, requests)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package collect
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/jmespath-community/go-jmespath/pkg/binding"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type eventsOperation struct {
+	client    client.Client
+	namespace string
+	collector v1alpha1.Events
+}
+
+// NewEvents creates an operation collecting events, they are exposed in the `events` output.
+func NewEvents(client client.Client, namespace string, collector v1alpha1.Events) operations.Operation {
+	return &eventsOperation{
+		client:    client,
+		namespace: namespace,
+		collector: collector,
+	}
+}
+
+func (o *eventsOperation) Exec(ctx context.Context, bindings binding.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = binding.NewBindings()
+	}
+	logger := internal.GetLogger(ctx, nil)
+	defer func() {
+		internal.LogEnd(logger, logging.Events, _err)
+	}()
+	q, err := newSelectorQuery(ctx, bindings, corev1.SchemeGroupVersion.WithKind("Event"), false, o.collector.ActionObjectSelector, o.namespace)
+	if err != nil {
+		return nil, err
+	}
+	internal.LogStart(logger, logging.Events, logging.Section("RESOURCE", q.String()))
+	format, err := v1alpha1.Expression(o.collector.Format).Value(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	items, err := q.list(ctx, o.client)
+	if err != nil {
+		return nil, err
+	}
+	sortEvents(items)
+	var text string
+	if format == "" {
+		text, err = renderEvents(items, q.allNamespaces())
+	} else {
+		text, err = renderObjects(format, items, q.allNamespaces())
+	}
+	if err != nil {
+		return nil, err
+	}
+	record(ctx, logger, logging.Events, text)
+	return outputs.Outputs{"events": objects(items)}, nil
+}
+
+// sortEvents sorts events by the time they were last seen, like `kubectl events` does.
+func sortEvents(items []unstructured.Unstructured) {
+	sort.SliceStable(items, func(i, j int) bool {
+		left, _ := toEvent(items[i])
+		right, _ := toEvent(items[j])
+		return lastSeen(left).Before(lastSeen(right))
+	})
+}
+
+func renderEvents(items []unstructured.Unstructured, withNamespace bool) (string, error) {
+	if len(items) == 0 {
+		return "No events found\n", nil
+	}
+	var rows [][]string
+	for _, item := range items {
+		event, err := toEvent(item)
+		if err != nil {
+			return "", err
+		}
+		var row []string
+		if withNamespace {
+			row = append(row, event.Namespace)
+		}
+		object := event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name
+		row = append(row, age(lastSeen(event)), event.Type, event.Reason, object, event.Message)
+		rows = append(rows, row)
+	}
+	header := []string{"LAST SEEN", "TYPE", "REASON", "OBJECT", "MESSAGE"}
+	if withNamespace {
+		header = append([]string{"NAMESPACE"}, header...)
+	}
+	return table(header, rows...), nil
+}
+
+func toEvent(item unstructured.Unstructured) (corev1.Event, error) {
+	var event corev1.Event
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.UnstructuredContent(), &event)
+	return event, err
+}
+
+func lastSeen(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if event.Series != nil && !event.Series.LastObservedTime.IsZero() {
+		return event.Series.LastObservedTime.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
@@ -0,0 +1,82 @@
+package collect
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/recorder"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func event(namespace, name, uid, reason string, lastSeen time.Time) unstructured.Unstructured {
+	obj := object("v1", "Event", namespace, name, nil)
+	obj.Object["involvedObject"] = map[string]any{"kind": "Pod", "name": "foo", "uid": uid}
+	obj.Object["reason"] = reason
+	obj.Object["type"] = "Normal"
+	obj.Object["message"] = reason + " message"
+	obj.Object["lastTimestamp"] = lastSeen.UTC().Format(time.RFC3339)
+	return obj
+}
+
+func Test_eventsOperation(t *testing.T) {
+	now := time.Now()
+	c := fakeClient(
+		event("chainsaw", "b", "1", "Started", now.Add(-time.Minute)),
+		event("chainsaw", "a", "1", "Scheduled", now.Add(-2*time.Minute)),
+		event("other", "c", "2", "Killing", now),
+	)
+	tests := []struct {
+		name      string
+		collector v1alpha1.Events
+		want      []string
+		wantErr   bool
+	}{{
+		name: "test namespace",
+		want: []string{"a", "b"},
+	}, {
+		name: "all namespaces",
+		collector: v1alpha1.Events{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Namespace: "*"},
+			},
+		},
+		want: []string{"a", "b", "c"},
+	}, {
+		name: "name",
+		collector: v1alpha1.Events{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "b"},
+			},
+		},
+		want: []string{"b"},
+	}, {
+		name: "bad format",
+		collector: v1alpha1.Events{
+			ActionFormat: v1alpha1.ActionFormat{Format: "xml"},
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := fakeRecorder{}
+			ctx := recorder.IntoContext(logging.IntoContext(context.TODO(), &tlogging.FakeLogger{}), r)
+			got, err := NewEvents(c, "chainsaw", tt.collector).Exec(ctx, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			var names []string
+			for _, event := range got["events"].([]any) {
+				names = append(names, event.(map[string]any)["metadata"].(map[string]any)["name"].(string))
+			}
+			assert.Equal(t, tt.want, names)
+			assert.Contains(t, r[recorder.Stdout], "REASON")
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package collect
+
+import (
+	"context"
+
+	"github.com/jmespath-community/go-jmespath/pkg/binding"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+)
+
+type getOperation struct {
+	client    client.Client
+	namespace string
+	collector v1alpha1.Get
+}
+
+// NewGet creates an operation getting resources, they are exposed in the `resources` output.
+func NewGet(client client.Client, namespace string, collector v1alpha1.Get) operations.Operation {
+	return &getOperation{
+		client:    client,
+		namespace: namespace,
+		collector: collector,
+	}
+}
+
+func (o *getOperation) Exec(ctx context.Context, bindings binding.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = binding.NewBindings()
+	}
+	logger := internal.GetLogger(ctx, nil)
+	defer func() {
+		internal.LogEnd(logger, logging.Get, _err)
+	}()
+	q, err := newQuery(ctx, o.client, bindings, o.collector.ObjectType, o.collector.ActionObjectSelector, o.namespace)
+	if err != nil {
+		return nil, err
+	}
+	internal.LogStart(logger, logging.Get, logging.Section("RESOURCE", q.String()))
+	format, err := v1alpha1.Expression(o.collector.Format).Value(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	items, err := q.list(ctx, o.client)
+	if err != nil {
+		return nil, err
+	}
+	text, err := renderObjects(format, items, q.allNamespaces())
+	if err != nil {
+		return nil, err
+	}
+	record(ctx, logger, logging.Get, text)
+	return outputs.Outputs{"resources": objects(items)}, nil
+}
@@ -0,0 +1,335 @@
+package collect
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/recorder"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRecorder map[string]string
+
+func (r fakeRecorder) AddOutput(stream string, content string) {
+	r[stream] += content
+}
+
+func Test_getOperation(t *testing.T) {
+	c := fakeClient(
+		object("v1", "Pod", "chainsaw", "foo", map[string]string{"app": "foo"}),
+		object("v1", "Pod", "chainsaw", "bar", nil),
+	)
+	tests := []struct {
+		name      string
+		collector v1alpha1.Get
+		want      outputs.Outputs
+		wantText  string
+		wantErr   bool
+	}{{
+		name:    "empty",
+		wantErr: true,
+	}, {
+		name: "selector",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType:           v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{Selector: "app=foo"},
+			},
+			ActionFormat: v1alpha1.ActionFormat{Format: "json"},
+		},
+		want: outputs.Outputs{
+			"resources": []any{
+				object("v1", "Pod", "chainsaw", "foo", map[string]string{"app": "foo"}).Object,
+			},
+		},
+		wantText: `{
+    "apiVersion": "v1",
+    "kind": "Pod",
+    "metadata": {
+        "labels": {
+            "app": "foo"
+        },
+        "name": "foo",
+        "namespace": "chainsaw"
+    }
+}
+`,
+	}, {
+		name: "table",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "bar"},
+				},
+			},
+		},
+		want: outputs.Outputs{
+			"resources": []any{
+				object("v1", "Pod", "chainsaw", "bar", nil).Object,
+			},
+		},
+		wantText: "NAME   AGE\nbar    <unknown>\n",
+	}, {
+		name: "none",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Service"},
+			},
+			ActionFormat: v1alpha1.ActionFormat{Format: "yaml"},
+		},
+		want: outputs.Outputs{
+			"resources": []any{},
+		},
+		wantText: "apiVersion: v1\nitems: []\nkind: List\n",
+	}, {
+		name: "bad format",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+			},
+			ActionFormat: v1alpha1.ActionFormat{Format: "xml"},
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := fakeRecorder{}
+			ctx := recorder.IntoContext(logging.IntoContext(context.TODO(), &tlogging.FakeLogger{}), r)
+			got, err := NewGet(c, "chainsaw", tt.collector).Exec(ctx, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				assert.Equal(t, tt.wantText, r[recorder.Stdout])
+			}
+		})
+	}
+}
+
+func Test_getOperation_requests(t *testing.T) {
+	pods := v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"}
+	tests := []struct {
+		name         string
+		collector    v1alpha1.Get
+		wantRequests []string
+		wantErr      bool
+	}{{
+		name: "without resource",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "foo"},
+				},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "with resource",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{ObjectType: pods},
+		},
+		wantRequests: []string{"list v1/Pod -n chainsaw"},
+	}, {
+		name: "with clustered resource",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Namespace"},
+			},
+		},
+		wantRequests: []string{"list v1/Namespace"},
+	}, {
+		name: "with name",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "foo"},
+				},
+			},
+		},
+		wantRequests: []string{"get v1/Pod foo -n chainsaw"},
+	}, {
+		name: "with namespace",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Namespace: "bar"},
+				},
+			},
+		},
+		wantRequests: []string{"list v1/Pod -n bar"},
+	}, {
+		name: "with name and namespace",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "foo", Namespace: "bar"},
+				},
+			},
+		},
+		wantRequests: []string{"get v1/Pod foo -n bar"},
+	}, {
+		name: "with selector",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					Selector: "foo=bar",
+				},
+			},
+		},
+		wantRequests: []string{"list v1/Pod -n chainsaw -l foo=bar"},
+	}, {
+		name: "with name and selector",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "foo"},
+					Selector:   "foo=bar",
+				},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "with namespace and selector",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Namespace: "bar"},
+					Selector:   "foo=bar",
+				},
+			},
+		},
+		wantRequests: []string{"list v1/Pod -n bar -l foo=bar"},
+	}, {
+		name: "with all namespaces",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "foo", Namespace: "*"},
+				},
+			},
+		},
+		wantRequests: []string{"list v1/Pod"},
+	}, {
+		name: "with all namespaces and selector",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Namespace: "*"},
+					Selector:   "foo=bar",
+				},
+			},
+		},
+		wantRequests: []string{"list v1/Pod -l foo=bar"},
+	}, {
+		name: "with format",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "foo"},
+				},
+			},
+			ActionFormat: v1alpha1.ActionFormat{Format: "yaml"},
+		},
+		wantRequests: []string{"get v1/Pod foo -n chainsaw"},
+	}, {
+		name: "missing resource",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "baz"},
+				},
+			},
+		},
+		wantRequests: []string{"get v1/Pod baz -n chainsaw"},
+		wantErr:      true,
+	}, {
+		name: "bad name",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "($bad)"},
+				},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "bad namespace",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Namespace: "($bad)"},
+				},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "bad selector",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					Selector: "($bad)",
+				},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "invalid selector",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					Selector: "=",
+				},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "bad format",
+		collector: v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: pods,
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{Name: "foo"},
+				},
+			},
+			ActionFormat: v1alpha1.ActionFormat{Format: "($bad)"},
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			c := recordingClient(&requests,
+				object("v1", "Pod", "chainsaw", "foo", map[string]string{"foo": "bar"}),
+				object("v1", "Pod", "bar", "foo", map[string]string{"foo": "bar"}),
+			)
+			ctx := recorder.IntoContext(logging.IntoContext(context.TODO(), &tlogging.FakeLogger{}), fakeRecorder{})
+			_, err := NewGet(c, "chainsaw", tt.collector).Exec(ctx, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRequests, requests)
+		})
+	}
+}
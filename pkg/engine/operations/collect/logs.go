@@ -0,0 +1,134 @@
+package collect
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmespath-community/go-jmespath/pkg/binding"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+)
+
+// defaultSelectorTail is the number of lines collected per container when pods are selected by labels,
+// it matches the default of `kubectl logs`.
+const defaultSelectorTail = 10
+
+type logsOperation struct {
+	clientset kubernetes.Interface
+	namespace string
+	collector v1alpha1.PodLogs
+}
+
+// NewLogs creates an operation collecting pod logs, they are exposed in the `logs` output.
+func NewLogs(clientset kubernetes.Interface, namespace string, collector v1alpha1.PodLogs) operations.Operation {
+	return &logsOperation{
+		clientset: clientset,
+		namespace: namespace,
+		collector: collector,
+	}
+}
+
+func (o *logsOperation) Exec(ctx context.Context, bindings binding.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = binding.NewBindings()
+	}
+	logger := internal.GetLogger(ctx, nil)
+	defer func() {
+		internal.LogEnd(logger, logging.Logs, _err)
+	}()
+	q, err := newSelectorQuery(ctx, bindings, corev1.SchemeGroupVersion.WithKind("Pod"), false, o.collector.ActionObjectSelector, o.namespace)
+	if err != nil {
+		return nil, err
+	}
+	container, err := o.collector.Container.Value(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	internal.LogStart(logger, logging.Logs, logging.Section("RESOURCE", q.String()))
+	pods, err := o.pods(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	var tail *int64
+	if o.collector.Tail != nil {
+		if *o.collector.Tail >= 0 {
+			tail = ptr.To(int64(*o.collector.Tail))
+		}
+	} else if q.name == "" {
+		tail = ptr.To(int64(defaultSelectorTail))
+	}
+	logs := []any{}
+	var text strings.Builder
+	for _, pod := range pods {
+		for _, name := range containers(pod, container) {
+			data, err := o.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container: name,
+				TailLines: tail,
+			}).DoRaw(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get logs of container %s in pod %s/%s: %w", name, pod.Namespace, pod.Name, err)
+			}
+			logs = append(logs, map[string]any{
+				"namespace": pod.Namespace,
+				"pod":       pod.Name,
+				"container": name,
+				"content":   string(data),
+			})
+			prefix := fmt.Sprintf("[pod/%s/%s] ", pod.Name, name)
+			for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+				if line != "" {
+					text.WriteString(prefix + line + "\n")
+				}
+			}
+		}
+	}
+	record(ctx, logger, logging.Logs, text.String())
+	return outputs.Outputs{"logs": logs}, nil
+}
+
+func (o *logsOperation) pods(ctx context.Context, q query) ([]corev1.Pod, error) {
+	if q.name != "" && !q.allNamespaces() {
+		pod, err := o.clientset.CoreV1().Pods(q.namespace).Get(ctx, q.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return []corev1.Pod{*pod}, nil
+	}
+	list, err := o.clientset.CoreV1().Pods(q.namespace).List(ctx, metav1.ListOptions{LabelSelector: q.selector})
+	if err != nil {
+		return nil, err
+	}
+	if q.name == "" {
+		return list.Items, nil
+	}
+	var out []corev1.Pod
+	for _, pod := range list.Items {
+		if pod.Name == q.name {
+			out = append(out, pod)
+		}
+	}
+	return out, nil
+}
+
+// containers returns the names of the pod containers to collect logs from, all of them when container is empty.
+func containers(pod corev1.Pod, container string) []string {
+	if container != "" {
+		return []string{container}
+	}
+	var out []string
+	for _, c := range pod.Spec.InitContainers {
+		out = append(out, c.Name)
+	}
+	for _, c := range pod.Spec.Containers {
+		out = append(out, c.Name)
+	}
+	return out
+}
@@ -0,0 +1,328 @@
+package collect
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/recorder"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func pod(namespace, name string, labels map[string]string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+	}
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: container})
+	}
+	return pod
+}
+
+func Test_logsOperation(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		pod("chainsaw", "foo", map[string]string{"app": "foo"}, "main", "sidecar"),
+		pod("chainsaw", "bar", nil, "main"),
+		pod("other", "foo", map[string]string{"app": "foo"}, "main"),
+	)
+	tests := []struct {
+		name      string
+		collector v1alpha1.PodLogs
+		want      []any
+		wantText  string
+		wantErr   bool
+	}{{
+		name: "name and selector",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "foo"},
+				Selector:   "app=foo",
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "not found",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "baz"},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "name",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "bar"},
+			},
+		},
+		want: []any{
+			map[string]any{"namespace": "chainsaw", "pod": "bar", "container": "main", "content": "fake logs"},
+		},
+		wantText: "[pod/bar/main] fake logs\n",
+	}, {
+		name: "selector",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				Selector: "app=foo",
+			},
+		},
+		want: []any{
+			map[string]any{"namespace": "chainsaw", "pod": "foo", "container": "main", "content": "fake logs"},
+			map[string]any{"namespace": "chainsaw", "pod": "foo", "container": "sidecar", "content": "fake logs"},
+		},
+		wantText: "[pod/foo/main] fake logs\n[pod/foo/sidecar] fake logs\n",
+	}, {
+		name: "container in all namespaces",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Namespace: "*", Name: "foo"},
+			},
+			Container: "main",
+		},
+		want: []any{
+			map[string]any{"namespace": "chainsaw", "pod": "foo", "container": "main", "content": "fake logs"},
+			map[string]any{"namespace": "other", "pod": "foo", "container": "main", "content": "fake logs"},
+		},
+		wantText: "[pod/foo/main] fake logs\n[pod/foo/main] fake logs\n",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := fakeRecorder{}
+			ctx := recorder.IntoContext(logging.IntoContext(context.TODO(), &tlogging.FakeLogger{}), r)
+			got, err := NewLogs(clientset, "chainsaw", tt.collector).Exec(ctx, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got["logs"])
+			assert.Equal(t, tt.wantText, r[recorder.Stdout])
+		})
+	}
+}
+
+// requests returns the actions received by a fake clientset in a kubectl like format.
+func requests(actions []k8stesting.Action) []string {
+	var out []string
+	for _, action := range actions {
+		request := action.GetVerb() + " " + action.GetResource().Resource
+		switch action := action.(type) {
+		case k8stesting.GetAction:
+			request += " " + action.GetName()
+		case k8stesting.GenericAction:
+			request += "/" + action.GetSubresource()
+			if opts, ok := action.GetValue().(*corev1.PodLogOptions); ok {
+				if opts.Container != "" {
+					request += " -c " + opts.Container
+				}
+				if opts.TailLines != nil {
+					request += fmt.Sprintf(" --tail %d", *opts.TailLines)
+				}
+			}
+		}
+		if action.GetNamespace() != "" {
+			request += " -n " + action.GetNamespace()
+		}
+		if action, ok := action.(k8stesting.ListAction); ok {
+			if selector := action.GetListRestrictions().Labels.String(); selector != "" {
+				request += " -l " + selector
+			}
+		}
+		out = append(out, request)
+	}
+	return out
+}
+
+func Test_logsOperation_requests(t *testing.T) {
+	tests := []struct {
+		name         string
+		collector    v1alpha1.PodLogs
+		wantRequests []string
+		wantErr      bool
+	}{{
+		name: "empty",
+		wantRequests: []string{
+			"list pods -n chainsaw",
+			"get pods/log -c main --tail 10 -n chainsaw",
+			"get pods/log -c main --tail 10 -n chainsaw",
+		},
+	}, {
+		name: "with name",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			},
+		},
+		wantRequests: []string{
+			"get pods foo -n chainsaw",
+			"get pods/log -c main -n chainsaw",
+		},
+	}, {
+		name: "with name and namespace",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "foo", Namespace: "bar"},
+			},
+		},
+		wantRequests: []string{
+			"get pods foo -n bar",
+			"get pods/log -c main -n bar",
+		},
+	}, {
+		name: "with container",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			},
+			Container: "sidecar",
+		},
+		wantRequests: []string{
+			"get pods foo -n chainsaw",
+			"get pods/log -c sidecar -n chainsaw",
+		},
+	}, {
+		name: "with tail",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			},
+			Tail: ptr.To(100),
+		},
+		wantRequests: []string{
+			"get pods foo -n chainsaw",
+			"get pods/log -c main --tail 100 -n chainsaw",
+		},
+	}, {
+		name: "with selector",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				Selector: "foo=bar",
+			},
+		},
+		wantRequests: []string{
+			"list pods -n chainsaw -l foo=bar",
+			"get pods/log -c main --tail 10 -n chainsaw",
+		},
+	}, {
+		name: "with selector and tail",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				Selector: "foo=bar",
+			},
+			Tail: ptr.To(-1),
+		},
+		wantRequests: []string{
+			"list pods -n chainsaw -l foo=bar",
+			"get pods/log -c main -n chainsaw",
+		},
+	}, {
+		name: "with namespace and selector",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Namespace: "bar"},
+				Selector:   "foo=bar",
+			},
+		},
+		wantRequests: []string{
+			"list pods -n bar -l foo=bar",
+			"get pods/log -c main --tail 10 -n bar",
+		},
+	}, {
+		name: "with all namespaces and selector",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Namespace: "*"},
+				Selector:   "foo=bar",
+			},
+		},
+		wantRequests: []string{
+			"list pods -l foo=bar",
+			"get pods/log -c main --tail 10 -n bar",
+			"get pods/log -c main --tail 10 -n chainsaw",
+		},
+	}, {
+		name: "with name and selector",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "foo"},
+				Selector:   "foo=bar",
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "missing pod",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "baz"},
+			},
+		},
+		wantRequests: []string{"get pods baz -n chainsaw"},
+		wantErr:      true,
+	}, {
+		name: "bad name",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "($bad)"},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "bad namespace",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Namespace: "($bad)"},
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "bad selector",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				Selector: "($bad)",
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "invalid selector",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				Selector: "=",
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "bad container",
+		collector: v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			},
+			Container: "($bad)",
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(
+				pod("chainsaw", "foo", map[string]string{"foo": "bar"}, "main"),
+				pod("chainsaw", "bar", nil, "main"),
+				pod("bar", "foo", map[string]string{"foo": "bar"}, "main"),
+			)
+			ctx := recorder.IntoContext(logging.IntoContext(context.TODO(), &tlogging.FakeLogger{}), fakeRecorder{})
+			_, err := NewLogs(clientset, "chainsaw", tt.collector).Exec(ctx, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRequests, requests(clientset.Actions()))
+		})
+	}
+}
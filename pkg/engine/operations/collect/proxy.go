@@ -0,0 +1,90 @@
+package collect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path"
+
+	"github.com/jmespath-community/go-jmespath/pkg/binding"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	apibindings "github.com/kyverno/chainsaw/pkg/engine/bindings"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"k8s.io/client-go/rest"
+)
+
+type proxyOperation struct {
+	client     client.Client
+	restClient rest.Interface
+	namespace  string
+	collector  v1alpha1.Proxy
+}
+
+// NewProxy creates an operation sending a GET request to a pod or service through the API server proxy,
+// the response body is exposed in the `response` output (decoded when it contains JSON).
+func NewProxy(client client.Client, restClient rest.Interface, namespace string, collector v1alpha1.Proxy) operations.Operation {
+	return &proxyOperation{
+		client:     client,
+		restClient: restClient,
+		namespace:  namespace,
+		collector:  collector,
+	}
+}
+
+func (o *proxyOperation) Exec(ctx context.Context, bindings binding.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = binding.NewBindings()
+	}
+	logger := internal.GetLogger(ctx, nil)
+	defer func() {
+		internal.LogEnd(logger, logging.Proxy, _err)
+	}()
+	q, err := newQuery(ctx, o.client, bindings, o.collector.ObjectType, v1alpha1.ActionObjectSelector{ObjectName: o.collector.ObjectName}, o.namespace)
+	if err != nil {
+		return nil, err
+	}
+	if q.name == "" {
+		return nil, errors.New("a name must be specified")
+	}
+	targetPath, err := o.collector.TargetPath.Value(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	targetPort, err := o.collector.TargetPort.Value(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	name := q.name
+	if targetPort != "" {
+		name += ":" + targetPort
+	}
+	url := path.Join("/api", "v1", "namespaces", q.namespace, q.resource, name, "proxy", targetPath)
+	internal.LogStart(logger, logging.Proxy, logging.Section("URL", url))
+	data, err := o.restClient.Get().AbsPath(url).DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	record(ctx, logger, logging.Proxy, string(data))
+	var response any = string(data)
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err == nil {
+		response = decoded
+	}
+	bindings = apibindings.RegisterBinding(ctx, bindings, "stdout", string(data))
+	bindings = apibindings.RegisterBinding(ctx, bindings, "response", response)
+	results, err := outputs.Process(ctx, bindings, nil, o.collector.Outputs...)
+	if err != nil {
+		return nil, err
+	}
+	if results == nil {
+		results = outputs.Outputs{}
+	}
+	if _, ok := results["response"]; !ok {
+		results["response"] = response
+	}
+	return results, nil
+}
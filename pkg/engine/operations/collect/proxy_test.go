@@ -0,0 +1,162 @@
+package collect
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/recorder"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest/fake"
+)
+
+func Test_proxyOperation(t *testing.T) {
+	tests := []struct {
+		name      string
+		collector v1alpha1.Proxy
+		body      string
+		wantPath  string
+		want      outputs.Outputs
+		wantErr   bool
+	}{{
+		name: "without name",
+		collector: v1alpha1.Proxy{
+			ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Service"},
+		},
+		wantErr: true,
+	}, {
+		name: "without resource",
+		collector: v1alpha1.Proxy{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+		},
+		wantErr: true,
+	}, {
+		name: "with name",
+		collector: v1alpha1.Proxy{
+			ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Service"},
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+		},
+		body:     "ok",
+		wantPath: "/api/v1/namespaces/chainsaw/services/foo/proxy",
+		want: outputs.Outputs{
+			"response": "ok",
+		},
+	}, {
+		name: "with name and namespace",
+		collector: v1alpha1.Proxy{
+			ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Service"},
+			ObjectName: v1alpha1.ObjectName{Name: "foo", Namespace: "bar"},
+		},
+		body:     "ok",
+		wantPath: "/api/v1/namespaces/bar/services/foo/proxy",
+		want: outputs.Outputs{
+			"response": "ok",
+		},
+	}, {
+		name: "with target port",
+		collector: v1alpha1.Proxy{
+			ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			TargetPort: "8080",
+		},
+		body:     "ok",
+		wantPath: "/api/v1/namespaces/chainsaw/pods/foo:8080/proxy",
+		want: outputs.Outputs{
+			"response": "ok",
+		},
+	}, {
+		name: "bad name",
+		collector: v1alpha1.Proxy{
+			ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Service"},
+			ObjectName: v1alpha1.ObjectName{Name: "($bad)"},
+		},
+		wantErr: true,
+	}, {
+		name: "bad namespace",
+		collector: v1alpha1.Proxy{
+			ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Service"},
+			ObjectName: v1alpha1.ObjectName{Name: "foo", Namespace: "($bad)"},
+		},
+		wantErr: true,
+	}, {
+		name: "bad target path",
+		collector: v1alpha1.Proxy{
+			ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Service"},
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			TargetPath: "($bad)",
+		},
+		wantErr: true,
+	}, {
+		name: "bad target port",
+		collector: v1alpha1.Proxy{
+			ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Service"},
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			TargetPort: "($bad)",
+		},
+		wantErr: true,
+	}, {
+		name: "json",
+		collector: v1alpha1.Proxy{
+			ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Service"},
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			TargetPort: "http",
+			TargetPath: "/status",
+		},
+		body:     `{"ready":true}`,
+		wantPath: "/api/v1/namespaces/chainsaw/services/foo:http/proxy/status",
+		want: outputs.Outputs{
+			"response": map[string]any{"ready": true},
+		},
+	}, {
+		name: "outputs",
+		collector: v1alpha1.Proxy{
+			ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+			ObjectName: v1alpha1.ObjectName{Namespace: "foo", Name: "bar"},
+			TargetPath: "/metrics",
+			ActionOutputs: v1alpha1.ActionOutputs{
+				Outputs: []v1alpha1.Output{{
+					Binding: v1alpha1.Binding{Name: "metrics", Value: v1alpha1.Any{Value: "($stdout)"}},
+				}},
+			},
+		},
+		body:     "up 1",
+		wantPath: "/api/v1/namespaces/foo/pods/bar/proxy/metrics",
+		want: outputs.Outputs{
+			"metrics":  "up 1",
+			"response": "up 1",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			restClient := &fake.RESTClient{
+				NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+				Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+					path = req.URL.Path
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(tt.body)),
+					}, nil
+				}),
+			}
+			r := fakeRecorder{}
+			ctx := recorder.IntoContext(logging.IntoContext(context.TODO(), &tlogging.FakeLogger{}), r)
+			got, err := NewProxy(fakeClient(), restClient, "chainsaw", tt.collector).Exec(ctx, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPath, path)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.body, r[recorder.Stdout])
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package collect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmespath-community/go-jmespath/pkg/binding"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// allNamespaces is the namespace value selecting objects in all namespaces.
+const allNamespaces = "*"
+
+// query selects the objects a collector operates on.
+type query struct {
+	gvk       schema.GroupVersionKind
+	resource  string
+	clustered bool
+	// namespace is empty when all namespaces are selected
+	namespace string
+	name      string
+	selector  string
+}
+
+func newQuery(ctx context.Context, c client.Client, bindings binding.Bindings, objectType v1alpha1.ObjectType, object v1alpha1.ActionObjectSelector, namespace string) (query, error) {
+	apiVersion, err := objectType.APIVersion.Value(ctx, bindings)
+	if err != nil {
+		return query{}, err
+	}
+	kind, err := objectType.Kind.Value(ctx, bindings)
+	if err != nil {
+		return query{}, err
+	}
+	if apiVersion == "" || kind == "" {
+		return query{}, errors.New("failed to map resource, apiVersion and kind must be specified")
+	}
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return query{}, err
+	}
+	gvk := gv.WithKind(kind)
+	mapping, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return query{}, err
+	}
+	q, err := newSelectorQuery(ctx, bindings, gvk, mapping.Scope.Name() == meta.RESTScopeNameRoot, object, namespace)
+	if err != nil {
+		return query{}, err
+	}
+	q.resource = mapping.Resource.Resource
+	return q, nil
+}
+
+func newSelectorQuery(ctx context.Context, bindings binding.Bindings, gvk schema.GroupVersionKind, clustered bool, object v1alpha1.ActionObjectSelector, namespace string) (query, error) {
+	name, err := object.Name.Value(ctx, bindings)
+	if err != nil {
+		return query{}, err
+	}
+	ns, err := object.Namespace.Value(ctx, bindings)
+	if err != nil {
+		return query{}, err
+	}
+	selector, err := object.Selector.Value(ctx, bindings)
+	if err != nil {
+		return query{}, err
+	}
+	if name != "" && selector != "" {
+		return query{}, errors.New("name cannot be provided when a selector is specified")
+	}
+	// the selector is validated early, the logs collector passes it to the clientset as is
+	if _, err := labels.Parse(selector); err != nil {
+		return query{}, err
+	}
+	q := query{
+		gvk:       gvk,
+		clustered: clustered,
+		name:      name,
+		selector:  selector,
+	}
+	if !clustered {
+		q.namespace = defaultNamespace(ns, namespace)
+	}
+	return q, nil
+}
+
+// defaultNamespace returns the namespace to use, falling back to the test namespace.
+// It returns an empty string when all namespaces are selected.
+func defaultNamespace(namespace, fallback string) string {
+	switch namespace {
+	case allNamespaces:
+		return ""
+	case "":
+		if fallback == "" {
+			return metav1.NamespaceDefault
+		}
+		return fallback
+	default:
+		return namespace
+	}
+}
+
+func (q query) allNamespaces() bool {
+	return !q.clustered && q.namespace == ""
+}
+
+func (q query) String() string {
+	out := q.gvk.GroupVersion().String() + "/" + q.gvk.Kind
+	if q.namespace != "" {
+		out += " @ " + q.namespace
+	}
+	if q.name != "" {
+		out += fmt.Sprintf(" (name: %s)", q.name)
+	} else if q.selector != "" {
+		out += fmt.Sprintf(" (selector: %s)", q.selector)
+	}
+	return out
+}
+
+func (q query) list(ctx context.Context, c client.Client) ([]unstructured.Unstructured, error) {
+	if q.name != "" && !q.allNamespaces() {
+		var obj unstructured.Unstructured
+		obj.SetGroupVersionKind(q.gvk)
+		if err := c.Get(ctx, client.ObjectKey{Namespace: q.namespace, Name: q.name}, &obj); err != nil {
+			return nil, err
+		}
+		return []unstructured.Unstructured{obj}, nil
+	}
+	var opts []client.ListOption
+	if q.namespace != "" {
+		opts = append(opts, client.InNamespace(q.namespace))
+	}
+	if q.selector != "" {
+		selector, err := labels.Parse(q.selector)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, client.MatchingSelector{Selector: selector})
+	}
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(q.gvk.GroupVersion().WithKind(q.gvk.Kind + "List"))
+	if err := c.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+	if q.name == "" {
+		return list.Items, nil
+	}
+	var out []unstructured.Unstructured
+	for _, item := range list.Items {
+		if item.GetName() == q.name {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,259 @@
+package collect
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func object(apiVersion, kind, namespace, name string, labels map[string]string) unstructured.Unstructured {
+	var obj unstructured.Unstructured
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+// fakeClient returns a client serving the given objects, it honors namespaces, names and label selectors.
+func fakeClient(objects ...unstructured.Unstructured) *tclient.FakeClient {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Service"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Event"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, meta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	return &tclient.FakeClient{
+		RESTMapperFn: func(int) meta.RESTMapper {
+			return mapper
+		},
+		GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			gvk := obj.GetObjectKind().GroupVersionKind()
+			for _, item := range objects {
+				if item.GroupVersionKind() == gvk && item.GetNamespace() == key.Namespace && item.GetName() == key.Name {
+					obj.(*unstructured.Unstructured).Object = item.DeepCopy().Object
+					return nil
+				}
+			}
+			return kerrors.NewNotFound(schema.GroupResource{Resource: strings.ToLower(gvk.Kind)}, key.Name)
+		},
+		ListFn: func(_ context.Context, _ int, list client.ObjectList, opts ...client.ListOption) error {
+			var options ctrlclient.ListOptions
+			options.ApplyOptions(opts)
+			gvk := list.GetObjectKind().GroupVersionKind()
+			gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+			l := list.(*unstructured.UnstructuredList)
+			for _, item := range objects {
+				if item.GroupVersionKind() != gvk {
+					continue
+				}
+				if options.Namespace != "" && item.GetNamespace() != options.Namespace {
+					continue
+				}
+				if options.LabelSelector != nil && !options.LabelSelector.Matches(labels.Set(item.GetLabels())) {
+					continue
+				}
+				l.Items = append(l.Items, *item.DeepCopy())
+			}
+			return nil
+		},
+	}
+}
+
+// recordingClient returns a fake client serving the given objects, the requests it receives
+// are recorded in a kubectl like format.
+func recordingClient(requests *[]string, objects ...unstructured.Unstructured) *tclient.FakeClient {
+	c := fakeClient(objects...)
+	get, list := c.GetFn, c.ListFn
+	c.GetFn = func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+		request := fmt.Sprintf("get %s %s", typeOf(obj.GetObjectKind().GroupVersionKind()), key.Name)
+		if key.Namespace != "" {
+			request += " -n " + key.Namespace
+		}
+		*requests = append(*requests, request)
+		return get(ctx, call, key, obj, opts...)
+	}
+	c.ListFn = func(ctx context.Context, call int, l client.ObjectList, opts ...client.ListOption) error {
+		var options ctrlclient.ListOptions
+		options.ApplyOptions(opts)
+		gvk := l.GetObjectKind().GroupVersionKind()
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+		request := "list " + typeOf(gvk)
+		if options.Namespace != "" {
+			request += " -n " + options.Namespace
+		}
+		if options.LabelSelector != nil {
+			request += " -l " + options.LabelSelector.String()
+		}
+		*requests = append(*requests, request)
+		return list(ctx, call, l, opts...)
+	}
+	return c
+}
+
+func typeOf(gvk schema.GroupVersionKind) string {
+	return gvk.GroupVersion().String() + "/" + gvk.Kind
+}
+
+func Test_newQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		objectType v1alpha1.ObjectType
+		object     v1alpha1.ActionObjectSelector
+		namespace  string
+		want       query
+		wantErr    bool
+	}{{
+		name:    "empty",
+		wantErr: true,
+	}, {
+		name:       "unknown kind",
+		objectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Foo"},
+		wantErr:    true,
+	}, {
+		name:       "name and selector",
+		objectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+		object: v1alpha1.ActionObjectSelector{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			Selector:   "app=foo",
+		},
+		wantErr: true,
+	}, {
+		name:       "invalid selector",
+		objectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+		object: v1alpha1.ActionObjectSelector{
+			Selector: "=",
+		},
+		wantErr: true,
+	}, {
+		name:       "test namespace",
+		objectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+		namespace:  "chainsaw",
+		want: query{
+			gvk:       schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			resource:  "pods",
+			namespace: "chainsaw",
+		},
+	}, {
+		name:       "default namespace",
+		objectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+		want: query{
+			gvk:       schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			resource:  "pods",
+			namespace: "default",
+		},
+	}, {
+		name:       "all namespaces",
+		objectType: v1alpha1.ObjectType{APIVersion: "apps/v1", Kind: "Deployment"},
+		object: v1alpha1.ActionObjectSelector{
+			ObjectName: v1alpha1.ObjectName{Namespace: "*"},
+			Selector:   "app=foo",
+		},
+		namespace: "chainsaw",
+		want: query{
+			gvk:      schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			resource: "deployments",
+			selector: "app=foo",
+		},
+	}, {
+		name:       "clustered",
+		objectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Namespace"},
+		object: v1alpha1.ActionObjectSelector{
+			ObjectName: v1alpha1.ObjectName{Namespace: "foo", Name: "bar"},
+		},
+		namespace: "chainsaw",
+		want: query{
+			gvk:       schema.GroupVersionKind{Version: "v1", Kind: "Namespace"},
+			resource:  "namespaces",
+			clustered: true,
+			name:      "bar",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newQuery(context.TODO(), fakeClient(), nil, tt.objectType, tt.object, tt.namespace)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_query_list(t *testing.T) {
+	c := fakeClient(
+		object("v1", "Pod", "foo", "a", map[string]string{"app": "a"}),
+		object("v1", "Pod", "foo", "b", map[string]string{"app": "b"}),
+		object("v1", "Pod", "bar", "a", map[string]string{"app": "a"}),
+		object("v1", "Namespace", "", "foo", nil),
+	)
+	pod := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	tests := []struct {
+		name    string
+		query   query
+		want    []string
+		wantErr bool
+	}{{
+		name:  "namespace",
+		query: query{gvk: pod, namespace: "foo"},
+		want:  []string{"foo/a", "foo/b"},
+	}, {
+		name:  "all namespaces",
+		query: query{gvk: pod},
+		want:  []string{"foo/a", "foo/b", "bar/a"},
+	}, {
+		name:  "selector",
+		query: query{gvk: pod, selector: "app=a"},
+		want:  []string{"foo/a", "bar/a"},
+	}, {
+		name:  "name",
+		query: query{gvk: pod, namespace: "bar", name: "a"},
+		want:  []string{"bar/a"},
+	}, {
+		name:  "name in all namespaces",
+		query: query{gvk: pod, name: "a"},
+		want:  []string{"foo/a", "bar/a"},
+	}, {
+		name:    "not found",
+		query:   query{gvk: pod, namespace: "bar", name: "b"},
+		wantErr: true,
+	}, {
+		name:    "invalid selector",
+		query:   query{gvk: pod, selector: "="},
+		wantErr: true,
+	}, {
+		name:  "clustered",
+		query: query{gvk: schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, clustered: true, name: "foo"},
+		want:  []string{"/foo"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := tt.query.list(context.TODO(), c)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			var got []string
+			for _, item := range items {
+				got = append(got, item.GetNamespace()+"/"+item.GetName())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package collect
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/recorder"
+	"github.com/kyverno/pkg/ext/output/color"
+)
+
+// record logs the text output of a collector and sends it to the recorder registered in ctx, if any.
+func record(ctx context.Context, logger logging.Logger, op logging.Operation, content string) {
+	if strings.TrimSpace(content) == "" {
+		return
+	}
+	if logger != nil {
+		logger.Log(op, logging.LogStatus, color.BoldFgCyan, logging.Section("OUTPUT", strings.TrimSpace(content)))
+	}
+	if r := recorder.FromContext(ctx); r != nil {
+		r.AddOutput(recorder.Stdout, content)
+	}
+}
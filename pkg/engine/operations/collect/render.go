@@ -0,0 +1,96 @@
+package collect
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+// objects returns the content of the given objects, suitable for bindings.
+func objects(items []unstructured.Unstructured) []any {
+	out := make([]any, 0, len(items))
+	for _, item := range items {
+		out = append(out, item.UnstructuredContent())
+	}
+	return out
+}
+
+// renderObjects prints objects in the given format, a table is printed when no format is set.
+func renderObjects(format string, items []unstructured.Unstructured, withNamespace bool) (string, error) {
+	switch format {
+	case "":
+		if len(items) == 0 {
+			return "No resources found\n", nil
+		}
+		var rows [][]string
+		for _, item := range items {
+			var row []string
+			if withNamespace {
+				row = append(row, item.GetNamespace())
+			}
+			row = append(row, item.GetName(), age(item.GetCreationTimestamp().Time))
+			rows = append(rows, row)
+		}
+		header := []string{"NAME", "AGE"}
+		if withNamespace {
+			header = append([]string{"NAMESPACE"}, header...)
+		}
+		return table(header, rows...), nil
+	case formatJSON, formatYAML:
+		var content any
+		if len(items) == 1 {
+			content = items[0].UnstructuredContent()
+		} else {
+			content = map[string]any{
+				"apiVersion": "v1",
+				"kind":       "List",
+				"items":      objects(items),
+			}
+		}
+		return marshal(format, content)
+	default:
+		return "", fmt.Errorf("unsupported format %q (expected %s or %s)", format, formatJSON, formatYAML)
+	}
+}
+
+func marshal(format string, content any) (string, error) {
+	if format == formatYAML {
+		data, err := yaml.Marshal(content)
+		return string(data), err
+	}
+	data, err := json.MarshalIndent(content, "", "    ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+func table(header []string, rows ...[]string) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	_ = w.Flush()
+	return buf.String()
+}
+
+func age(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(time.Since(t))
+}
@@ -0,0 +1,190 @@
+package collect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmespath-community/go-jmespath/pkg/binding"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// predicate returns true when an object satisfies the wait condition.
+type predicate = func(unstructured.Unstructured) (bool, error)
+
+type waitOperation struct {
+	client    client.Client
+	namespace string
+	collector v1alpha1.Wait
+}
+
+// NewWait creates an operation waiting for resources to be deleted or to satisfy a condition,
+// the resources in their final state are exposed in the `resources` output.
+func NewWait(client client.Client, namespace string, collector v1alpha1.Wait) operations.Operation {
+	return &waitOperation{
+		client:    client,
+		namespace: namespace,
+		collector: collector,
+	}
+}
+
+func (o *waitOperation) Exec(ctx context.Context, bindings binding.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = binding.NewBindings()
+	}
+	logger := internal.GetLogger(ctx, nil)
+	defer func() {
+		internal.LogEnd(logger, logging.Wait, _err)
+	}()
+	q, err := newQuery(ctx, o.client, bindings, o.collector.ObjectType, o.collector.ActionObjectSelector, o.namespace)
+	if err != nil {
+		return nil, err
+	}
+	format, err := v1alpha1.Expression(o.collector.Format).Value(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	description, condition, err := o.condition(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	internal.LogStart(logger, logging.Wait, logging.Section("RESOURCE", q.String()), logging.Section("FOR", description))
+	if o.collector.Timeout != nil && o.collector.Timeout.Duration >= 0 {
+		timeoutCtx, cancel := context.WithTimeout(ctx, o.collector.Timeout.Duration)
+		defer cancel()
+		ctx = timeoutCtx
+	}
+	var items []unstructured.Unstructured
+	err = wait.PollUntilContextCancel(ctx, client.PollInterval, true, func(ctx context.Context) (bool, error) {
+		list, err := q.list(ctx, o.client)
+		if kerrors.IsNotFound(err) {
+			list, err = nil, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		items = list
+		// deletion completes when no object is left
+		if condition == nil {
+			return len(items) == 0, nil
+		}
+		if len(items) == 0 {
+			return false, nil
+		}
+		for _, item := range items {
+			if ok, err := condition(item); err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || wait.Interrupted(err) {
+			return nil, fmt.Errorf("timed out waiting for %s (%s): %w", q, description, err)
+		}
+		return nil, err
+	}
+	var text string
+	if format != "" {
+		if text, err = renderObjects(format, items, q.allNamespaces()); err != nil {
+			return nil, err
+		}
+	} else if condition == nil {
+		text = fmt.Sprintf("%s deleted\n", q)
+	} else {
+		var b strings.Builder
+		for _, item := range items {
+			fmt.Fprintf(&b, "%s/%s condition met\n", strings.ToLower(item.GetKind()), item.GetName())
+		}
+		text = b.String()
+	}
+	record(ctx, logger, logging.Wait, text)
+	return outputs.Outputs{"resources": objects(items)}, nil
+}
+
+// condition returns the description and predicate of the wait condition, the predicate is nil when waiting for deletion.
+func (o *waitOperation) condition(ctx context.Context, bindings binding.Bindings) (string, predicate, error) {
+	waitFor := o.collector.WaitFor
+	if waitFor.Deletion != nil {
+		return "deletion", nil, nil
+	}
+	if waitFor.Condition != nil {
+		name, err := waitFor.Condition.Name.Value(ctx, bindings)
+		if err != nil {
+			return "", nil, err
+		}
+		if name == "" {
+			return "", nil, errors.New("a condition name must be specified for condition wait type")
+		}
+		value := "True"
+		if waitFor.Condition.Value != nil {
+			if value, err = waitFor.Condition.Value.Value(ctx, bindings); err != nil {
+				return "", nil, err
+			}
+		}
+		return fmt.Sprintf("condition %s=%s", name, value), func(obj unstructured.Unstructured) (bool, error) {
+			conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+			if err != nil {
+				return false, err
+			}
+			for _, condition := range conditions {
+				if condition, ok := condition.(map[string]any); ok {
+					if strings.EqualFold(fmt.Sprint(condition["type"]), name) {
+						return strings.EqualFold(fmt.Sprint(condition["status"]), value), nil
+					}
+				}
+			}
+			return false, nil
+		}, nil
+	}
+	if waitFor.JsonPath != nil {
+		path, err := waitFor.JsonPath.Path.Value(ctx, bindings)
+		if err != nil {
+			return "", nil, err
+		}
+		if path == "" {
+			return "", nil, errors.New("a path must be specified for jsonpath wait type")
+		}
+		value, err := waitFor.JsonPath.Value.Value(ctx, bindings)
+		if err != nil {
+			return "", nil, err
+		}
+		if value == "" {
+			return "", nil, errors.New("a value must be specified for jsonpath wait type")
+		}
+		if !strings.HasPrefix(path, "{") {
+			path = "{" + path + "}"
+		}
+		parser := jsonpath.New("wait").AllowMissingKeys(true)
+		if err := parser.Parse(path); err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("jsonpath %s=%s", path, value), func(obj unstructured.Unstructured) (bool, error) {
+			results, err := parser.FindResults(obj.Object)
+			if err != nil {
+				return false, err
+			}
+			found := false
+			for _, result := range results {
+				for _, r := range result {
+					found = true
+					if fmt.Sprint(r.Interface()) != value {
+						return false, nil
+					}
+				}
+			}
+			return found, nil
+		}, nil
+	}
+	return "", nil, errors.New("either a deletion or a condition must be specified")
+}
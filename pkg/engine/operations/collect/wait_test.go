@@ -0,0 +1,231 @@
+package collect
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/recorder"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_waitOperation(t *testing.T) {
+	ready := object("v1", "Pod", "chainsaw", "ready", map[string]string{"app": "foo"})
+	ready.Object["status"] = map[string]any{
+		"phase": "Running",
+		"conditions": []any{
+			map[string]any{"type": "Ready", "status": "True"},
+		},
+	}
+	pending := object("v1", "Pod", "chainsaw", "pending", map[string]string{"app": "bar"})
+	pending.Object["status"] = map[string]any{
+		"phase": "Pending",
+		"conditions": []any{
+			map[string]any{"type": "Ready", "status": "False"},
+		},
+	}
+	c := fakeClient(ready, pending)
+	value := v1alpha1.Expression("false")
+	wait := func(selector v1alpha1.ActionObjectSelector, waitFor v1alpha1.WaitFor) v1alpha1.Wait {
+		return v1alpha1.Wait{
+			ActionTimeout: v1alpha1.ActionTimeout{Timeout: &metav1.Duration{Duration: 200 * time.Millisecond}},
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType:           v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+				ActionObjectSelector: selector,
+			},
+			WaitFor: waitFor,
+		}
+	}
+	tests := []struct {
+		name      string
+		collector v1alpha1.Wait
+		want      []any
+		wantText  string
+		wantErr   bool
+	}{{
+		name:      "no condition",
+		collector: wait(v1alpha1.ActionObjectSelector{}, v1alpha1.WaitFor{}),
+		wantErr:   true,
+	}, {
+		name:      "condition without name",
+		collector: wait(v1alpha1.ActionObjectSelector{}, v1alpha1.WaitFor{Condition: &v1alpha1.WaitForCondition{}}),
+		wantErr:   true,
+	}, {
+		name: "condition",
+		collector: wait(
+			v1alpha1.ActionObjectSelector{Selector: "app=foo"},
+			v1alpha1.WaitFor{Condition: &v1alpha1.WaitForCondition{Name: "ready"}},
+		),
+		want:     []any{ready.Object},
+		wantText: "pod/ready condition met\n",
+	}, {
+		name: "condition value",
+		collector: wait(
+			v1alpha1.ActionObjectSelector{ObjectName: v1alpha1.ObjectName{Name: "pending"}},
+			v1alpha1.WaitFor{Condition: &v1alpha1.WaitForCondition{Name: "Ready", Value: &value}},
+		),
+		want:     []any{pending.Object},
+		wantText: "pod/pending condition met\n",
+	}, {
+		name: "condition timeout",
+		collector: wait(
+			v1alpha1.ActionObjectSelector{},
+			v1alpha1.WaitFor{Condition: &v1alpha1.WaitForCondition{Name: "Ready"}},
+		),
+		wantErr: true,
+	}, {
+		name: "jsonpath",
+		collector: wait(
+			v1alpha1.ActionObjectSelector{Selector: "app=foo"},
+			v1alpha1.WaitFor{JsonPath: &v1alpha1.WaitForJsonPath{Path: ".status.phase", Value: "Running"}},
+		),
+		want:     []any{ready.Object},
+		wantText: "pod/ready condition met\n",
+	}, {
+		name: "jsonpath timeout",
+		collector: wait(
+			v1alpha1.ActionObjectSelector{},
+			v1alpha1.WaitFor{JsonPath: &v1alpha1.WaitForJsonPath{Path: "{.status.phase}", Value: "Running"}},
+		),
+		wantErr: true,
+	}, {
+		name: "jsonpath without value",
+		collector: wait(
+			v1alpha1.ActionObjectSelector{},
+			v1alpha1.WaitFor{JsonPath: &v1alpha1.WaitForJsonPath{Path: "{.status.phase}"}},
+		),
+		wantErr: true,
+	}, {
+		name: "deletion",
+		collector: wait(
+			v1alpha1.ActionObjectSelector{ObjectName: v1alpha1.ObjectName{Name: "deleted"}},
+			v1alpha1.WaitFor{Deletion: &v1alpha1.WaitForDeletion{}},
+		),
+		want:     []any{},
+		wantText: "v1/Pod @ chainsaw (name: deleted) deleted\n",
+	}, {
+		name: "deletion timeout",
+		collector: wait(
+			v1alpha1.ActionObjectSelector{Selector: "app=foo"},
+			v1alpha1.WaitFor{Deletion: &v1alpha1.WaitForDeletion{}},
+		),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := fakeRecorder{}
+			ctx := recorder.IntoContext(logging.IntoContext(context.TODO(), &tlogging.FakeLogger{}), r)
+			got, err := NewWait(c, "chainsaw", tt.collector).Exec(ctx, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got["resources"])
+			assert.Equal(t, tt.wantText, r[recorder.Stdout])
+		})
+	}
+}
+
+func Test_waitOperation_requests(t *testing.T) {
+	wait := func(objectType v1alpha1.ObjectType, selector v1alpha1.ActionObjectSelector, waitFor v1alpha1.WaitFor) v1alpha1.Wait {
+		return v1alpha1.Wait{
+			ActionTimeout: v1alpha1.ActionTimeout{Timeout: &metav1.Duration{Duration: 200 * time.Millisecond}},
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType:           objectType,
+				ActionObjectSelector: selector,
+			},
+			WaitFor: waitFor,
+		}
+	}
+	pods := v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"}
+	deletion := v1alpha1.WaitFor{Deletion: &v1alpha1.WaitForDeletion{}}
+	tests := []struct {
+		name         string
+		collector    v1alpha1.Wait
+		wantRequests []string
+		wantErr      bool
+	}{{
+		name:      "without resource",
+		collector: wait(v1alpha1.ObjectType{}, v1alpha1.ActionObjectSelector{ObjectName: v1alpha1.ObjectName{Name: "foo"}}, deletion),
+		wantErr:   true,
+	}, {
+		name:         "with resource",
+		collector:    wait(pods, v1alpha1.ActionObjectSelector{}, deletion),
+		wantRequests: []string{"list v1/Pod -n chainsaw"},
+	}, {
+		name:         "with clustered resource",
+		collector:    wait(v1alpha1.ObjectType{APIVersion: "v1", Kind: "Namespace"}, v1alpha1.ActionObjectSelector{}, deletion),
+		wantRequests: []string{"list v1/Namespace"},
+	}, {
+		name:         "with name",
+		collector:    wait(pods, v1alpha1.ActionObjectSelector{ObjectName: v1alpha1.ObjectName{Name: "foo"}}, deletion),
+		wantRequests: []string{"get v1/Pod foo -n chainsaw"},
+	}, {
+		name:         "with name and namespace",
+		collector:    wait(pods, v1alpha1.ActionObjectSelector{ObjectName: v1alpha1.ObjectName{Name: "foo", Namespace: "bar"}}, deletion),
+		wantRequests: []string{"get v1/Pod foo -n bar"},
+	}, {
+		name:         "with selector",
+		collector:    wait(pods, v1alpha1.ActionObjectSelector{Selector: "foo=bar"}, deletion),
+		wantRequests: []string{"list v1/Pod -n chainsaw -l foo=bar"},
+	}, {
+		name:      "with name and selector",
+		collector: wait(pods, v1alpha1.ActionObjectSelector{ObjectName: v1alpha1.ObjectName{Name: "foo"}, Selector: "foo=bar"}, deletion),
+		wantErr:   true,
+	}, {
+		name:         "with namespace and selector",
+		collector:    wait(pods, v1alpha1.ActionObjectSelector{ObjectName: v1alpha1.ObjectName{Namespace: "bar"}, Selector: "foo=bar"}, deletion),
+		wantRequests: []string{"list v1/Pod -n bar -l foo=bar"},
+	}, {
+		name:         "with all namespaces",
+		collector:    wait(pods, v1alpha1.ActionObjectSelector{ObjectName: v1alpha1.ObjectName{Namespace: "*"}}, deletion),
+		wantRequests: []string{"list v1/Pod"},
+	}, {
+		name:      "without condition",
+		collector: wait(pods, v1alpha1.ActionObjectSelector{ObjectName: v1alpha1.ObjectName{Name: "foo"}}, v1alpha1.WaitFor{}),
+		wantErr:   true,
+	}, {
+		name:      "bad name",
+		collector: wait(pods, v1alpha1.ActionObjectSelector{ObjectName: v1alpha1.ObjectName{Name: "($bad)"}}, deletion),
+		wantErr:   true,
+	}, {
+		name:      "bad namespace",
+		collector: wait(pods, v1alpha1.ActionObjectSelector{ObjectName: v1alpha1.ObjectName{Namespace: "($bad)"}}, deletion),
+		wantErr:   true,
+	}, {
+		name:      "bad selector",
+		collector: wait(pods, v1alpha1.ActionObjectSelector{Selector: "($bad)"}, deletion),
+		wantErr:   true,
+	}, {
+		name:      "invalid selector",
+		collector: wait(pods, v1alpha1.ActionObjectSelector{Selector: "="}, deletion),
+		wantErr:   true,
+	}, {
+		name: "bad format",
+		collector: func() v1alpha1.Wait {
+			collector := wait(pods, v1alpha1.ActionObjectSelector{ObjectName: v1alpha1.ObjectName{Name: "foo"}}, deletion)
+			collector.Format = "($bad)"
+			return collector
+		}(),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			c := recordingClient(&requests)
+			ctx := recorder.IntoContext(logging.IntoContext(context.TODO(), &tlogging.FakeLogger{}), fakeRecorder{})
+			_, err := NewWait(c, "chainsaw", tt.collector).Exec(ctx, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRequests, requests)
+		})
+	}
+}
@@ -102,6 +102,7 @@ func (o *operation) execute(ctx context.Context, bindings binding.Bindings, cmd
 			if sections := output.Sections(); len(sections) != 0 {
 				logger.Log(logging.Command, logging.LogStatus, color.BoldFgCyan, sections...)
 			}
+			output.Record(ctx)
 		}()
 	}
 	cmd.Stdout = &output.Stdout
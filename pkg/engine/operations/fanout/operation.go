@@ -0,0 +1,125 @@
+package fanout
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmespath-community/go-jmespath/pkg/binding"
+	"github.com/kyverno/chainsaw/pkg/client"
+	apibindings "github.com/kyverno/chainsaw/pkg/engine/bindings"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"go.uber.org/multierr"
+	"k8s.io/client-go/rest"
+)
+
+// Target is an operation bound to a single cluster.
+type Target struct {
+	Cluster   string
+	Config    *rest.Config
+	Client    client.Client
+	Operation operations.Operation
+}
+
+// Reporter is notified when the operation completed on a cluster.
+type Reporter = func(cluster string, startTime time.Time, endTime time.Time, err error)
+
+type operation struct {
+	targets     []Target
+	concurrency int
+	reporter    Reporter
+}
+
+func New(targets []Target, concurrency int, reporter Reporter) operations.Operation {
+	return &operation{
+		targets:     targets,
+		concurrency: concurrency,
+		reporter:    reporter,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings binding.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = binding.NewBindings()
+	}
+	logger := internal.GetLogger(ctx, nil)
+	defer func() {
+		internal.LogEnd(logger, logging.FanOut, _err)
+	}()
+	internal.LogStart(logger, logging.FanOut, logging.Section("CLUSTERS", strings.Join(o.clusters(), "\n")))
+	return o.execute(ctx, bindings)
+}
+
+func (o *operation) execute(ctx context.Context, bindings binding.Bindings) (outputs.Outputs, error) {
+	results := make([]outputs.Outputs, len(o.targets))
+	errs := make([]error, len(o.targets))
+	concurrency := o.concurrency
+	if concurrency <= 0 || concurrency > len(o.targets) {
+		concurrency = len(o.targets)
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range o.targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			target := o.targets[i]
+			startTime := time.Now()
+			results[i], errs[i] = o.executeTarget(ctx, bindings, target)
+			if o.reporter != nil {
+				o.reporter(target.Cluster, startTime, time.Now(), errs[i])
+			}
+		}(i)
+	}
+	wg.Wait()
+	var out outputs.Outputs
+	for i, result := range results {
+		for name, value := range result {
+			if out == nil {
+				out = outputs.Outputs{}
+			}
+			perCluster, ok := out[name].(map[string]any)
+			if !ok {
+				perCluster = map[string]any{}
+				out[name] = perCluster
+			}
+			perCluster[o.targets[i].Cluster] = value
+		}
+	}
+	var err error
+	for i := range errs {
+		if errs[i] != nil {
+			err = multierr.Append(err, fmt.Errorf("cluster %s: %w", o.targets[i].Cluster, errs[i]))
+		}
+	}
+	return out, err
+}
+
+func (o *operation) executeTarget(ctx context.Context, bindings binding.Bindings, target Target) (_ outputs.Outputs, _err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			_err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	bindings = apibindings.RegisterBinding(ctx, bindings, "cluster", target.Cluster)
+	bindings = apibindings.RegisterBinding(ctx, bindings, "client", target.Client)
+	bindings = apibindings.RegisterBinding(ctx, bindings, "config", target.Config)
+	return target.Operation.Exec(ctx, bindings)
+}
+
+func (o *operation) clusters() []string {
+	clusters := make([]string, 0, len(o.targets))
+	for _, target := range o.targets {
+		clusters = append(clusters, target.Cluster)
+	}
+	return clusters
+}
@@ -0,0 +1,132 @@
+package fanout
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jmespath-community/go-jmespath/pkg/binding"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	mock "github.com/kyverno/chainsaw/pkg/engine/operations/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	ttesting "github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_operation_Exec(t *testing.T) {
+	ok := func(value string) mock.MockOperation {
+		return mock.MockOperation{
+			ExecFn: func(ctx context.Context, bindings binding.Bindings) (outputs.Outputs, error) {
+				cluster, err := bindings.Get("$cluster")
+				if err != nil {
+					return nil, err
+				}
+				name, err := cluster.Value()
+				if err != nil {
+					return nil, err
+				}
+				return outputs.Outputs{"value": value + "-" + name.(string)}, nil
+			},
+		}
+	}
+	ko := mock.MockOperation{
+		ExecFn: func(ctx context.Context, bindings binding.Bindings) (outputs.Outputs, error) {
+			return nil, errors.New("dummy")
+		},
+	}
+	panics := mock.MockOperation{
+		ExecFn: func(ctx context.Context, bindings binding.Bindings) (outputs.Outputs, error) {
+			panic("boom")
+		},
+	}
+	tests := []struct {
+		name     string
+		targets  []Target
+		want     outputs.Outputs
+		wantErr  string
+		reported map[string]bool
+	}{{
+		name: "success",
+		targets: []Target{
+			{Cluster: "a", Operation: ok("foo")},
+			{Cluster: "b", Operation: ok("foo")},
+		},
+		want: outputs.Outputs{
+			"value": map[string]any{
+				"a": "foo-a",
+				"b": "foo-b",
+			},
+		},
+		reported: map[string]bool{"a": true, "b": true},
+	}, {
+		name: "partial failure",
+		targets: []Target{
+			{Cluster: "a", Operation: ok("foo")},
+			{Cluster: "b", Operation: ko},
+		},
+		want: outputs.Outputs{
+			"value": map[string]any{
+				"a": "foo-a",
+			},
+		},
+		wantErr:  "cluster b: dummy",
+		reported: map[string]bool{"a": true, "b": false},
+	}, {
+		name: "panic",
+		targets: []Target{
+			{Cluster: "a", Operation: panics},
+		},
+		wantErr:  "cluster a: panic: boom",
+		reported: map[string]bool{"a": false},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lock sync.Mutex
+			reported := map[string]bool{}
+			reporter := func(cluster string, _ time.Time, _ time.Time, err error) {
+				lock.Lock()
+				defer lock.Unlock()
+				reported[cluster] = err == nil
+			}
+			logger := &tlogging.FakeLogger{}
+			ctx := ttesting.IntoContext(logging.IntoContext(context.TODO(), logger), t)
+			got, err := New(tt.targets, 0, reporter).Exec(ctx, nil)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.reported, reported)
+		})
+	}
+}
+
+func Test_operation_Concurrency(t *testing.T) {
+	var running, max int32
+	op := mock.MockOperation{
+		ExecFn: func(ctx context.Context, bindings binding.Bindings) (outputs.Outputs, error) {
+			current := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				old := atomic.LoadInt32(&max)
+				if current <= old || atomic.CompareAndSwapInt32(&max, old, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return nil, nil
+		},
+	}
+	var targets []Target
+	for _, cluster := range []string{"a", "b", "c", "d", "e", "f"} {
+		targets = append(targets, Target{Cluster: cluster, Operation: op})
+	}
+	_, err := New(targets, 2, nil).Exec(context.TODO(), nil)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, max, int32(2))
+}
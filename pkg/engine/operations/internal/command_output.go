@@ -2,10 +2,12 @@ package internal
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/recorder"
 )
 
 type CommandOutput struct {
@@ -33,3 +35,17 @@ func (c *CommandOutput) Sections() []fmt.Stringer {
 	}
 	return sections
 }
+
+// Record sends the command output to the recorder registered in ctx, if any.
+func (c *CommandOutput) Record(ctx context.Context) {
+	r := recorder.FromContext(ctx)
+	if r == nil {
+		return
+	}
+	if o := c.Out(); strings.TrimSpace(o) != "" {
+		r.AddOutput(recorder.Stdout, o)
+	}
+	if e := c.Err(); strings.TrimSpace(e) != "" {
+		r.AddOutput(recorder.Stderr, e)
+	}
+}
@@ -2,10 +2,12 @@ package internal
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/recorder"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -103,3 +105,22 @@ func TestCommandOutput_Sections(t *testing.T) {
 		})
 	}
 }
+
+type fakeRecorder struct {
+	outputs map[string]string
+}
+
+func (r *fakeRecorder) AddOutput(stream string, content string) {
+	r.outputs[stream] += content
+}
+
+func TestCommandOutput_Record(t *testing.T) {
+	var co CommandOutput
+	co.Stdout.WriteString("hello\n")
+	co.Stderr.WriteString("  \n")
+	// no recorder in context
+	co.Record(context.TODO())
+	r := &fakeRecorder{outputs: map[string]string{}}
+	co.Record(recorder.IntoContext(context.TODO(), r))
+	assert.Equal(t, map[string]string{"stdout": "hello\n"}, r.outputs)
+}
@@ -0,0 +1,31 @@
+package recorder
+
+import (
+	"context"
+)
+
+const (
+	Stdout = "stdout"
+	Stderr = "stderr"
+)
+
+// Recorder collects the output of the commands and scripts run by operations,
+// the operation report implements it.
+type Recorder interface {
+	AddOutput(stream string, content string)
+}
+
+type recorderKey struct{}
+
+func FromContext(ctx context.Context) Recorder {
+	if ctx != nil {
+		if v, ok := ctx.Value(recorderKey{}).(Recorder); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func IntoContext(ctx context.Context, recorder Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, recorder)
+}
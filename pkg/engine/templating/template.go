@@ -13,7 +13,7 @@ import (
 )
 
 func Template(ctx context.Context, tpl v1alpha1.Any, value any, bindings binding.Bindings) (any, error) {
-	return mutate.Mutate(ctx, nil, mutate.Parse(ctx, tpl.Value), value, bindings, template.WithFunctionCaller(functions.Caller()))
+	return mutate.Mutate(ctx, nil, mutate.Parse(ctx, tpl.Value), value, bindings, template.WithFunctionCaller(functions.CallerWithContext(ctx)))
 }
 
 func TemplateAndMerge(ctx context.Context, obj unstructured.Unstructured, bindings binding.Bindings, templates ...v1alpha1.Any) (unstructured.Unstructured, error) {
@@ -17,7 +17,7 @@ func String(ctx context.Context, in string, bindings binding.Bindings) (string,
 	if expression == nil || expression.Engine == "" {
 		return in, nil
 	}
-	if converted, err := template.Execute(ctx, expression.Statement, nil, bindings, template.WithFunctionCaller(functions.Caller())); err != nil {
+	if converted, err := template.Execute(ctx, expression.Statement, nil, bindings, template.WithFunctionCaller(functions.CallerWithContext(ctx))); err != nil {
 		return "", err
 	} else {
 		if converted, ok := converted.(string); !ok {
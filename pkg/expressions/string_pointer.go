@@ -20,7 +20,7 @@ func StringPointer(ctx context.Context, in *string, bindings binding.Bindings) (
 	if expression == nil || expression.Engine == "" {
 		return in, nil
 	}
-	if converted, err := template.Execute(ctx, expression.Statement, nil, bindings, template.WithFunctionCaller(functions.Caller())); err != nil {
+	if converted, err := template.Execute(ctx, expression.Statement, nil, bindings, template.WithFunctionCaller(functions.CallerWithContext(ctx))); err != nil {
 		return nil, err
 	} else if converted == nil {
 		return nil, nil
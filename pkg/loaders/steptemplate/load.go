@@ -1,12 +1,14 @@
 package steptemplate
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/loaders"
+	"github.com/kyverno/chainsaw/pkg/loaders/steptemplate/remote"
 	yamlutils "github.com/kyverno/chainsaw/pkg/utils/yaml"
 	"github.com/kyverno/pkg/ext/resource/convert"
 	"github.com/kyverno/pkg/ext/resource/loader"
@@ -37,6 +39,19 @@ func Load(path string, remarshal bool) ([]*v1alpha1.StepTemplate, error) {
 	return tests, nil
 }
 
+// LoadReference loads the step templates a test step refers to, ref is either a path
+// relative to base or a remote reference resolved through the default remote cache.
+func LoadReference(ctx context.Context, base string, ref string, remarshal bool) ([]*v1alpha1.StepTemplate, error) {
+	if !remote.IsRemote(ref) {
+		return Load(filepath.Join(base, ref), remarshal)
+	}
+	path, err := remote.Default().Resolve(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return Load(path, remarshal)
+}
+
 func Parse(content []byte, remarshal bool) ([]*v1alpha1.StepTemplate, error) {
 	return parse(content, remarshal, nil, nil, nil)
 }
@@ -0,0 +1,242 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const dockerHubKey = "https://index.docker.io/v1/"
+
+// credentials are the credentials used to authenticate against a registry.
+type credentials struct {
+	Username      string
+	Password      string
+	IdentityToken string
+}
+
+type credentialsFunc = func(registry string) (credentials, error)
+
+type dockerAuth struct {
+	Auth          string `json:"auth"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	IdentityToken string `json:"identitytoken"`
+}
+
+type dockerConfig struct {
+	Auths       map[string]dockerAuth `json:"auths"`
+	CredsStore  string                `json:"credsStore"`
+	CredHelpers map[string]string     `json:"credHelpers"`
+}
+
+func dockerConfigPath() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".docker", "config.json")
+}
+
+// registryHost normalizes a registry or a docker config key to a host name.
+func registryHost(registry string) string {
+	if i := strings.Index(registry, "://"); i >= 0 {
+		registry = registry[i+3:]
+	}
+	registry, _, _ = strings.Cut(registry, "/")
+	switch registry {
+	case "docker.io", "registry-1.docker.io":
+		return "index.docker.io"
+	}
+	return registry
+}
+
+// dockerCredentials returns the credentials of a registry from the docker configuration,
+// credential helpers are honored. No credentials are returned if the configuration doesn't exist.
+func dockerCredentials(registry string) (credentials, error) {
+	path := dockerConfigPath()
+	if path == "" {
+		return credentials{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return credentials{}, nil
+		}
+		return credentials{}, err
+	}
+	var config dockerConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return credentials{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	host := registryHost(registry)
+	for key, helper := range config.CredHelpers {
+		if registryHost(key) == host {
+			return helperCredentials(helper, host)
+		}
+	}
+	for key, auth := range config.Auths {
+		if registryHost(key) != host {
+			continue
+		}
+		creds := credentials{
+			Username:      auth.Username,
+			Password:      auth.Password,
+			IdentityToken: auth.IdentityToken,
+		}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return credentials{}, fmt.Errorf("invalid auth for registry %s: %w", key, err)
+			}
+			creds.Username, creds.Password, _ = strings.Cut(string(decoded), ":")
+		}
+		if creds != (credentials{}) {
+			return creds, nil
+		}
+	}
+	if config.CredsStore != "" {
+		return helperCredentials(config.CredsStore, host)
+	}
+	return credentials{}, nil
+}
+
+// helperCredentials gets credentials from a docker credential helper.
+func helperCredentials(helper string, host string) (credentials, error) {
+	server := host
+	if host == "index.docker.io" {
+		server = dockerHubKey
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(server)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if strings.Contains(stdout.String()+stderr.String(), "credentials not found") {
+			return credentials{}, nil
+		}
+		return credentials{}, fmt.Errorf("credential helper %s failed: %w", helper, err)
+	}
+	var out struct {
+		Username string
+		Secret   string
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return credentials{}, fmt.Errorf("credential helper %s returned invalid credentials: %w", helper, err)
+	}
+	if out.Username == "<token>" {
+		return credentials{IdentityToken: out.Secret}, nil
+	}
+	return credentials{Username: out.Username, Password: out.Secret}, nil
+}
+
+// challengeParams parses the comma separated parameters of an authentication challenge,
+// quoted values can contain commas (scope="repository:foo:pull,push").
+func challengeParams(params string) map[string]string {
+	values := map[string]string{}
+	for params != "" {
+		key, rest, ok := strings.Cut(params, "=")
+		if !ok {
+			break
+		}
+		key = strings.TrimSpace(strings.TrimLeft(key, ", "))
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		values[key] = value
+		params = rest
+	}
+	return values
+}
+
+// authenticate answers an authentication challenge and returns the authorization header
+// to use for requests in scope.
+func (c *registryClient) authenticate(ctx context.Context, registry string, scope string, challenge string) (string, error) {
+	creds, err := c.credentials(registry)
+	if err != nil {
+		return "", err
+	}
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if strings.EqualFold(scheme, "Basic") {
+		if creds.Username == "" {
+			return "", fmt.Errorf("registry %s requires credentials", registry)
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(creds.Username+":"+creds.Password)), nil
+	}
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported authentication challenge: %q", challenge)
+	}
+	values := challengeParams(params)
+	if values["realm"] == "" {
+		return "", fmt.Errorf("invalid authentication challenge: %q", challenge)
+	}
+	if values["scope"] != "" {
+		scope = values["scope"]
+	}
+	var req *http.Request
+	if creds.IdentityToken != "" {
+		form := url.Values{
+			"grant_type":    []string{"refresh_token"},
+			"refresh_token": []string{creds.IdentityToken},
+			"service":       []string{values["service"]},
+			"scope":         []string{scope},
+			"client_id":     []string{"chainsaw"},
+		}
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, values["realm"], strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		query := url.Values{}
+		if service := values["service"]; service != "" {
+			query.Set("service", service)
+		}
+		query.Set("scope", scope)
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, values["realm"]+"?"+query.Encode(), nil)
+		if err != nil {
+			return "", err
+		}
+		if creds.Username != "" {
+			req.SetBasicAuth(creds.Username, creds.Password)
+		}
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get registry token: %s", resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return "", errors.New("registry returned an empty token")
+	}
+	return "Bearer " + token.Token, nil
+}
@@ -0,0 +1,143 @@
+package remote
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDockerCredentials(t *testing.T) {
+	auth := base64.StdEncoding.EncodeToString([]byte("user:pass"))
+	tests := []struct {
+		name     string
+		config   string
+		registry string
+		want     credentials
+		wantErr  bool
+	}{{
+		name:     "no config",
+		registry: "ghcr.io",
+	}, {
+		name:     "auth",
+		config:   `{"auths":{"ghcr.io":{"auth":"` + auth + `"}}}`,
+		registry: "ghcr.io",
+		want:     credentials{Username: "user", Password: "pass"},
+	}, {
+		name:     "username and password",
+		config:   `{"auths":{"https://registry.example.com":{"username":"user","password":"pass"}}}`,
+		registry: "registry.example.com",
+		want:     credentials{Username: "user", Password: "pass"},
+	}, {
+		name:     "identity token",
+		config:   `{"auths":{"registry.example.com":{"identitytoken":"token"}}}`,
+		registry: "registry.example.com",
+		want:     credentials{IdentityToken: "token"},
+	}, {
+		name:     "docker hub",
+		config:   `{"auths":{"https://index.docker.io/v1/":{"auth":"` + auth + `"}}}`,
+		registry: "docker.io",
+		want:     credentials{Username: "user", Password: "pass"},
+	}, {
+		name:     "other registry",
+		config:   `{"auths":{"ghcr.io":{"auth":"` + auth + `"}}}`,
+		registry: "registry.example.com",
+	}, {
+		name:     "invalid config",
+		config:   `{`,
+		registry: "ghcr.io",
+		wantErr:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("DOCKER_CONFIG", dir)
+			if tt.config != "" {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(tt.config), 0o600))
+			}
+			got, err := dockerCredentials(tt.registry)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+// tokenRegistry wraps a registry and requires bearer tokens issued for the scope of each request.
+type tokenRegistry struct {
+	lock     sync.Mutex
+	registry http.Handler
+	scopes   []string
+}
+
+func (r *tokenRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		if user, pass, ok := req.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		scope := req.URL.Query().Get("scope")
+		r.lock.Lock()
+		r.scopes = append(r.scopes, scope)
+		r.lock.Unlock()
+		_, _ = fmt.Fprintf(w, `{"token":%q}`, scope)
+		return
+	}
+	repository := strings.Join(strings.Split(strings.TrimPrefix(req.URL.Path, "/v2/"), "/")[:2], "/")
+	scope := "repository:" + repository + ":pull"
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		scope += ",push"
+	}
+	if req.Header.Get("Authorization") != "Bearer "+scope {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="http://%s/token",service="registry",scope="%s"`, req.Host, scope))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	r.registry.ServeHTTP(w, req)
+}
+
+func TestCache_Authentication(t *testing.T) {
+	registry := newRegistry()
+	defer registry.Close()
+	tokens := &tokenRegistry{registry: registry.Config.Handler}
+	server := httptest.NewServer(tokens)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+	ref := "oci://" + host + "/templates/create:v1"
+	var registries []string
+	lookup := func(registry string) (credentials, error) {
+		registries = append(registries, registry)
+		return credentials{Username: "user", Password: "pass"}, nil
+	}
+	publisher := NewCache(Options{Dir: t.TempDir(), Insecure: true})
+	publisher.credentials = lookup
+	_, err := publisher.Push(context.TODO(), ref, []byte(template))
+	assert.NoError(t, err)
+	cache := NewCache(Options{Dir: t.TempDir(), Insecure: true})
+	cache.credentials = lookup
+	_, err = cache.Pull(context.TODO(), ref)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"repository:templates/create:pull",
+		"repository:templates/create:pull,push",
+		"repository:templates/create:pull",
+	}, tokens.scopes)
+	assert.Equal(t, []string{host, host, host}, registries)
+	cache = NewCache(Options{Dir: t.TempDir(), Insecure: true})
+	cache.credentials = func(string) (credentials, error) {
+		return credentials{}, nil
+	}
+	_, err = cache.Pull(context.TODO(), ref)
+	assert.Error(t, err)
+}
@@ -0,0 +1,298 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+)
+
+// Options configures a step template cache.
+type Options struct {
+	// Dir is the cache directory, defaults to DefaultDir().
+	Dir string
+	// Offline only resolves references from the cache.
+	Offline bool
+	// Insecure uses plain http to talk to registries.
+	Insecure bool
+}
+
+// Entry is a reference pinned in the cache.
+type Entry struct {
+	Reference string    `json:"reference"`
+	Digest    string    `json:"digest"`
+	Pulled    time.Time `json:"pulled"`
+}
+
+type fetcher = func(context.Context, *Reference) ([]byte, error)
+
+// Cache stores remote step templates on disk.
+// References are pinned to the digest of the content fetched the first time they were resolved,
+// pulling a reference explicitly refreshes the pinned digest.
+type Cache struct {
+	options     Options
+	fetch       fetcher
+	client      *http.Client
+	credentials credentialsFunc
+	lock        sync.Mutex
+}
+
+// DefaultDir returns the default cache directory.
+func DefaultDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "chainsaw", "templates")
+}
+
+// NewCache creates a step template cache.
+func NewCache(options Options) *Cache {
+	if options.Dir == "" {
+		options.Dir = DefaultDir()
+	}
+	c := &Cache{
+		options:     options,
+		client:      http.DefaultClient,
+		credentials: dockerCredentials,
+	}
+	c.fetch = c.fetchRemote
+	return c
+}
+
+var (
+	defaultCache *Cache
+	defaultLock  sync.Mutex
+)
+
+// Default returns the cache used to resolve remote step templates.
+func Default() *Cache {
+	defaultLock.Lock()
+	defer defaultLock.Unlock()
+	if defaultCache == nil {
+		defaultCache = NewCache(Options{})
+	}
+	return defaultCache
+}
+
+// SetDefault sets the cache used to resolve remote step templates.
+func SetDefault(cache *Cache) {
+	defaultLock.Lock()
+	defer defaultLock.Unlock()
+	defaultCache = cache
+}
+
+func (c *Cache) indexPath() string {
+	return filepath.Join(c.options.Dir, "index.json")
+}
+
+func (c *Cache) blobPath(digest string) string {
+	return filepath.Join(c.options.Dir, "blobs", strings.Replace(digest, ":", string(filepath.Separator), 1))
+}
+
+func (c *Cache) readIndex() (map[string]Entry, error) {
+	index := map[string]Entry{}
+	data, err := os.ReadFile(c.indexPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return index, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("corrupted step template cache index: %w", err)
+	}
+	return index, nil
+}
+
+func (c *Cache) writeIndex(index map[string]Entry) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(c.indexPath(), data)
+}
+
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// lookup returns the path of the blob pinned for ref, the blob is verified against the pinned digest.
+func (c *Cache) lookup(ref string) (string, bool, error) {
+	index, err := c.readIndex()
+	if err != nil {
+		return "", false, err
+	}
+	entry, ok := index[ref]
+	if !ok {
+		return "", false, nil
+	}
+	path := c.blobPath(entry.Digest)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	if Digest(content) != entry.Digest {
+		return "", false, fmt.Errorf("cached step template %s does not match pinned digest %s", ref, entry.Digest)
+	}
+	return path, true, nil
+}
+
+// Resolve returns the path of a local file holding the content of a remote step template.
+// Pinned references are served from the cache, other references are pulled unless the cache is offline.
+func (c *Cache) Resolve(ctx context.Context, ref string) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	path, ok, err := c.lookup(ref)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return path, nil
+	}
+	if c.options.Offline {
+		return "", fmt.Errorf("step template %s is not in the cache (offline mode)", ref)
+	}
+	entry, err := c.pull(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	return c.blobPath(entry.Digest), nil
+}
+
+// Pull fetches a remote step template and pins its digest in the cache.
+func (c *Cache) Pull(ctx context.Context, ref string) (Entry, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.options.Offline {
+		return Entry{}, errors.New("cannot pull step templates in offline mode")
+	}
+	return c.pull(ctx, ref)
+}
+
+func (c *Cache) pull(ctx context.Context, ref string) (Entry, error) {
+	parsed, err := Parse(ref)
+	if err != nil {
+		return Entry{}, err
+	}
+	content, err := c.fetch(ctx, parsed)
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to fetch step template %s: %w", ref, err)
+	}
+	return c.store(ref, content)
+}
+
+func (c *Cache) store(ref string, content []byte) (Entry, error) {
+	entry := Entry{
+		Reference: ref,
+		Digest:    Digest(content),
+		Pulled:    time.Now().UTC(),
+	}
+	if err := writeFile(c.blobPath(entry.Digest), content); err != nil {
+		return Entry{}, err
+	}
+	index, err := c.readIndex()
+	if err != nil {
+		return Entry{}, err
+	}
+	index[ref] = entry
+	if err := c.writeIndex(index); err != nil {
+		return Entry{}, err
+	}
+	return entry, nil
+}
+
+// Push publishes a step template to an OCI registry and pins the pushed content in the cache.
+func (c *Cache) Push(ctx context.Context, ref string, content []byte) (Entry, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.options.Offline {
+		return Entry{}, errors.New("cannot push step templates in offline mode")
+	}
+	parsed, err := Parse(ref)
+	if err != nil {
+		return Entry{}, err
+	}
+	if parsed.Kind != KindOCI {
+		return Entry{}, fmt.Errorf("only oci references can be pushed: %s", ref)
+	}
+	client := &registryClient{client: c.client, insecure: c.options.Insecure, credentials: c.credentials}
+	if _, err := client.push(ctx, parsed, content); err != nil {
+		return Entry{}, fmt.Errorf("failed to push step template %s: %w", ref, err)
+	}
+	return c.store(ref, content)
+}
+
+// List returns the references pinned in the cache.
+func (c *Cache) List() ([]Entry, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	index, err := c.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(index))
+	for _, entry := range index {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Reference < entries[j].Reference
+	})
+	return entries, nil
+}
+
+func (c *Cache) fetchRemote(ctx context.Context, ref *Reference) ([]byte, error) {
+	switch ref.Kind {
+	case KindOCI:
+		client := &registryClient{client: c.client, insecure: c.options.Insecure, credentials: c.credentials}
+		return client.pull(ctx, ref)
+	case KindGit:
+		return fetchGit(ctx, ref)
+	default:
+		return nil, fmt.Errorf("unsupported reference kind: %s", ref.Kind)
+	}
+}
+
+func fetchGit(ctx context.Context, ref *Reference) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "chainsaw-template-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	dst := filepath.Join(dir, "repo")
+	if err := getter.GetAny(dst, ref.Source, getter.WithContext(ctx)); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dst, filepath.Clean(filepath.FromSlash(ref.Path)))
+	if rel, err := filepath.Rel(dst, path); err != nil || strings.HasPrefix(rel, "..") {
+		return nil, fmt.Errorf("path %s escapes the repository", ref.Path)
+	}
+	return os.ReadFile(path)
+}
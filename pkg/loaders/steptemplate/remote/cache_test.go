@@ -0,0 +1,148 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const template = `apiVersion: chainsaw.kyverno.io/v1alpha1
+kind: StepTemplate
+metadata:
+  name: create
+spec:
+  try:
+  - sleep:
+      duration: 1s
+`
+
+// registry is a minimal in memory OCI registry.
+type registry struct {
+	lock      sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+}
+
+func newRegistry() *httptest.Server {
+	r := &registry{
+		blobs:     map[string][]byte{},
+		manifests: map[string][]byte{},
+	}
+	return httptest.NewServer(r)
+}
+
+func (r *registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	body, _ := io.ReadAll(req.Body)
+	switch {
+	case strings.Contains(path, "/blobs/uploads/"):
+		if req.Method == http.MethodPost {
+			w.Header().Set("Location", "/v2/"+path+"upload-id")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		digest := req.URL.Query().Get("digest")
+		if Digest(body) != digest {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.blobs[digest] = body
+		w.WriteHeader(http.StatusCreated)
+	case strings.Contains(path, "/blobs/"):
+		blob, ok := r.blobs[path[strings.LastIndex(path, "/")+1:]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(blob)
+	case strings.Contains(path, "/manifests/"):
+		if req.Method == http.MethodPut {
+			r.manifests[path] = body
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		manifest, ok := r.manifests[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(manifest)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestCache_PushPull(t *testing.T) {
+	server := newRegistry()
+	defer server.Close()
+	ref := "oci://" + strings.TrimPrefix(server.URL, "http://") + "/templates/create:v1"
+	publisher := NewCache(Options{Dir: t.TempDir(), Insecure: true})
+	pushed, err := publisher.Push(context.TODO(), ref, []byte(template))
+	assert.NoError(t, err)
+	assert.Equal(t, Digest([]byte(template)), pushed.Digest)
+	cache := NewCache(Options{Dir: t.TempDir(), Insecure: true})
+	pulled, err := cache.Pull(context.TODO(), ref)
+	assert.NoError(t, err)
+	assert.Equal(t, pushed.Digest, pulled.Digest)
+	path, err := cache.Resolve(context.TODO(), ref)
+	assert.NoError(t, err)
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, template, string(content))
+	entries, err := cache.List()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, ref, entries[0].Reference)
+}
+
+func TestCache_Resolve(t *testing.T) {
+	ref := "oci://registry.example.com/templates/create:v1"
+	dir := t.TempDir()
+	calls := 0
+	cache := NewCache(Options{Dir: dir})
+	cache.fetch = func(context.Context, *Reference) ([]byte, error) {
+		calls++
+		return []byte(template), nil
+	}
+	// first resolution pulls and pins the reference
+	path, err := cache.Resolve(context.TODO(), ref)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	// next resolutions are served from the cache
+	cached, err := cache.Resolve(context.TODO(), ref)
+	assert.NoError(t, err)
+	assert.Equal(t, path, cached)
+	assert.Equal(t, 1, calls)
+	// offline mode uses the cache and fails on unknown references
+	offline := NewCache(Options{Dir: dir, Offline: true})
+	offline.fetch = func(context.Context, *Reference) ([]byte, error) {
+		return nil, errors.New("unexpected fetch")
+	}
+	cached, err = offline.Resolve(context.TODO(), ref)
+	assert.NoError(t, err)
+	assert.Equal(t, path, cached)
+	_, err = offline.Resolve(context.TODO(), "oci://registry.example.com/templates/delete:v1")
+	assert.EqualError(t, err, "step template oci://registry.example.com/templates/delete:v1 is not in the cache (offline mode)")
+	_, err = offline.Pull(context.TODO(), ref)
+	assert.Error(t, err)
+	// tampered blobs don't match the pinned digest
+	assert.NoError(t, os.WriteFile(path, []byte("tampered"), 0o600))
+	_, err = cache.Resolve(context.TODO(), ref)
+	assert.Error(t, err)
+}
+
+func TestCache_PushGit(t *testing.T) {
+	cache := NewCache(Options{Dir: t.TempDir()})
+	_, err := cache.Push(context.TODO(), "git::https://github.com/org/repo.git//create.yaml", []byte(template))
+	assert.EqualError(t, err, "only oci references can be pushed: git::https://github.com/org/repo.git//create.yaml")
+}
@@ -0,0 +1,243 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	manifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	// ArtifactType is the artifact type of step templates pushed by chainsaw.
+	ArtifactType = "application/vnd.kyverno.chainsaw.steptemplate.v1"
+	// LayerMediaType is the media type of the layer holding the step template content.
+	LayerMediaType = "application/vnd.kyverno.chainsaw.steptemplate.layer.v1+yaml"
+	emptyMediaType = "application/vnd.oci.empty.v1+json"
+	maxBlobSize    = 4 << 20
+)
+
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+type manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	ArtifactType  string       `json:"artifactType,omitempty"`
+	Config        descriptor   `json:"config"`
+	Layers        []descriptor `json:"layers"`
+}
+
+var emptyConfig = []byte("{}")
+
+// Digest returns the sha256 digest of content.
+func Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// registryClient is a minimal OCI distribution client, it authenticates with the credentials
+// from the docker configuration and caches registry tokens per scope.
+type registryClient struct {
+	client         *http.Client
+	insecure       bool
+	credentials    credentialsFunc
+	authorizations map[string]string
+}
+
+func (c *registryClient) url(ref *Reference, format string, args ...any) string {
+	scheme := "https"
+	if c.insecure || isLocalhost(ref.Registry) {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/%s", scheme, ref.Registry, ref.Repository, fmt.Sprintf(format, args...))
+}
+
+func isLocalhost(registry string) bool {
+	host := registry
+	if i := strings.LastIndex(host, ":"); i >= 0 {
+		host = host[:i]
+	}
+	return host == "localhost" || host == "127.0.0.1" || host == "[::1]"
+}
+
+// scope returns the token scope needed to send a request to the repository of ref.
+func scope(ref *Reference, method string) string {
+	if method == http.MethodGet || method == http.MethodHead {
+		return fmt.Sprintf("repository:%s:pull", ref.Repository)
+	}
+	return fmt.Sprintf("repository:%s:pull,push", ref.Repository)
+}
+
+func (c *registryClient) do(ctx context.Context, ref *Reference, method string, url string, body []byte, header http.Header) (*http.Response, error) {
+	scope := scope(ref, method)
+	send := func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		if authorization := c.authorizations[scope]; authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		return c.client.Do(req)
+	}
+	resp, err := send()
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+	authorization, err := c.authenticate(ctx, ref.Registry, scope, challenge)
+	if err != nil {
+		return nil, err
+	}
+	if c.authorizations == nil {
+		c.authorizations = map[string]string{}
+	}
+	c.authorizations[scope] = authorization
+	return send()
+}
+
+func readBody(resp *http.Response, expected int) ([]byte, error) {
+	defer resp.Body.Close()
+	if resp.StatusCode != expected {
+		return nil, fmt.Errorf("unexpected registry response: %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxBlobSize))
+}
+
+// pull fetches the step template stored in the first layer of the artifact.
+func (c *registryClient) pull(ctx context.Context, ref *Reference) ([]byte, error) {
+	header := http.Header{"Accept": []string{manifestMediaType}}
+	resp, err := c.do(ctx, ref, http.MethodGet, c.url(ref, "manifests/%s", ref.manifestRef()), nil, header)
+	if err != nil {
+		return nil, err
+	}
+	data, err := readBody(resp, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+	if ref.Digest != "" && Digest(data) != ref.Digest {
+		return nil, fmt.Errorf("manifest digest mismatch, expected %s", ref.Digest)
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+	if len(m.Layers) != 1 {
+		return nil, fmt.Errorf("expected exactly one layer in the step template artifact, found %d", len(m.Layers))
+	}
+	layer := m.Layers[0]
+	resp, err = c.do(ctx, ref, http.MethodGet, c.url(ref, "blobs/%s", layer.Digest), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	content, err := readBody(resp, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blob %s: %w", layer.Digest, err)
+	}
+	if Digest(content) != layer.Digest {
+		return nil, fmt.Errorf("blob digest mismatch, expected %s", layer.Digest)
+	}
+	return content, nil
+}
+
+// push uploads content as a single layer artifact and returns the manifest digest.
+func (c *registryClient) push(ctx context.Context, ref *Reference, content []byte) (string, error) {
+	if ref.Digest != "" {
+		return "", errors.New("cannot push to a digest reference")
+	}
+	if err := c.pushBlob(ctx, ref, emptyConfig); err != nil {
+		return "", err
+	}
+	if err := c.pushBlob(ctx, ref, content); err != nil {
+		return "", err
+	}
+	m := manifest{
+		SchemaVersion: 2,
+		MediaType:     manifestMediaType,
+		ArtifactType:  ArtifactType,
+		Config: descriptor{
+			MediaType: emptyMediaType,
+			Digest:    Digest(emptyConfig),
+			Size:      int64(len(emptyConfig)),
+		},
+		Layers: []descriptor{{
+			MediaType: LayerMediaType,
+			Digest:    Digest(content),
+			Size:      int64(len(content)),
+		}},
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	header := http.Header{"Content-Type": []string{manifestMediaType}}
+	resp, err := c.do(ctx, ref, http.MethodPut, c.url(ref, "manifests/%s", ref.Tag), data, header)
+	if err != nil {
+		return "", err
+	}
+	if _, err := readBody(resp, http.StatusCreated); err != nil {
+		return "", fmt.Errorf("failed to push manifest: %w", err)
+	}
+	return Digest(data), nil
+}
+
+func (c *registryClient) pushBlob(ctx context.Context, ref *Reference, content []byte) error {
+	digest := Digest(content)
+	resp, err := c.do(ctx, ref, http.MethodHead, c.url(ref, "blobs/%s", digest), nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	resp, err = c.do(ctx, ref, http.MethodPost, c.url(ref, "blobs/uploads/"), nil, nil)
+	if err != nil {
+		return err
+	}
+	location := resp.Header.Get("Location")
+	if _, err := readBody(resp, http.StatusAccepted); err != nil {
+		return fmt.Errorf("failed to start blob upload: %w", err)
+	}
+	upload, err := url.Parse(location)
+	if err != nil {
+		return fmt.Errorf("invalid upload location: %w", err)
+	}
+	if !upload.IsAbs() {
+		base, err := url.Parse(c.url(ref, ""))
+		if err != nil {
+			return err
+		}
+		upload = base.ResolveReference(upload)
+	}
+	query := upload.Query()
+	query.Set("digest", digest)
+	upload.RawQuery = query.Encode()
+	header := http.Header{"Content-Type": []string{"application/octet-stream"}}
+	resp, err = c.do(ctx, ref, http.MethodPut, upload.String(), content, header)
+	if err != nil {
+		return err
+	}
+	if _, err := readBody(resp, http.StatusCreated); err != nil {
+		return fmt.Errorf("failed to upload blob %s: %w", digest, err)
+	}
+	return nil
+}
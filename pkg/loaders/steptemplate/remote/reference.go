@@ -0,0 +1,105 @@
+package remote
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+)
+
+const (
+	ociPrefix = "oci://"
+	gitPrefix = "git::"
+)
+
+// Kind is the kind of a remote step template reference.
+type Kind string
+
+const (
+	KindOCI Kind = "oci"
+	KindGit Kind = "git"
+)
+
+// Reference is a parsed remote step template reference.
+//
+// OCI references have the form `oci://<registry>/<repository>[:<tag>|@<digest>]`,
+// git references use the go-getter syntax `git::<url>//<path>[?ref=<ref>]`.
+type Reference struct {
+	Raw  string
+	Kind Kind
+	// Registry, Repository, Tag and Digest are set for OCI references.
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+	// Source and Path are set for git references.
+	Source string
+	Path   string
+}
+
+// IsRemote returns true if the step template reference points to a remote location.
+func IsRemote(ref string) bool {
+	return strings.HasPrefix(ref, ociPrefix) || strings.HasPrefix(ref, gitPrefix)
+}
+
+// Parse parses a remote step template reference.
+func Parse(ref string) (*Reference, error) {
+	switch {
+	case strings.HasPrefix(ref, ociPrefix):
+		return parseOCI(ref)
+	case strings.HasPrefix(ref, gitPrefix):
+		return parseGit(ref)
+	default:
+		return nil, fmt.Errorf("not a remote step template reference: %s", ref)
+	}
+}
+
+func parseOCI(ref string) (*Reference, error) {
+	out := &Reference{
+		Raw:  ref,
+		Kind: KindOCI,
+	}
+	name := strings.TrimPrefix(ref, ociPrefix)
+	if i := strings.Index(name, "@"); i >= 0 {
+		out.Digest = name[i+1:]
+		name = name[:i]
+		if !strings.HasPrefix(out.Digest, "sha256:") {
+			return nil, fmt.Errorf("unsupported digest in reference %s", ref)
+		}
+	}
+	if i := strings.LastIndex(name, ":"); i >= 0 && !strings.Contains(name[i:], "/") {
+		out.Tag = name[i+1:]
+		name = name[:i]
+	}
+	registry, repository, ok := strings.Cut(name, "/")
+	if !ok || registry == "" || repository == "" {
+		return nil, fmt.Errorf("invalid oci reference %s, expected oci://<registry>/<repository>[:<tag>|@<digest>]", ref)
+	}
+	out.Registry = registry
+	out.Repository = repository
+	if out.Tag == "" && out.Digest == "" {
+		out.Tag = "latest"
+	}
+	return out, nil
+}
+
+func parseGit(ref string) (*Reference, error) {
+	source, path := getter.SourceDirSubdir(ref)
+	if path == "" {
+		return nil, fmt.Errorf("invalid git reference %s, expected git::<url>//<path>[?ref=<ref>]", ref)
+	}
+	return &Reference{
+		Raw:    ref,
+		Kind:   KindGit,
+		Source: source,
+		Path:   path,
+	}, nil
+}
+
+// manifestRef returns the tag or digest used to address the manifest of an OCI reference.
+func (r *Reference) manifestRef() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
@@ -0,0 +1,89 @@
+package remote
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRemote(t *testing.T) {
+	assert.True(t, IsRemote("oci://ghcr.io/org/templates/create:v1"))
+	assert.True(t, IsRemote("git::https://github.com/org/repo.git//templates/create.yaml"))
+	assert.False(t, IsRemote("templates/create.yaml"))
+	assert.False(t, IsRemote("../create.yaml"))
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		ref     string
+		want    *Reference
+		wantErr bool
+	}{{
+		name: "oci tag",
+		ref:  "oci://localhost:5000/templates/create:v1",
+		want: &Reference{
+			Raw:        "oci://localhost:5000/templates/create:v1",
+			Kind:       KindOCI,
+			Registry:   "localhost:5000",
+			Repository: "templates/create",
+			Tag:        "v1",
+		},
+	}, {
+		name: "oci default tag",
+		ref:  "oci://ghcr.io/org/create",
+		want: &Reference{
+			Raw:        "oci://ghcr.io/org/create",
+			Kind:       KindOCI,
+			Registry:   "ghcr.io",
+			Repository: "org/create",
+			Tag:        "latest",
+		},
+	}, {
+		name: "oci digest",
+		ref:  "oci://ghcr.io/org/create@sha256:abc",
+		want: &Reference{
+			Raw:        "oci://ghcr.io/org/create@sha256:abc",
+			Kind:       KindOCI,
+			Registry:   "ghcr.io",
+			Repository: "org/create",
+			Digest:     "sha256:abc",
+		},
+	}, {
+		name:    "oci without repository",
+		ref:     "oci://ghcr.io",
+		wantErr: true,
+	}, {
+		name:    "oci bad digest",
+		ref:     "oci://ghcr.io/org/create@md5:abc",
+		wantErr: true,
+	}, {
+		name: "git",
+		ref:  "git::https://github.com/org/repo.git//templates/create.yaml?ref=v1",
+		want: &Reference{
+			Raw:    "git::https://github.com/org/repo.git//templates/create.yaml?ref=v1",
+			Kind:   KindGit,
+			Source: "git::https://github.com/org/repo.git?ref=v1",
+			Path:   "templates/create.yaml",
+		},
+	}, {
+		name:    "git without path",
+		ref:     "git::https://github.com/org/repo.git",
+		wantErr: true,
+	}, {
+		name:    "local",
+		ref:     "templates/create.yaml",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.ref)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/kyverno/chainsaw/pkg/apis/conversion"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
 	"github.com/kyverno/chainsaw/pkg/loaders"
 	yamlutils "github.com/kyverno/chainsaw/pkg/utils/yaml"
 	"github.com/kyverno/pkg/ext/resource/convert"
@@ -20,7 +22,10 @@ type (
 	converter     = func(unstructured.Unstructured) (*v1alpha1.Test, error)
 )
 
-var test_v1alpha1 = v1alpha1.SchemeGroupVersion.WithKind("Test")
+var (
+	test_v1alpha1 = v1alpha1.SchemeGroupVersion.WithKind("Test")
+	test_v1alpha2 = v1alpha2.SchemeGroupVersion.WithKind("Test")
+)
 
 func Load(file string, remarshal bool) ([]*v1alpha1.Test, error) {
 	content, err := os.ReadFile(filepath.Clean(file))
@@ -79,9 +84,30 @@ func parse(content []byte, remarshal bool, splitter splitter, loaderFactory load
 				return nil, err
 			}
 			tests = append(tests, test)
+		case test_v1alpha2:
+			test, err := convertV1alpha2(untyped)
+			if err != nil {
+				return nil, err
+			}
+			tests = append(tests, test)
 		default:
 			return nil, fmt.Errorf("type not supported %s", gvk)
 		}
 	}
 	return tests, nil
 }
+
+// convertV1alpha2 converts a v1alpha2 test to v1alpha1, the version consumed by the runner.
+func convertV1alpha2(untyped unstructured.Unstructured) (*v1alpha1.Test, error) {
+	in, err := convert.To[v1alpha2.Test](untyped)
+	if err != nil {
+		return nil, err
+	}
+	var out v1alpha1.Test
+	if err := conversion.Convert_v1alpha2_Test_To_v1alpha1_Test(in, &out, nil); err != nil {
+		return nil, fmt.Errorf("failed to convert test %s: %w", in.Name, err)
+	}
+	out.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	out.Kind = "Test"
+	return &out, nil
+}
@@ -13,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
 )
 
 func TestLoad(t *testing.T) {
@@ -144,6 +145,67 @@ func TestLoad(t *testing.T) {
 				}},
 			},
 		}},
+	}, {
+		name: "ok v1alpha2",
+		path: filepath.Join(basePath, "ok-v1alpha2.yaml"),
+		want: []*v1alpha1.Test{{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "chainsaw.kyverno.io/v1alpha1",
+				Kind:       "Test",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-1",
+			},
+			Spec: v1alpha1.TestSpec{
+				Cluster:    ptr.To("foo"),
+				Skip:       ptr.To(true),
+				Concurrent: ptr.To(true),
+				Steps: []v1alpha1.TestStep{{
+					TestStepSpec: v1alpha1.TestStepSpec{
+						Try: []v1alpha1.Operation{{
+							Apply: &v1alpha1.Apply{
+								ActionBindings: v1alpha1.ActionBindings{
+									Bindings: []v1alpha1.Binding{{
+										Name:  "foo",
+										Value: v1alpha1.Any{Value: "bar"},
+									}},
+								},
+								ActionResourceRef: v1alpha1.ActionResourceRef{
+									FileRef: v1alpha1.FileRef{
+										File: "foo.yaml",
+									},
+								},
+							},
+						}, {
+							OperationBase: v1alpha1.OperationBase{
+								ContinueOnError: ptr.To(true),
+							},
+							Assert: &v1alpha1.Assert{
+								ActionCheckRef: v1alpha1.ActionCheckRef{
+									FileRef: v1alpha1.FileRef{
+										File: "bar.yaml",
+									},
+								},
+								ActionClusters: v1alpha1.ActionClusters{
+									Cluster: ptr.To("bar"),
+								},
+							},
+						}},
+						Catch: []v1alpha1.CatchFinally{{
+							Description: "collect logs",
+							PodLogs: &v1alpha1.PodLogs{
+								ActionObjectSelector: v1alpha1.ActionObjectSelector{
+									ObjectName: v1alpha1.ObjectName{
+										Namespace: "foo",
+										Name:      "bar",
+									},
+								},
+							},
+						}},
+					},
+				}},
+			},
+		}},
 	}, {
 		name: "multiple",
 		path: filepath.Join(basePath, "multiple.yaml"),
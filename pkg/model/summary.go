@@ -8,12 +8,14 @@ type SummaryResult interface {
 	Passed() int32
 	Failed() int32
 	Skipped() int32
+	Flaky() int32
 }
 
 type Summary struct {
 	passed  atomic.Int32
 	failed  atomic.Int32
 	skipped atomic.Int32
+	flaky   atomic.Int32
 }
 
 func (s *Summary) IncPassed() {
@@ -28,6 +30,10 @@ func (s *Summary) IncSkipped() {
 	s.skipped.Add(1)
 }
 
+func (s *Summary) IncFlaky() {
+	s.flaky.Add(1)
+}
+
 func (s *Summary) Passed() int32 {
 	return s.passed.Load()
 }
@@ -39,3 +45,7 @@ func (s *Summary) Failed() int32 {
 func (s *Summary) Skipped() int32 {
 	return s.skipped.Load()
 }
+
+func (s *Summary) Flaky() int32 {
+	return s.flaky.Load()
+}
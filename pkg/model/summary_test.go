@@ -12,7 +12,7 @@ func Test_summay(t *testing.T) {
 	var s Summary
 	const count int32 = 10000
 	for i := 0; i < int(count); i++ {
-		wg.Add(3)
+		wg.Add(4)
 		go func() {
 			defer wg.Done()
 			s.IncFailed()
@@ -25,9 +25,14 @@ func Test_summay(t *testing.T) {
 			defer wg.Done()
 			s.IncSkipped()
 		}()
+		go func() {
+			defer wg.Done()
+			s.IncFlaky()
+		}()
 	}
 	wg.Wait()
 	assert.Equal(t, count, s.Failed())
 	assert.Equal(t, count, s.Passed())
 	assert.Equal(t, count, s.Skipped())
+	assert.Equal(t, count, s.Flaky())
 }
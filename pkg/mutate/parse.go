@@ -105,7 +105,7 @@ func (n *scalarNode) mutate(ctx context.Context, path *field.Path, value any, bi
 			if err != nil {
 				return nil, field.InternalError(path, err)
 			}
-			searched, err := Mutate(ctx, nil, Parse(ctx, obj), nil, bindings, template.WithFunctionCaller(functions.Caller()))
+			searched, err := Mutate(ctx, nil, Parse(ctx, obj), nil, bindings, template.WithFunctionCaller(functions.CallerWithContext(ctx)))
 			if err != nil {
 				return nil, field.InternalError(path, err)
 			}
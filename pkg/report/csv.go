@@ -2,32 +2,28 @@ package report
 
 import (
 	"fmt"
-	"github.com/kyverno/chainsaw/pkg/utils/table"
 	"os"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/utils/table"
 )
 
-func saveCsv(report *Report, file string) error {
+func saveCsv(report JSONReport, file string) error {
 	var rows [][]interface{}
 
-	perFolder := map[string][]*TestReport{}
-	for _, test := range report.tests {
-		perFolder[test.test.BasePath] = append(perFolder[test.test.BasePath], test)
+	perFolder := map[string][]JSONTestReport{}
+	for _, test := range report.Tests {
+		perFolder[test.BasePath] = append(perFolder[test.BasePath], test)
 	}
 	for _, tests := range perFolder {
 		for _, test := range tests {
 			var row []interface{}
-			row = append(row, test.test.Test.Name)
-			if test.failed {
-				row = append(row, "fail")
-			} else if test.skipped {
-				row = append(row, "skip")
-			} else {
-				row = append(row, "pass")
-			}
-			for i, step := range test.steps {
-				for j, op := range step.reports {
-					if op.err != nil {
-						row = append(row, fmt.Sprintf("step %d op %d - %s: %s", i, j, op.operationType, op.err))
+			row = append(row, test.Name)
+			row = append(row, string(test.Status))
+			for i, step := range test.Steps {
+				for j, op := range step.Operations {
+					if len(op.Errors) != 0 {
+						row = append(row, fmt.Sprintf("step %d op %d - %s: %s", i, j, operationLabel(op), strings.Join(op.Errors, "; ")))
 					}
 				}
 			}
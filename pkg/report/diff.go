@@ -0,0 +1,103 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+)
+
+type DiffStatus string
+
+const (
+	DiffNewlyFailing DiffStatus = "newly-failing"
+	DiffNewlyPassing DiffStatus = "newly-passing"
+	DiffFlaky        DiffStatus = "flaky"
+)
+
+// Outcomes counts the outcomes of a test in a run.
+type Outcomes struct {
+	Passed  int
+	Failed  int
+	Skipped int
+	Flaky   int
+}
+
+func (o Outcomes) add(status Status) Outcomes {
+	switch status {
+	case StatusFail:
+		o.Failed++
+	case StatusSkip:
+		o.Skipped++
+	case StatusFlaky:
+		o.Flaky++
+	default:
+		o.Passed++
+	}
+	return o
+}
+
+func (o Outcomes) flaky() bool {
+	return o.Flaky != 0 || (o.Passed != 0 && o.Failed != 0)
+}
+
+func (o Outcomes) String() string {
+	if o == (Outcomes{}) {
+		return "-"
+	}
+	var parts []string
+	for _, part := range []struct {
+		count int
+		name  Status
+	}{{o.Passed, StatusPass}, {o.Failed, StatusFail}, {o.Skipped, StatusSkip}, {o.Flaky, StatusFlaky}} {
+		if part.count != 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", part.count, part.name))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// TestDiff is a test whose outcome differs between two runs.
+type TestDiff struct {
+	Test   TestKey
+	Status DiffStatus
+	Base   Outcomes
+	Head   Outcomes
+}
+
+// Diff compares two runs of the same suite.
+// A test is flaky when it passed after being retried or both passed and failed in one of the runs (repeated runs or merged reports),
+// otherwise it is newly failing (or passing) when it failed (or passed) in head and only passed (or failed) in base.
+// Tests that are skipped or absent in one of the runs are not reported.
+func Diff(base, head JSONReport) []TestDiff {
+	outcomes := func(report JSONReport) ([]TestKey, map[TestKey]Outcomes) {
+		keys, groups := groupTests(report)
+		out := map[TestKey]Outcomes{}
+		for key, tests := range groups {
+			for _, test := range tests {
+				out[key] = out[key].add(test.Status)
+			}
+		}
+		return keys, out
+	}
+	_, before := outcomes(base)
+	keys, after := outcomes(head)
+	var out []TestDiff
+	for _, key := range keys {
+		diff := TestDiff{
+			Test: key,
+			Base: before[key],
+			Head: after[key],
+		}
+		switch {
+		case diff.Base.flaky() || diff.Head.flaky():
+			diff.Status = DiffFlaky
+		case diff.Head.Failed != 0 && diff.Base.Passed != 0:
+			diff.Status = DiffNewlyFailing
+		case diff.Head.Passed != 0 && diff.Base.Failed != 0:
+			diff.Status = DiffNewlyPassing
+		default:
+			continue
+		}
+		out = append(out, diff)
+	}
+	return out
+}
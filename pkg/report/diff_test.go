@@ -0,0 +1,77 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Diff(t *testing.T) {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	scenario := func(test JSONTestReport, id int) JSONTestReport {
+		test.Scenario = &JSONScenario{Id: id}
+		return test
+	}
+	source := func(test JSONTestReport, source string) JSONTestReport {
+		test.Source = source
+		return test
+	}
+	base := JSONReport{Tests: []JSONTestReport{
+		test("stable", StatusPass, start, time.Second),
+		test("regression", StatusPass, start, time.Second),
+		test("fixed", StatusFail, start, time.Second),
+		test("flaky", StatusPass, start, time.Second),
+		test("skipped", StatusPass, start, time.Second),
+		scenario(test("scenario", StatusPass, start, time.Second), 1),
+		scenario(test("scenario", StatusFail, start, time.Second), 2),
+		source(test("merged", StatusPass, start, time.Second), "a"),
+		source(test("merged", StatusFail, start, time.Second), "b"),
+	}}
+	head := JSONReport{Tests: []JSONTestReport{
+		test("stable", StatusPass, start, time.Second),
+		test("regression", StatusFail, start, time.Second),
+		test("fixed", StatusPass, start, time.Second),
+		test("flaky", StatusPass, start, time.Second),
+		test("flaky", StatusFail, start, time.Second),
+		test("skipped", StatusSkip, start, 0),
+		test("added", StatusFail, start, time.Second),
+		scenario(test("scenario", StatusPass, start, time.Second), 1),
+		scenario(test("scenario", StatusFail, start, time.Second), 2),
+		source(test("merged", StatusPass, start, time.Second), "a"),
+		source(test("merged", StatusPass, start, time.Second), "b"),
+	}}
+	assert.Equal(t, []TestDiff{{
+		Test:   TestKey{Name: "regression"},
+		Status: DiffNewlyFailing,
+		Base:   Outcomes{Passed: 1},
+		Head:   Outcomes{Failed: 1},
+	}, {
+		Test:   TestKey{Name: "fixed"},
+		Status: DiffNewlyPassing,
+		Base:   Outcomes{Failed: 1},
+		Head:   Outcomes{Passed: 1},
+	}, {
+		Test:   TestKey{Name: "flaky"},
+		Status: DiffFlaky,
+		Base:   Outcomes{Passed: 1},
+		Head:   Outcomes{Passed: 1, Failed: 1},
+	}, {
+		Test:   TestKey{Source: "b", Name: "merged"},
+		Status: DiffNewlyPassing,
+		Base:   Outcomes{Failed: 1},
+		Head:   Outcomes{Passed: 1},
+	}}, Diff(base, head))
+	assert.Nil(t, Diff(base, base))
+}
+
+func TestOutcomes_String(t *testing.T) {
+	assert.Equal(t, "-", Outcomes{}.String())
+	assert.Equal(t, "2 pass, 1 skip", Outcomes{Passed: 2, Skipped: 1}.String())
+}
+
+func TestTestKey_String(t *testing.T) {
+	assert.Equal(t, "foo", TestKey{Name: "foo"}.String())
+	assert.Equal(t, "foo (tests/foo)", TestKey{BasePath: "tests/foo", Name: "foo"}.String())
+	assert.Equal(t, "foo (tests/foo) [a]", TestKey{Source: "a", BasePath: "tests/foo", Name: "foo"}.String())
+}
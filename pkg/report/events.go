@@ -0,0 +1,226 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/utils/ndjson"
+)
+
+type EventType string
+
+const (
+	EventReportStart    EventType = "report.start"
+	EventReportEnd      EventType = "report.end"
+	EventTestStart      EventType = "test.start"
+	EventTestEnd        EventType = "test.end"
+	EventStepStart      EventType = "step.start"
+	EventStepEnd        EventType = "step.end"
+	EventOperationStart EventType = "operation.start"
+	EventOperationEnd   EventType = "operation.end"
+)
+
+// Event is a record emitted while a report is built.
+// Tests, steps and operations are identified by their position (starting at 1) in their parent,
+// the payload is a snapshot of the node when the event was emitted (without its children).
+type Event struct {
+	Type        EventType            `json:"type"`
+	Time        time.Time            `json:"time"`
+	TestId      int                  `json:"testId,omitempty"`
+	StepId      int                  `json:"stepId,omitempty"`
+	OperationId int                  `json:"operationId,omitempty"`
+	Report      *JSONReport          `json:"report,omitempty"`
+	Test        *JSONTestReport      `json:"test,omitempty"`
+	Step        *JSONStepReport      `json:"step,omitempty"`
+	Operation   *JSONOperationReport `json:"operation,omitempty"`
+}
+
+// EventSink receives report events as they happen.
+type EventSink interface {
+	Emit(Event)
+}
+
+func emit(sink EventSink, event Event) {
+	if sink == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	sink.Emit(event)
+}
+
+// FileSink appends events to a file, one JSON document per line.
+// Every event is written as soon as it is emitted so that the file survives an interrupted run.
+type FileSink struct {
+	appender *ndjson.Appender
+}
+
+// NewFileSink creates (or truncates) the event log at path.
+func NewFileSink(path string) (*FileSink, error) {
+	appender, err := ndjson.Open(path, true)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{appender: appender}, nil
+}
+
+func (s *FileSink) Emit(event Event) {
+	s.appender.Append(event)
+}
+
+// Close closes the file and returns the first error encountered while writing events.
+func (s *FileSink) Close() error {
+	return s.appender.Close()
+}
+
+// ReadEvents reads an event log, a truncated last line (interrupted write) is ignored.
+func ReadEvents(r io.Reader) ([]Event, error) {
+	var events []Event
+	err := ndjson.Read(r, func(event Event) error {
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid event log: %w", err)
+	}
+	return events, nil
+}
+
+// LoadEvents reads an event log from file.
+func LoadEvents(file string) ([]Event, error) {
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadEvents(f)
+}
+
+// errInterrupted is reported on tests, steps and operations that started but never ended.
+const errInterrupted = "interrupted before completion"
+
+// Rebuild rebuilds a report from its events.
+// Nodes that started but never ended (the run was interrupted) are reported as failed
+// and end with the last event.
+func Rebuild(events []Event) (JSONReport, error) {
+	out := JSONReport{
+		Version: JSONReportVersion,
+		Tests:   []JSONTestReport{},
+	}
+	if len(events) == 0 {
+		return out, fmt.Errorf("no event found")
+	}
+	type step struct {
+		JSONStepReport
+		ended      bool
+		operations map[int]*JSONOperationReport
+		opEnded    map[int]bool
+	}
+	type test struct {
+		JSONTestReport
+		ended bool
+		steps map[int]*step
+	}
+	tests := map[int]*test{}
+	getTest := func(id int) *test {
+		if tests[id] == nil {
+			tests[id] = &test{steps: map[int]*step{}}
+		}
+		return tests[id]
+	}
+	getStep := func(testId, id int) *step {
+		t := getTest(testId)
+		if t.steps[id] == nil {
+			t.steps[id] = &step{operations: map[int]*JSONOperationReport{}, opEnded: map[int]bool{}}
+		}
+		return t.steps[id]
+	}
+	var ended bool
+	last := events[len(events)-1].Time
+	for _, event := range events {
+		switch event.Type {
+		case EventReportStart, EventReportEnd:
+			if event.Report != nil {
+				out.Name = event.Report.Name
+				out.StartTime = event.Report.StartTime
+				out.EndTime = event.Report.EndTime
+			}
+			ended = event.Type == EventReportEnd
+		case EventTestStart, EventTestEnd:
+			if event.Test != nil {
+				t := getTest(event.TestId)
+				// a retried test starts again, steps of the previous attempts are part of the test attempts
+				if event.Type == EventTestStart && len(event.Test.Attempts) != 0 {
+					t.steps = map[int]*step{}
+				}
+				t.JSONTestReport = *event.Test
+				t.ended = event.Type == EventTestEnd
+			}
+		case EventStepStart, EventStepEnd:
+			if event.Step != nil {
+				s := getStep(event.TestId, event.StepId)
+				s.JSONStepReport = *event.Step
+				s.ended = event.Type == EventStepEnd
+			}
+		case EventOperationStart, EventOperationEnd:
+			if event.Operation != nil {
+				s := getStep(event.TestId, event.StepId)
+				operation := *event.Operation
+				s.operations[event.OperationId] = &operation
+				s.opEnded[event.OperationId] = event.Type == EventOperationEnd
+			}
+		default:
+			return out, fmt.Errorf("unknown event type: %s", event.Type)
+		}
+	}
+	interrupt := func(status *Status, start time.Time, end *time.Time, durationStr *string, errs *[]string) {
+		*status = StatusFail
+		*end = last
+		*durationStr = duration(start, last)
+		*errs = append(*errs, errInterrupted)
+	}
+	if !ended {
+		out.EndTime = last
+	}
+	out.Duration = duration(out.StartTime, out.EndTime)
+	for _, testId := range sortedKeys(tests) {
+		t := tests[testId]
+		for _, stepId := range sortedKeys(t.steps) {
+			s := t.steps[stepId]
+			for _, operationId := range sortedKeys(s.operations) {
+				operation := s.operations[operationId]
+				if !s.opEnded[operationId] {
+					interrupt(&operation.Status, operation.StartTime, &operation.EndTime, &operation.Duration, &operation.Errors)
+				}
+				if operation.Status == StatusFail {
+					s.Status = StatusFail
+				}
+				s.Operations = append(s.Operations, *operation)
+			}
+			if !s.ended {
+				interrupt(&s.Status, s.StartTime, &s.EndTime, &s.Duration, &s.Errors)
+			}
+			t.Steps = append(t.Steps, s.JSONStepReport)
+		}
+		if !t.ended {
+			interrupt(&t.Status, t.StartTime, &t.EndTime, &t.Duration, &t.Errors)
+		}
+		out.Summary.add(t.Status)
+		out.Tests = append(out.Tests, t.JSONTestReport)
+	}
+	return out, nil
+}
+
+func sortedKeys[V any](in map[int]V) []int {
+	keys := make([]int, 0, len(in))
+	for key := range in {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
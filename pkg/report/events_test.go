@@ -0,0 +1,146 @@
+package report
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/discovery"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type memorySink struct {
+	events []Event
+}
+
+func (s *memorySink) Emit(event Event) {
+	s.events = append(s.events, event)
+}
+
+// run builds a report the way the processors do.
+func run(sink EventSink) *Report {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	report := New("chainsaw-report")
+	report.SetEventSink(sink)
+	report.SetStartTime(start)
+	test := report.ForTest(&discovery.Test{
+		BasePath: "testdata/foo",
+		Test: &v1alpha1.Test{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		},
+	})
+	test.SetStartTime(start)
+	test.SetNamespace("chainsaw-foo")
+	step := test.ForStep(&v1alpha1.TestStep{Name: "create"})
+	step.SetStartTime(start)
+	apply := step.ForOperation("Apply deployment.yaml", OperationTypeApply)
+	apply.SetStartTime(start)
+	apply.SetResource("deployment.yaml")
+	apply.AddOutput("stdout", "applied")
+	apply.SetEndTime(start.Add(500 * time.Millisecond))
+	check := step.ForOperation("Assert ", OperationTypeAssert)
+	check.SetStartTime(start.Add(500 * time.Millisecond))
+	check.SetCluster("data")
+	check.SetErr(errors.New("spec.replicas: expected 3"))
+	check.SetEndTime(start.Add(time.Second))
+	step.SetEndTime(start.Add(time.Second))
+	test.Fail()
+	test.SetEndTime(start.Add(time.Second))
+	skipped := report.ForTest(&discovery.Test{
+		Test: &v1alpha1.Test{
+			ObjectMeta: metav1.ObjectMeta{Name: "bar"},
+		},
+	})
+	skipped.SetStartTime(start.Add(time.Second))
+	skipped.Skip()
+	skipped.SetEndTime(start.Add(time.Second))
+	report.SetEndTime(start.Add(2 * time.Second))
+	return report
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events", "events.ndjson")
+	sink, err := NewFileSink(path)
+	assert.NoError(t, err)
+	report := run(sink)
+	assert.NoError(t, sink.Close())
+	events, err := LoadEvents(path)
+	assert.NoError(t, err)
+	assert.Len(t, events, 12)
+	got, err := Rebuild(events)
+	assert.NoError(t, err)
+	assert.Equal(t, report.toJSON(), got)
+}
+
+func TestReadEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []EventType
+		wantErr bool
+	}{{
+		name: "empty",
+	}, {
+		name: "events",
+		in:   "{\"type\":\"report.start\"}\n\n{\"type\":\"report.end\"}\n",
+		want: []EventType{EventReportStart, EventReportEnd},
+	}, {
+		name: "truncated last line",
+		in:   "{\"type\":\"report.start\"}\n{\"type\":\"test.st",
+		want: []EventType{EventReportStart},
+	}, {
+		name:    "invalid line",
+		in:      "{\"type\":\"report.start\"}\n{\"type\":\n{\"type\":\"report.end\"}\n",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := ReadEvents(strings.NewReader(tt.in))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			var got []EventType
+			for _, event := range events {
+				got = append(got, event.Type)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRebuild(t *testing.T) {
+	var sink memorySink
+	run(&sink)
+	// the run is interrupted while the assert operation is running
+	events := sink.events[:6]
+	got, err := Rebuild(events)
+	assert.NoError(t, err)
+	last := events[len(events)-1].Time
+	assert.Equal(t, last, got.EndTime)
+	assert.Equal(t, JSONSummary{Failed: 1}, got.Summary)
+	assert.Len(t, got.Tests, 1)
+	test := got.Tests[0]
+	assert.Equal(t, "foo", test.Name)
+	assert.Equal(t, StatusFail, test.Status)
+	assert.Equal(t, []string{errInterrupted}, test.Errors)
+	assert.Len(t, test.Steps, 1)
+	assert.Equal(t, StatusFail, test.Steps[0].Status)
+	assert.Len(t, test.Steps[0].Operations, 2)
+	assert.Equal(t, StatusPass, test.Steps[0].Operations[0].Status)
+	assert.Equal(t, []JSONLog{{Stream: "stdout", Content: "applied"}}, test.Steps[0].Operations[0].Logs)
+	assert.Equal(t, StatusFail, test.Steps[0].Operations[1].Status)
+	assert.Equal(t, []string{errInterrupted}, test.Steps[0].Operations[1].Errors)
+	assert.Equal(t, last, test.Steps[0].Operations[1].EndTime)
+	// no event
+	_, err = Rebuild(nil)
+	assert.Error(t, err)
+	// unknown event
+	_, err = Rebuild([]Event{{Type: "foo"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,89 @@
+package report
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"os"
+	"strings"
+	"time"
+)
+
+//go:embed html.tmpl
+var htmlTemplate string
+
+var htmlTmpl = template.Must(template.New("report").Funcs(template.FuncMap{
+	"bar":    bar,
+	"inc":    func(i int) int { return i + 1 },
+	"lines":  lines,
+	"phases": phases,
+}).Parse(htmlTemplate))
+
+func saveHTML(report JSONReport, file string) error {
+	var buf bytes.Buffer
+	if err := htmlTmpl.Execute(&buf, report); err != nil {
+		return err
+	}
+	return os.WriteFile(file, buf.Bytes(), 0o600)
+}
+
+// bar returns the style positioning an item in a timeline spanning from start to end.
+func bar(start, end, itemStart, itemEnd time.Time) template.CSS {
+	total := end.Sub(start)
+	if total <= 0 || itemStart.IsZero() {
+		return "left:0%;width:0%"
+	}
+	left := float64(itemStart.Sub(start)) / float64(total) * 100
+	width := float64(itemEnd.Sub(itemStart)) / float64(total) * 100
+	left = min(max(left, 0), 100)
+	width = min(max(width, 0.5), 100-left)
+	return template.CSS(fmt.Sprintf("left:%.2f%%;width:%.2f%%", left, width))
+}
+
+type line struct {
+	Class string
+	Text  string
+}
+
+// lines classifies the lines of an error so that resource diffs can be highlighted.
+func lines(in string) []line {
+	var out []line
+	for _, text := range strings.Split(in, "\n") {
+		class := ""
+		switch {
+		case strings.HasPrefix(text, "+++"), strings.HasPrefix(text, "---"), strings.HasPrefix(text, "@@"):
+			class = "meta"
+		case strings.HasPrefix(text, "+"):
+			class = "add"
+		case strings.HasPrefix(text, "-"):
+			class = "del"
+		case strings.HasPrefix(text, "* "):
+			class = "err"
+		}
+		out = append(out, line{Class: class, Text: text})
+	}
+	return out
+}
+
+type phaseGroup struct {
+	Phase      Phase
+	Operations []JSONOperationReport
+}
+
+// phases groups operations by phase, in the order blocks run.
+func phases(operations []JSONOperationReport) []phaseGroup {
+	var out []phaseGroup
+	for _, phase := range []Phase{PhaseTry, PhaseCatch, PhaseFinally, PhaseCleanup} {
+		group := phaseGroup{Phase: phase}
+		for _, operation := range operations {
+			if operation.Phase == phase || (operation.Phase == "" && phase == PhaseTry) {
+				group.Operations = append(group.Operations, operation)
+			}
+		}
+		if len(group.Operations) != 0 {
+			out = append(out, group)
+		}
+	}
+	return out
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// resourceError mimics the message of a resource error, a header, the field errors and a diff.
+func resourceError() error {
+	return stringError(strings.Join([]string{
+		"-----------------------",
+		"apps/v1/Deployment/foo",
+		"-----------------------",
+		"* spec.replicas: Invalid value: 1: Expected value: 3",
+		"",
+		"--- expected",
+		"+++ actual",
+		"@@ -4,4 +4,4 @@",
+		"   name: foo",
+		" spec:",
+		"-  replicas: 3",
+		"+  replicas: 1",
+	}, "\n"))
+}
+
+type stringError string
+
+func (e stringError) Error() string { return string(e) }
+
+func TestSaveHTML(t *testing.T) {
+	report := sampleReport()
+	report.tests[0].steps[0].reports[0].AddOutput("stdout", "<created>\n")
+	report.tests[0].steps[0].reports[1].SetErr(resourceError())
+	dir := t.TempDir()
+	assert.NoError(t, report.Save("HTML", dir, "report"))
+	data, err := os.ReadFile(filepath.Join(dir, "report.html"))
+	assert.NoError(t, err)
+	html := string(data)
+	assert.Contains(t, html, "<title>chainsaw-report</title>")
+	assert.Contains(t, html, `<span class="fail">1 failed</span>`)
+	assert.Contains(t, html, "<strong>foo</strong>")
+	assert.Contains(t, html, "namespace chainsaw-foo")
+	assert.Contains(t, html, "cluster data")
+	assert.Contains(t, html, `<span class="del">-  replicas: 3</span>`)
+	assert.Contains(t, html, `<span class="add">&#43;  replicas: 1</span>`)
+	assert.Contains(t, html, `<span class="err">* spec.replicas: Invalid value: 1: Expected value: 3</span>`)
+	// logs are escaped
+	assert.Contains(t, html, "&lt;created&gt;")
+	// operations are grouped by phase and collected output is attached
+	assert.Contains(t, html, `<div class="label phase">catch</div>`)
+	assert.Contains(t, html, "attachment podLogs")
+	assert.Contains(t, html, "starting foo")
+	// the report is self contained
+	assert.NotContains(t, html, "<script src")
+	assert.NotContains(t, html, "<link")
+}
+
+func Test_bar(t *testing.T) {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, "left:25.00%;width:50.00%", string(bar(start, start.Add(4*time.Second), start.Add(time.Second), start.Add(3*time.Second))))
+	assert.Equal(t, "left:0%;width:0%", string(bar(start, start, start, start)))
+	assert.Equal(t, "left:100.00%;width:0.00%", string(bar(start, start.Add(time.Second), start.Add(2*time.Second), start.Add(3*time.Second))))
+}
+
+func Test_lines(t *testing.T) {
+	assert.Equal(t, []line{
+		{Class: "meta", Text: "---"},
+		{Text: "header"},
+		{Class: "err", Text: "* error"},
+		{Class: "del", Text: "-a"},
+		{Class: "add", Text: "+b"},
+		{Text: " c"},
+	}, lines("---\nheader\n* error\n-a\n+b\n c"))
+}
+
+func Test_phases(t *testing.T) {
+	operations := []JSONOperationReport{
+		{Name: "cleanup", Phase: PhaseCleanup},
+		{Name: "apply"},
+		{Name: "catch", Phase: PhaseCatch},
+		{Name: "assert", Phase: PhaseTry},
+	}
+	assert.Equal(t, []phaseGroup{{
+		Phase:      PhaseTry,
+		Operations: []JSONOperationReport{operations[1], operations[3]},
+	}, {
+		Phase:      PhaseCatch,
+		Operations: []JSONOperationReport{operations[2]},
+	}, {
+		Phase:      PhaseCleanup,
+		Operations: []JSONOperationReport{operations[0]},
+	}}, phases(operations))
+}
@@ -4,55 +4,277 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"strings"
+	"path/filepath"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"go.uber.org/multierr"
+)
+
+// JSONReportVersion is the version of the JSON report schema.
+const JSONReportVersion = "chainsaw.kyverno.io/report/v1"
+
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip"
+	// StatusFlaky is the status of tests that passed after being retried.
+	StatusFlaky Status = "flaky"
 )
 
-type ret struct {
+// JSONReport is the serialized form of a Report.
+type JSONReport struct {
+	Version   string           `json:"version"`
+	Name      string           `json:"name"`
+	StartTime time.Time        `json:"startTime"`
+	EndTime   time.Time        `json:"endTime"`
+	Duration  string           `json:"duration"`
+	Summary   JSONSummary      `json:"summary"`
+	Tests     []JSONTestReport `json:"tests"`
+}
+
+type JSONSummary struct {
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+	Flaky   int `json:"flaky"`
+}
+
+func (s *JSONSummary) add(status Status) {
+	switch status {
+	case StatusFail:
+		s.Failed++
+	case StatusSkip:
+		s.Skipped++
+	case StatusFlaky:
+		s.Flaky++
+	default:
+		s.Passed++
+	}
+}
+
+// JSONTestReport is the serialized form of a TestReport.
+type JSONTestReport struct {
+	Name      string           `json:"name"`
+	BasePath  string           `json:"basePath,omitempty"`
+	Status    Status           `json:"status"`
+	StartTime time.Time        `json:"startTime"`
+	EndTime   time.Time        `json:"endTime"`
+	Duration  string           `json:"duration"`
+	Namespace string           `json:"namespace,omitempty"`
+	Scenario  *JSONScenario    `json:"scenario,omitempty"`
+	Errors    []string         `json:"errors,omitempty"`
+	Output    []string         `json:"output,omitempty"`
+	Steps     []JSONStepReport `json:"steps,omitempty"`
+	// Source labels the run a test comes from in merged reports.
+	Source string `json:"source,omitempty"`
+	// Attempts contains the failed attempts of a retried test, the last attempt is the test itself.
+	Attempts []JSONTestReport `json:"attempts,omitempty"`
+}
+
+type JSONScenario struct {
+	Id       int                `json:"id"`
+	Bindings []v1alpha1.Binding `json:"bindings,omitempty"`
+}
+
+// JSONStepReport is the serialized form of a StepReport.
+type JSONStepReport struct {
+	Name        string                `json:"name,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Status      Status                `json:"status"`
+	StartTime   time.Time             `json:"startTime"`
+	EndTime     time.Time             `json:"endTime"`
+	Duration    string                `json:"duration"`
+	Errors      []string              `json:"errors,omitempty"`
+	Traces      []string              `json:"traces,omitempty"`
+	Operations  []JSONOperationReport `json:"operations,omitempty"`
+}
+
+// JSONOperationReport is the serialized form of an OperationReport.
+type JSONOperationReport struct {
+	Name        string           `json:"name"`
+	Type        OperationType    `json:"type"`
+	Phase       Phase            `json:"phase"`
+	Cluster     string           `json:"cluster,omitempty"`
+	Resource    string           `json:"resource,omitempty"`
+	Status      Status           `json:"status"`
+	StartTime   time.Time        `json:"startTime"`
+	EndTime     time.Time        `json:"endTime"`
+	Duration    string           `json:"duration"`
+	Errors      []string         `json:"errors,omitempty"`
+	Logs        []JSONLog        `json:"logs,omitempty"`
+	Attachments []JSONAttachment `json:"attachments,omitempty"`
+}
+
+// JSONLog is an output stream captured while running an operation.
+type JSONLog struct {
+	Stream  string `json:"stream"`
+	Content string `json:"content"`
+}
+
+// JSONAttachment is the output collected by a collector operation (get, describe, events, podLogs).
+type JSONAttachment struct {
 	Name    string `json:"name"`
-	Status  string `json:"status"`
-	Message string `json:"message"`
-	Remark  string `json:"remark"`
-}
-
-func saveJson(report *Report, file string) error {
-	var rows []ret
-
-	perFolder := map[string][]*TestReport{}
-	for _, test := range report.tests {
-		perFolder[test.test.BasePath] = append(perFolder[test.test.BasePath], test)
-	}
-	for _, tests := range perFolder {
-		for _, test := range tests {
-			var row ret
-			row.Name = test.test.Test.Name
-			if test.failed {
-				row.Status = "fail"
-			} else if test.skipped {
-				row.Status = "skip"
-			} else {
-				row.Status = "pass"
-			}
-			if test.err != nil {
-				row.Remark = test.err.Error()
-			}
-			for i, step := range test.steps {
-				if step.err != nil {
-					row.Remark = step.err.Error()
-				}
-				for j, op := range step.reports {
-					if op.err != nil {
-						row.Remark = fmt.Sprintf("step %d: %s op %d - %s[%s]: %s", i, step.step.Description, j, op.operationType, op.name, op.err.Error())
-					}
-				}
-			}
-			row.Message = strings.Join(test.output, "\n")
-			rows = append(rows, row)
-		}
+	Content string `json:"content"`
+}
+
+// LoadJSON reads a JSON report from file.
+func LoadJSON(file string) (*JSONReport, error) {
+	data, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+	var report JSONReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	if report.Version != JSONReportVersion {
+		return nil, fmt.Errorf("unsupported report version %q in %s (expected %q)", report.Version, file, JSONReportVersion)
 	}
+	return &report, nil
+}
 
-	marshal, err := json.Marshal(rows)
+func saveJson(report JSONReport, file string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(file, marshal, 0o600)
+	return os.WriteFile(file, data, 0o600)
+}
+
+func (r *Report) toJSON() JSONReport {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	out := JSONReport{
+		Version:   JSONReportVersion,
+		Name:      r.name,
+		StartTime: r.startTime.UTC(),
+		EndTime:   r.endTime.UTC(),
+		Duration:  duration(r.startTime, r.endTime),
+		Tests:     []JSONTestReport{},
+	}
+	for _, test := range r.tests {
+		test := test.toJSON()
+		out.Summary.add(test.Status)
+		out.Tests = append(out.Tests, test)
+	}
+	return out
+}
+
+func (r *TestReport) toJSON() JSONTestReport {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	out := JSONTestReport{
+		BasePath:  r.test.BasePath,
+		Status:    StatusPass,
+		StartTime: r.startTime.UTC(),
+		EndTime:   r.endTime.UTC(),
+		Duration:  duration(r.startTime, r.endTime),
+		Namespace: r.namespace,
+		Errors:    errorList(r.err),
+		Output:    r.output,
+	}
+	if r.test.Test != nil {
+		out.Name = r.test.Test.Name
+	}
+	if r.failed {
+		out.Status = StatusFail
+	} else if r.skipped {
+		out.Status = StatusSkip
+	} else if len(r.attempts) != 0 {
+		out.Status = StatusFlaky
+	}
+	if len(r.attempts) != 0 {
+		out.Attempts = r.attempts
+		out.StartTime = r.attempts[0].StartTime
+		out.Duration = duration(out.StartTime, r.endTime)
+	}
+	if r.scenario != 0 {
+		out.Scenario = &JSONScenario{
+			Id:       r.scenario,
+			Bindings: r.bindings,
+		}
+	}
+	for _, step := range r.steps {
+		out.Steps = append(out.Steps, step.toJSON())
+	}
+	return out
+}
+
+func (r *StepReport) toJSON() JSONStepReport {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	out := JSONStepReport{
+		Status:    StatusPass,
+		StartTime: r.startTime.UTC(),
+		EndTime:   r.endTime.UTC(),
+		Duration:  duration(r.startTime, r.endTime),
+		Errors:    errorList(r.err),
+		Traces:    r.traces,
+	}
+	if r.step != nil {
+		out.Name = r.step.Name
+		out.Description = r.step.Description
+	}
+	if r.err != nil {
+		out.Status = StatusFail
+	}
+	for _, operation := range r.reports {
+		operation := operation.toJSON()
+		if operation.Status == StatusFail {
+			out.Status = StatusFail
+		}
+		out.Operations = append(out.Operations, operation)
+	}
+	return out
+}
+
+func (r *OperationReport) toJSON() JSONOperationReport {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	out := JSONOperationReport{
+		Name:      r.name,
+		Type:      r.operationType,
+		Phase:     r.Phase(),
+		Cluster:   r.cluster,
+		Resource:  r.resource,
+		Status:    StatusPass,
+		StartTime: r.startTime.UTC(),
+		EndTime:   r.endTime.UTC(),
+		Duration:  duration(r.startTime, r.endTime),
+		Errors:    errorList(r.err),
+	}
+	if r.err != nil {
+		out.Status = StatusFail
+	}
+	for _, attachment := range r.attachments {
+		out.Attachments = append(out.Attachments, JSONAttachment{
+			Name:    attachment.Name,
+			Content: attachment.Content,
+		})
+	}
+	for _, log := range r.logs {
+		out.Logs = append(out.Logs, JSONLog{
+			Stream:  log.Stream,
+			Content: log.Content,
+		})
+	}
+	return out
+}
+
+func duration(start, end time.Time) string {
+	if start.IsZero() || end.Before(start) {
+		return time.Duration(0).String()
+	}
+	return end.Sub(start).String()
+}
+
+func errorList(err error) []string {
+	var out []string
+	for _, err := range multierr.Errors(err) {
+		out = append(out, err.Error())
+	}
+	return out
 }
@@ -0,0 +1,176 @@
+package report
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/data"
+	"github.com/kyverno/chainsaw/pkg/discovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/xeipuuv/gojsonschema"
+	"go.uber.org/multierr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func sampleReport() *Report {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	report := New("chainsaw-report")
+	report.SetStartTime(start)
+	report.SetEndTime(start.Add(10 * time.Second))
+	test := report.ForTest(&discovery.Test{
+		BasePath: "testdata/foo",
+		Test: &v1alpha1.Test{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		},
+	})
+	test.SetStartTime(start)
+	test.SetEndTime(start.Add(5 * time.Second))
+	test.SetNamespace("chainsaw-foo")
+	test.SetScenario(2, []v1alpha1.Binding{{
+		Name:  "replicas",
+		Value: v1alpha1.Any{Value: float64(3)},
+	}})
+	test.Fail()
+	step := test.ForStep(&v1alpha1.TestStep{Name: "create"})
+	step.SetStartTime(start)
+	step.SetEndTime(start.Add(time.Second))
+	step.AddTrace("trace")
+	apply := step.ForOperation("Apply deployment.yaml", OperationTypeApply)
+	apply.SetResource("deployment.yaml")
+	apply.SetStartTime(start)
+	apply.SetEndTime(start.Add(500 * time.Millisecond))
+	check := step.ForOperation("Assert ", OperationTypeAssert)
+	check.SetCluster("data")
+	check.SetResource("apps/v1/Deployment foo")
+	check.SetStartTime(start.Add(500 * time.Millisecond))
+	check.SetEndTime(start.Add(time.Second))
+	check.SetErr(multierr.Combine(errors.New("spec.replicas: expected 3"), errors.New("status.ready: expected true")))
+	logs := step.ForOperation("Logs ", OperationTypePodLogs)
+	logs.SetPhase(PhaseCatch)
+	logs.SetStartTime(start.Add(time.Second))
+	logs.AddAttachment("podLogs", "starting foo")
+	logs.SetEndTime(start.Add(time.Second))
+	skipped := report.ForTest(&discovery.Test{
+		Test: &v1alpha1.Test{
+			ObjectMeta: metav1.ObjectMeta{Name: "bar"},
+		},
+	})
+	skipped.Skip()
+	return report
+}
+
+func TestReport_toJSON(t *testing.T) {
+	got := sampleReport().toJSON()
+	assert.Equal(t, JSONReportVersion, got.Version)
+	assert.Equal(t, "10s", got.Duration)
+	assert.Equal(t, JSONSummary{Failed: 1, Skipped: 1}, got.Summary)
+	assert.Len(t, got.Tests, 2)
+	test := got.Tests[0]
+	assert.Equal(t, StatusFail, test.Status)
+	assert.Equal(t, "chainsaw-foo", test.Namespace)
+	assert.Equal(t, &JSONScenario{Id: 2, Bindings: []v1alpha1.Binding{{Name: "replicas", Value: v1alpha1.Any{Value: float64(3)}}}}, test.Scenario)
+	assert.Len(t, test.Steps, 1)
+	step := test.Steps[0]
+	assert.Equal(t, StatusFail, step.Status)
+	assert.Equal(t, []string{"trace"}, step.Traces)
+	assert.Equal(t, []JSONOperationReport{{
+		Name:      "Apply deployment.yaml",
+		Type:      OperationTypeApply,
+		Phase:     PhaseTry,
+		Resource:  "deployment.yaml",
+		Status:    StatusPass,
+		StartTime: step.StartTime,
+		EndTime:   step.StartTime.Add(500 * time.Millisecond),
+		Duration:  "500ms",
+	}, {
+		Name:      "Assert ",
+		Type:      OperationTypeAssert,
+		Phase:     PhaseTry,
+		Cluster:   "data",
+		Resource:  "apps/v1/Deployment foo",
+		Status:    StatusFail,
+		StartTime: step.StartTime.Add(500 * time.Millisecond),
+		EndTime:   step.StartTime.Add(time.Second),
+		Duration:  "500ms",
+		Errors:    []string{"spec.replicas: expected 3", "status.ready: expected true"},
+	}, {
+		Name:        "Logs ",
+		Type:        OperationTypePodLogs,
+		Phase:       PhaseCatch,
+		Status:      StatusPass,
+		StartTime:   step.StartTime.Add(time.Second),
+		EndTime:     step.StartTime.Add(time.Second),
+		Duration:    "0s",
+		Attachments: []JSONAttachment{{Name: "podLogs", Content: "starting foo"}},
+	}}, step.Operations)
+	assert.Equal(t, StatusSkip, got.Tests[1].Status)
+}
+
+func flakyReport() *Report {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	report := New("chainsaw-report")
+	test := report.ForTest(&discovery.Test{
+		Test: &v1alpha1.Test{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		},
+	})
+	test.SetStartTime(start)
+	step := test.ForStep(&v1alpha1.TestStep{Name: "create"})
+	step.SetErr(errors.New("context deadline exceeded"))
+	test.Fail()
+	test.SetEndTime(start.Add(time.Second))
+	test.NewAttempt()
+	test.SetStartTime(start.Add(2 * time.Second))
+	test.ForStep(&v1alpha1.TestStep{Name: "create"})
+	test.SetEndTime(start.Add(3 * time.Second))
+	return report
+}
+
+func TestTestReport_NewAttempt(t *testing.T) {
+	got := flakyReport().toJSON()
+	assert.Equal(t, JSONSummary{Flaky: 1}, got.Summary)
+	test := got.Tests[0]
+	assert.Equal(t, StatusFlaky, test.Status)
+	assert.Equal(t, "3s", test.Duration)
+	assert.Len(t, test.Steps, 1)
+	assert.Equal(t, StatusPass, test.Steps[0].Status)
+	assert.Len(t, test.Attempts, 1)
+	attempt := test.Attempts[0]
+	assert.Equal(t, StatusFail, attempt.Status)
+	assert.Equal(t, "1s", attempt.Duration)
+	assert.Equal(t, []string{"context deadline exceeded"}, attempt.Steps[0].Errors)
+}
+
+func TestSaveJson(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "report.json")
+	assert.NoError(t, sampleReport().Save("JSON", "", file))
+	// the saved report validates against the published schema
+	schemas, err := data.ReportSchemas()
+	assert.NoError(t, err)
+	schema, err := fs.ReadFile(schemas, "report-chainsaw-v1.json")
+	assert.NoError(t, err)
+	content, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), gojsonschema.NewBytesLoader(content))
+	assert.NoError(t, err)
+	assert.True(t, result.Valid(), result.Errors())
+	// and loads back
+	loaded, err := LoadJSON(file)
+	assert.NoError(t, err)
+	assert.Equal(t, sampleReport().toJSON(), *loaded)
+}
+
+func TestLoadJSON(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "report.json")
+	assert.NoError(t, os.WriteFile(file, []byte(`[{"name":"foo","status":"pass"}]`), 0o600))
+	_, err := LoadJSON(file)
+	assert.Error(t, err)
+	assert.NoError(t, os.WriteFile(file, []byte(`{"version":"v0"}`), 0o600))
+	_, err = LoadJSON(file)
+	assert.Error(t, err)
+}
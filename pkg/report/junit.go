@@ -4,6 +4,8 @@ import (
 	"encoding/xml"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -11,10 +13,21 @@ type failureNode struct {
 	XMLName struct{} `xml:"failure"`
 }
 
+// flakyFailureNode is a failed attempt of a test that passed after being retried.
+type flakyFailureNode struct {
+	XMLName struct{} `xml:"flakyFailure"`
+	Message string   `xml:"message,attr,omitempty"`
+}
+
 type skippedNode struct {
 	XMLName struct{} `xml:"skipped"`
 }
 
+type systemOutNode struct {
+	XMLName struct{} `xml:"system-out"`
+	Content string   `xml:",chardata"`
+}
+
 type propertyNode struct {
 	XMLName struct{} `xml:"property"`
 	Name    string   `xml:"name,attr"`
@@ -51,15 +64,15 @@ type testsuitesNode struct {
 	Inner     []any
 }
 
-func saveJUnit(report *Report, file string) error {
+func saveJUnit(report JSONReport, file string) error {
 	testsuites := testsuitesNode{
-		Name:      report.name,
-		Timestamp: report.startTime.UTC().Format(time.RFC3339),
-		Time:      report.endTime.Sub(report.startTime).Seconds(),
+		Name:      report.Name,
+		Timestamp: report.StartTime.UTC().Format(time.RFC3339),
+		Time:      report.EndTime.Sub(report.StartTime).Seconds(),
 	}
-	perFolder := map[string][]*TestReport{}
-	for _, test := range report.tests {
-		perFolder[test.test.BasePath] = append(perFolder[test.test.BasePath], test)
+	perFolder := map[string][]JSONTestReport{}
+	for _, test := range report.Tests {
+		perFolder[test.BasePath] = append(perFolder[test.BasePath], test)
 	}
 	for folder, tests := range perFolder {
 		testsuite := testsuiteNode{
@@ -67,48 +80,60 @@ func saveJUnit(report *Report, file string) error {
 		}
 		for _, test := range tests {
 			var properties []any
-			if test.namespace != "" {
+			var attachments []string
+			if test.Namespace != "" {
 				properties = append(properties, propertyNode{
 					Name:  "namespace",
-					Value: test.namespace,
+					Value: test.Namespace,
 				})
 			}
-			for i, step := range test.steps {
-				if step.step.Name != "" {
+			for i, step := range test.Steps {
+				if step.Name != "" {
 					properties = append(properties, propertyNode{
 						Name:  fmt.Sprintf("step%d", i),
-						Value: step.step.Name,
+						Value: step.Name,
 					})
 				}
-				for j, op := range step.reports {
-					if op.err != nil {
+				for j, op := range step.Operations {
+					if len(op.Errors) != 0 {
 						properties = append(properties, propertyNode{
 							Name:  fmt.Sprintf("step%d", i),
-							Value: fmt.Sprintf("op %d - %s: %s", j, op.operationType, op.err),
+							Value: fmt.Sprintf("op %d - %s: %s", j, operationLabel(op), strings.Join(op.Errors, "; ")),
 						})
 					} else {
 						properties = append(properties, propertyNode{
 							Name:  fmt.Sprintf("step%d", i),
-							Value: fmt.Sprintf("op %d - %s", j, op.operationType),
+							Value: fmt.Sprintf("op %d - %s", j, operationLabel(op)),
 						})
 					}
+					for _, attachment := range op.Attachments {
+						attachments = append(attachments, fmt.Sprintf("--- step %d op %d - %s (%s)\n%s", i, j, operationLabel(op), attachment.Name, attachment.Content))
+					}
 				}
 			}
 			testcase := testcaseNode{
-				Name:      test.test.Test.Name,
-				Timestamp: test.startTime.UTC().Format(time.RFC3339),
-				Time:      test.endTime.Sub(test.startTime).Seconds(),
-				File:      test.test.BasePath,
+				Name:      test.Name,
+				Timestamp: test.StartTime.UTC().Format(time.RFC3339),
+				Time:      test.EndTime.Sub(test.StartTime).Seconds(),
+				File:      test.BasePath,
 			}
 			if len(properties) != 0 {
 				testcase.Inner = append(testcase.Inner, propertiesNode{Inner: properties})
 			}
-			if test.skipped {
+			if test.Status == StatusSkip {
 				testcase.Inner = append(testcase.Inner, skippedNode{})
 			}
-			if test.failed {
+			if test.Status == StatusFail {
 				testcase.Inner = append(testcase.Inner, failureNode{})
 			}
+			if test.Status == StatusFlaky {
+				for _, attempt := range test.Attempts {
+					testcase.Inner = append(testcase.Inner, flakyFailureNode{Message: strings.Join(allErrors(attempt), "; ")})
+				}
+			}
+			if len(attachments) != 0 {
+				testcase.Inner = append(testcase.Inner, systemOutNode{Content: strings.Join(attachments, "\n")})
+			}
 			testsuite.Inner = append(testsuite.Inner, testcase)
 		}
 		testsuites.Inner = append(testsuites.Inner, testsuite)
@@ -119,3 +144,181 @@ func saveJUnit(report *Report, file string) error {
 	}
 	return os.WriteFile(file, data, 0o600)
 }
+
+type junitTestsuites struct {
+	XMLName   xml.Name
+	Name      string           `xml:"name,attr"`
+	Timestamp string           `xml:"timestamp,attr"`
+	Time      float64          `xml:"time,attr"`
+	Suites    []junitTestsuite `xml:"testsuite"`
+}
+
+type junitTestsuite struct {
+	Name      string           `xml:"name,attr"`
+	Timestamp string           `xml:"timestamp,attr"`
+	File      string           `xml:"file,attr"`
+	Cases     []junitTestcase  `xml:"testcase"`
+	Suites    []junitTestsuite `xml:"testsuite"`
+}
+
+type junitTestcase struct {
+	Name       string          `xml:"name,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Time       float64         `xml:"time,attr"`
+	File       string          `xml:"file,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	Failure    *junitMessage   `xml:"failure"`
+	Error      *junitMessage   `xml:"error"`
+	Skipped    *junitMessage   `xml:"skipped"`
+	Flaky      []junitMessage  `xml:"flakyFailure"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// LoadJUnit reads a JUnit report from file.
+// JUnit reports carry less information than JSON reports, only tests with their status,
+// timing and failure messages are loaded.
+func LoadJUnit(file string) (*JSONReport, error) {
+	data, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+	var root junitTestsuites
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	switch root.XMLName.Local {
+	case "testsuites":
+	case "testsuite":
+		var suite junitTestsuite
+		if err := xml.Unmarshal(data, &suite); err != nil {
+			return nil, err
+		}
+		root = junitTestsuites{
+			Name:      suite.Name,
+			Timestamp: suite.Timestamp,
+			Suites:    []junitTestsuite{suite},
+		}
+	default:
+		return nil, fmt.Errorf("unsupported JUnit root element %q in %s", root.XMLName.Local, file)
+	}
+	startTime := parseTimestamp(root.Timestamp)
+	out := JSONReport{
+		Version:   JSONReportVersion,
+		Name:      root.Name,
+		StartTime: startTime,
+		EndTime:   startTime.Add(seconds(root.Time)),
+		Duration:  seconds(root.Time).String(),
+		Tests:     []JSONTestReport{},
+	}
+	var load func(junitTestsuite)
+	load = func(suite junitTestsuite) {
+		for _, testcase := range suite.Cases {
+			test := testcase.toJSON(suite, startTime)
+			out.Summary.add(test.Status)
+			out.Tests = append(out.Tests, test)
+		}
+		for _, suite := range suite.Suites {
+			load(suite)
+		}
+	}
+	for _, suite := range root.Suites {
+		load(suite)
+	}
+	return &out, nil
+}
+
+func (testcase junitTestcase) toJSON(suite junitTestsuite, defaultTime time.Time) JSONTestReport {
+	startTime := parseTimestamp(testcase.Timestamp)
+	if startTime.IsZero() {
+		startTime = defaultTime
+	}
+	out := JSONTestReport{
+		Name:      testcase.Name,
+		BasePath:  testcase.File,
+		Status:    StatusPass,
+		StartTime: startTime,
+		EndTime:   startTime.Add(seconds(testcase.Time)),
+		Duration:  seconds(testcase.Time).String(),
+	}
+	if out.BasePath == "" {
+		out.BasePath = suite.File
+	}
+	for _, property := range testcase.Properties {
+		if property.Name == "namespace" {
+			out.Namespace = property.Value
+		}
+	}
+	for _, failure := range []*junitMessage{testcase.Failure, testcase.Error} {
+		if failure != nil {
+			out.Status = StatusFail
+			if message := failure.message(); message != "" {
+				out.Errors = append(out.Errors, message)
+			}
+		}
+	}
+	if out.Status != StatusFail && testcase.Skipped != nil {
+		out.Status = StatusSkip
+	}
+	if out.Status == StatusPass && len(testcase.Flaky) != 0 {
+		out.Status = StatusFlaky
+		for _, failure := range testcase.Flaky {
+			attempt := JSONTestReport{Name: out.Name, BasePath: out.BasePath, Status: StatusFail}
+			if message := failure.message(); message != "" {
+				attempt.Errors = append(attempt.Errors, message)
+			}
+			out.Attempts = append(out.Attempts, attempt)
+		}
+	}
+	return out
+}
+
+func (m *junitMessage) message() string {
+	if m.Message != "" {
+		return m.Message
+	}
+	return strings.TrimSpace(m.Text)
+}
+
+func parseTimestamp(in string) time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, in); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
+
+func seconds(in float64) time.Duration {
+	return time.Duration(in * float64(time.Second)).Round(time.Millisecond)
+}
+
+// allErrors returns the errors of a test, its steps and operations.
+func allErrors(test JSONTestReport) []string {
+	var errs []string
+	errs = append(errs, test.Errors...)
+	for _, step := range test.Steps {
+		errs = append(errs, step.Errors...)
+		for _, op := range step.Operations {
+			errs = append(errs, op.Errors...)
+		}
+	}
+	return errs
+}
+
+// operationLabel is the operation type, prefixed with its phase when the operation
+// doesn't belong to the try block.
+func operationLabel(op JSONOperationReport) string {
+	if op.Phase == "" || op.Phase == PhaseTry {
+		return string(op.Type)
+	}
+	return fmt.Sprintf("%s/%s", op.Phase, op.Type)
+}
@@ -0,0 +1,74 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadJUnit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.xml")
+	report := sampleReport().toJSON()
+	assert.NoError(t, saveJUnit(report, path))
+	got, err := LoadJUnit(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "chainsaw-report", got.Name)
+	assert.Equal(t, report.StartTime, got.StartTime)
+	assert.Equal(t, report.EndTime, got.EndTime)
+	assert.Equal(t, report.Summary, got.Summary)
+	assert.Len(t, got.Tests, 2)
+	var foo JSONTestReport
+	for _, test := range got.Tests {
+		if test.Name == "foo" {
+			foo = test
+		}
+	}
+	assert.Equal(t, "testdata/foo", foo.BasePath)
+	assert.Equal(t, "chainsaw-foo", foo.Namespace)
+	assert.Equal(t, StatusFail, foo.Status)
+	assert.Equal(t, 5*time.Second, foo.EndTime.Sub(foo.StartTime))
+}
+
+func TestLoadJUnit_Testsuite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.xml")
+	data := `<testsuite name="suite" timestamp="2024-06-01T10:00:00">
+  <testcase name="foo" time="1.5"><error>boom</error></testcase>
+  <testcase name="bar"><skipped/></testcase>
+  <testcase name="baz"></testcase>
+</testsuite>`
+	assert.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	got, err := LoadJUnit(path)
+	assert.NoError(t, err)
+	assert.Equal(t, JSONSummary{Passed: 1, Failed: 1, Skipped: 1}, got.Summary)
+	assert.Equal(t, []string{"boom"}, got.Tests[0].Errors)
+	assert.Equal(t, "1.5s", got.Tests[0].Duration)
+	assert.Equal(t, time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC), got.Tests[0].StartTime)
+	// unsupported root
+	assert.NoError(t, os.WriteFile(path, []byte(`<foo></foo>`), 0o600))
+	_, err = LoadJUnit(path)
+	assert.Error(t, err)
+}
+
+func TestSaveJUnit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.xml")
+	assert.NoError(t, saveJUnit(sampleReport().toJSON(), path))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	xml := string(data)
+	assert.Contains(t, xml, `<property name="step0" value="op 1 - assert: spec.replicas: expected 3; status.ready: expected true"></property>`)
+	assert.Contains(t, xml, `<property name="step0" value="op 2 - catch/podLogs"></property>`)
+	assert.Contains(t, xml, "<system-out>--- step 0 op 2 - catch/podLogs (podLogs)&#xA;starting foo</system-out>")
+}
+
+func TestSaveJUnit_Flaky(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.xml")
+	assert.NoError(t, saveJUnit(flakyReport().toJSON(), path))
+	loaded, err := LoadJUnit(path)
+	assert.NoError(t, err)
+	assert.Equal(t, JSONSummary{Flaky: 1}, loaded.Summary)
+	assert.Equal(t, StatusFlaky, loaded.Tests[0].Status)
+	assert.Equal(t, []string{"context deadline exceeded"}, loaded.Tests[0].Attempts[0].Errors)
+}
@@ -0,0 +1,95 @@
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Load reads a report from file, JUnit reports are detected with the .xml extension
+// and other files are read as JSON reports.
+func Load(file string) (*JSONReport, error) {
+	if strings.EqualFold(filepath.Ext(file), ".xml") {
+		return LoadJUnit(file)
+	}
+	return LoadJSON(file)
+}
+
+// Run is a report labelled with the run it comes from.
+type Run struct {
+	Source string
+	Report JSONReport
+}
+
+// Merge combines reports from several runs into a single report.
+// Tests are kept in the order of the input reports, the report spans from the first start to the last end.
+// Tests are labelled with the source of their run unless they were already labelled by a previous merge.
+func Merge(name string, runs ...Run) JSONReport {
+	out := JSONReport{
+		Version: JSONReportVersion,
+		Name:    name,
+		Tests:   []JSONTestReport{},
+	}
+	for _, run := range runs {
+		report := run.Report
+		if !report.StartTime.IsZero() && (out.StartTime.IsZero() || report.StartTime.Before(out.StartTime)) {
+			out.StartTime = report.StartTime
+		}
+		if report.EndTime.After(out.EndTime) {
+			out.EndTime = report.EndTime
+		}
+		for _, test := range report.Tests {
+			if test.Source == "" {
+				test.Source = run.Source
+			}
+			out.Summary.add(test.Status)
+			out.Tests = append(out.Tests, test)
+		}
+	}
+	out.Duration = duration(out.StartTime, out.EndTime)
+	return out
+}
+
+// TestKey identifies a test across runs, tests from different sources are told apart.
+type TestKey struct {
+	Source   string
+	BasePath string
+	Name     string
+}
+
+func (k TestKey) String() string {
+	name := k.Name
+	if k.BasePath != "" {
+		name = fmt.Sprintf("%s (%s)", name, k.BasePath)
+	}
+	if k.Source != "" {
+		name = fmt.Sprintf("%s [%s]", name, k.Source)
+	}
+	return name
+}
+
+func keyOf(test JSONTestReport) TestKey {
+	name := test.Name
+	if test.Scenario != nil {
+		name = fmt.Sprintf("%s[%d]", name, test.Scenario.Id)
+	}
+	return TestKey{
+		Source:   test.Source,
+		BasePath: test.BasePath,
+		Name:     name,
+	}
+}
+
+// groupTests groups tests by key, keys are returned in the order they first appear.
+func groupTests(report JSONReport) ([]TestKey, map[TestKey][]JSONTestReport) {
+	var keys []TestKey
+	groups := map[TestKey][]JSONTestReport{}
+	for _, test := range report.Tests {
+		key := keyOf(test)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], test)
+	}
+	return keys, groups
+}
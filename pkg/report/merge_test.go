@@ -0,0 +1,64 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func test(name string, status Status, start time.Time, d time.Duration) JSONTestReport {
+	return JSONTestReport{
+		Name:      name,
+		Status:    status,
+		StartTime: start,
+		EndTime:   start.Add(d),
+	}
+}
+
+func TestMerge(t *testing.T) {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	got := Merge("merged", Run{
+		Source: "a",
+		Report: JSONReport{
+			StartTime: start.Add(time.Hour),
+			EndTime:   start.Add(2 * time.Hour),
+			Tests:     []JSONTestReport{test("foo", StatusPass, start, time.Second)},
+		},
+	}, Run{
+		Source: "b",
+		Report: JSONReport{
+			StartTime: start,
+			EndTime:   start.Add(time.Hour),
+			Tests: []JSONTestReport{
+				test("foo", StatusFail, start, time.Second),
+				{Name: "bar", Status: StatusSkip, Source: "c"},
+			},
+		},
+	})
+	assert.Equal(t, JSONReportVersion, got.Version)
+	assert.Equal(t, "merged", got.Name)
+	assert.Equal(t, start, got.StartTime)
+	assert.Equal(t, start.Add(2*time.Hour), got.EndTime)
+	assert.Equal(t, "2h0m0s", got.Duration)
+	assert.Equal(t, JSONSummary{Passed: 1, Failed: 1, Skipped: 1}, got.Summary)
+	assert.Len(t, got.Tests, 3)
+	assert.Equal(t, "a", got.Tests[0].Source)
+	assert.Equal(t, "b", got.Tests[1].Source)
+	assert.Equal(t, "c", got.Tests[2].Source)
+	// no report
+	got = Merge("empty")
+	assert.Equal(t, []JSONTestReport{}, got.Tests)
+	assert.Equal(t, "0s", got.Duration)
+}
+
+func TestLoad(t *testing.T) {
+	got, err := Load("../../testdata/commands/report/base.json")
+	assert.NoError(t, err)
+	assert.Len(t, got.Tests, 4)
+	got, err = Load("../../testdata/commands/report/head.xml")
+	assert.NoError(t, err)
+	assert.Len(t, got.Tests, 6)
+	_, err = Load("../../testdata/commands/report/missing.json")
+	assert.Error(t, err)
+}
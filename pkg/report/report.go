@@ -15,14 +15,43 @@ import (
 type OperationType string
 
 const (
-	OperationTypeCreate  OperationType = "create"
-	OperationTypeDelete  OperationType = "delete"
-	OperationTypeApply   OperationType = "apply"
-	OperationTypeAssert  OperationType = "assert"
-	OperationTypeError   OperationType = "error"
-	OperationTypeScript  OperationType = "script"
-	OperationTypeSleep   OperationType = "sleep"
-	OperationTypeCommand OperationType = "command"
+	OperationTypeCreate   OperationType = "create"
+	OperationTypeDelete   OperationType = "delete"
+	OperationTypeApply    OperationType = "apply"
+	OperationTypeAssert   OperationType = "assert"
+	OperationTypeError    OperationType = "error"
+	OperationTypeScript   OperationType = "script"
+	OperationTypeSleep    OperationType = "sleep"
+	OperationTypeCommand  OperationType = "command"
+	OperationTypePatch    OperationType = "patch"
+	OperationTypeUpdate   OperationType = "update"
+	OperationTypeWait     OperationType = "wait"
+	OperationTypeGet      OperationType = "get"
+	OperationTypeDescribe OperationType = "describe"
+	OperationTypeEvents   OperationType = "events"
+	OperationTypePodLogs  OperationType = "podLogs"
+	OperationTypeProxy    OperationType = "proxy"
+)
+
+// IsCollector returns true for operations collecting data (resources, events, logs) for troubleshooting,
+// their output is attached to the report.
+func (t OperationType) IsCollector() bool {
+	switch t {
+	case OperationTypeGet, OperationTypeDescribe, OperationTypeEvents, OperationTypePodLogs:
+		return true
+	default:
+		return false
+	}
+}
+
+// Phase is the block of a step an operation belongs to.
+type Phase string
+
+const (
+	PhaseTry     Phase = "try"
+	PhaseCatch   Phase = "catch"
+	PhaseFinally Phase = "finally"
+	PhaseCleanup Phase = "cleanup"
 )
 
 type Report struct {
@@ -30,6 +59,7 @@ type Report struct {
 	startTime time.Time
 	endTime   time.Time
 	tests     []*TestReport
+	events    EventSink
 	lock      sync.Mutex
 }
 
@@ -39,23 +69,52 @@ func New(name string) *Report {
 	}
 }
 
+// SetEventSink registers a sink receiving the report events as they happen,
+// it must be called before the report is started.
+func (r *Report) SetEventSink(sink EventSink) {
+	r.events = sink
+}
+
 func (r *Report) SetStartTime(t time.Time) {
 	r.startTime = t
+	emit(r.events, Event{
+		Type: EventReportStart,
+		Report: &JSONReport{
+			Version:   JSONReportVersion,
+			Name:      r.name,
+			StartTime: t.UTC(),
+		},
+	})
 }
 
 func (r *Report) SetEndTime(t time.Time) {
 	r.endTime = t
+	emit(r.events, Event{
+		Type: EventReportEnd,
+		Report: &JSONReport{
+			Version:   JSONReportVersion,
+			Name:      r.name,
+			StartTime: r.startTime.UTC(),
+			EndTime:   t.UTC(),
+			Duration:  duration(r.startTime, t),
+		},
+	})
 }
 
 func (r *Report) ForTest(test *discovery.Test) *TestReport {
-	out := &TestReport{test: test}
 	r.lock.Lock()
 	defer r.lock.Unlock()
+	out := &TestReport{test: test, id: len(r.tests) + 1, events: r.events}
 	r.tests = append(r.tests, out)
 	return out
 }
 
 func (r *Report) Save(format v1alpha2.ReportFormatType, path, name string) error {
+	return Write(r.toJSON(), format, path, name)
+}
+
+// Write writes a serialized report in the given format.
+func Write(report JSONReport, format v1alpha2.ReportFormatType, path, name string) error {
 	if filepath.Ext(name) == "" {
 		name += "." + strings.ToLower(string(format))
 	}
@@ -65,11 +124,13 @@ func (r *Report) Save(format v1alpha2.ReportFormatType, path, name string) error
 	}
 	switch format {
 	case v1alpha2.XMLFormat:
-		return saveJUnit(r, filePath)
+		return saveJUnit(report, filePath)
 	case v1alpha2.JSONFormat:
-		return saveJson(r, filePath)
+		return saveJson(report, filePath)
 	case v1alpha2.CSVFormat:
-		return saveCsv(r, filePath)
+		return saveCsv(report, filePath)
+	case v1alpha2.HTMLFormat:
+		return saveHTML(report, filePath)
 	default:
 		return fmt.Errorf("unknown report format: %s", format)
 	}
@@ -77,15 +138,35 @@ func (r *Report) Save(format v1alpha2.ReportFormatType, path, name string) error
 
 type TestReport struct {
 	test      *discovery.Test
+	id        int
+	events    EventSink
 	startTime time.Time
 	endTime   time.Time
 	namespace string
+	scenario  int
+	bindings  []v1alpha1.Binding
 	skipped   bool
 	failed    bool
 	steps     []*StepReport
 	lock      sync.Mutex
 	output    []string
 	err       error
+	attempts  []JSONTestReport
+}
+
+// NewAttempt archives the current (failed) attempt of the test before it is retried.
+func (r *TestReport) NewAttempt() {
+	attempt := r.toJSON()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	attempt.Attempts = nil
+	attempt.StartTime = r.startTime.UTC()
+	attempt.Duration = duration(r.startTime, r.endTime)
+	r.attempts = append(r.attempts, attempt)
+	r.steps = nil
+	r.failed = false
+	r.output = nil
+	r.err = nil
 }
 
 func (r *TestReport) SetErr(err error) {
@@ -93,14 +174,30 @@ func (r *TestReport) SetErr(err error) {
 }
 func (r *TestReport) SetStartTime(t time.Time) {
 	r.startTime = t
+	r.emit(EventTestStart)
 }
 
 func (r *TestReport) SetOutput(in ...string) {
 	r.output = append(r.output, in...)
 }
 
+// SetEndTime marks the end of the test, the test status must be set before.
 func (r *TestReport) SetEndTime(t time.Time) {
 	r.endTime = t
+	r.emit(EventTestEnd)
+}
+
+func (r *TestReport) emit(eventType EventType) {
+	if r.events == nil {
+		return
+	}
+	test := r.toJSON()
+	test.Steps = nil
+	emit(r.events, Event{
+		Type:   eventType,
+		TestId: r.id,
+		Test:   &test,
+	})
 }
 
 func (r *TestReport) Skip() {
@@ -115,57 +212,176 @@ func (r *TestReport) SetNamespace(namespace string) {
 	r.namespace = namespace
 }
 
+// SetScenario records the scenario the test runs and the bindings it defines.
+func (r *TestReport) SetScenario(id int, bindings []v1alpha1.Binding) {
+	r.scenario = id
+	r.bindings = bindings
+}
+
 func (r *TestReport) ForStep(step *v1alpha1.TestStep) *StepReport {
-	out := &StepReport{step: step}
 	r.lock.Lock()
 	defer r.lock.Unlock()
+	out := &StepReport{step: step, test: r.id, id: len(r.steps) + 1, events: r.events}
 	r.steps = append(r.steps, out)
 	return out
 }
 
 type StepReport struct {
 	step      *v1alpha1.TestStep
+	test      int
+	id        int
+	events    EventSink
 	startTime time.Time
 	endTime   time.Time
 	reports   []*OperationReport
+	traces    []string
 	lock      sync.Mutex
 	err       error
 }
 
 func (r *StepReport) SetStartTime(t time.Time) {
 	r.startTime = t
+	r.emit(EventStepStart)
 }
 
 func (r *StepReport) SetEndTime(t time.Time) {
 	r.endTime = t
+	r.emit(EventStepEnd)
+}
+
+func (r *StepReport) emit(eventType EventType) {
+	if r.events == nil {
+		return
+	}
+	step := r.toJSON()
+	step.Operations = nil
+	emit(r.events, Event{
+		Type:   eventType,
+		TestId: r.test,
+		StepId: r.id,
+		Step:   &step,
+	})
 }
 
 func (r *StepReport) SetErr(err error) {
 	r.err = err
 }
 
+func (r *StepReport) AddTrace(in ...string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.traces = append(r.traces, in...)
+}
+
 func (r *StepReport) ForOperation(name string, operationType OperationType) *OperationReport {
-	step := &OperationReport{name: name, operationType: operationType}
 	r.lock.Lock()
 	defer r.lock.Unlock()
-	r.reports = append(r.reports, step)
-	return step
+	out := &OperationReport{
+		name:          name,
+		operationType: operationType,
+		test:          r.test,
+		step:          r.id,
+		id:            len(r.reports) + 1,
+		events:        r.events,
+	}
+	r.reports = append(r.reports, out)
+	return out
 }
 
 type OperationReport struct {
 	name          string
 	operationType OperationType
+	test          int
+	step          int
+	id            int
+	events        EventSink
+	phase         Phase
+	cluster       string
+	resource      string
 	startTime     time.Time
 	endTime       time.Time
+	logs          []Log
+	attachments   []Attachment
+	lock          sync.Mutex
 	err           error
 }
 
+// Log is an output stream captured while running an operation.
+type Log struct {
+	Stream  string
+	Content string
+}
+
+// AddOutput records the output of a command or script run by the operation.
+func (r *OperationReport) AddOutput(stream string, content string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.logs = append(r.logs, Log{Stream: stream, Content: content})
+}
+
+// Attachment is the output of a collector operation.
+type Attachment struct {
+	Name    string
+	Content string
+}
+
+// AddAttachment records the output collected by the operation.
+func (r *OperationReport) AddAttachment(name string, content string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.attachments = append(r.attachments, Attachment{Name: name, Content: content})
+}
+
+func (r *OperationReport) Name() string {
+	return r.name
+}
+
+func (r *OperationReport) Type() OperationType {
+	return r.operationType
+}
+
+func (r *OperationReport) Phase() Phase {
+	if r.phase == "" {
+		return PhaseTry
+	}
+	return r.phase
+}
+
+// SetPhase sets the block of the step the operation belongs to, it defaults to PhaseTry.
+func (r *OperationReport) SetPhase(phase Phase) {
+	r.phase = phase
+}
+
+func (r *OperationReport) SetCluster(cluster string) {
+	r.cluster = cluster
+}
+
+func (r *OperationReport) SetResource(resource string) {
+	r.resource = resource
+}
+
 func (r *OperationReport) SetStartTime(t time.Time) {
 	r.startTime = t
+	r.emit(EventOperationStart)
 }
 
 func (r *OperationReport) SetEndTime(t time.Time) {
 	r.endTime = t
+	r.emit(EventOperationEnd)
+}
+
+func (r *OperationReport) emit(eventType EventType) {
+	if r.events == nil {
+		return
+	}
+	operation := r.toJSON()
+	emit(r.events, Event{
+		Type:        eventType,
+		TestId:      r.test,
+		StepId:      r.step,
+		OperationId: r.id,
+		Operation:   &operation,
+	})
 }
 
 func (r *OperationReport) SetErr(err error) {
@@ -0,0 +1,58 @@
+package report
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// TestStats contains statistics about the runs of a test.
+type TestStats struct {
+	Test     TestKey
+	Outcomes Outcomes
+	// PassRate is the ratio of passed runs over passed, flaky and failed runs, skipped runs are ignored.
+	PassRate float64
+	// P50, P90 and P99 are duration percentiles of the runs that were not skipped.
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+}
+
+// Stats computes statistics per test, tests are returned in the order they first appear in the report.
+func Stats(report JSONReport) []TestStats {
+	keys, groups := groupTests(report)
+	var out []TestStats
+	for _, key := range keys {
+		stats := TestStats{
+			Test: key,
+		}
+		var durations []time.Duration
+		for _, test := range groups[key] {
+			stats.Outcomes = stats.Outcomes.add(test.Status)
+			if test.Status != StatusSkip && !test.StartTime.IsZero() && !test.EndTime.Before(test.StartTime) {
+				durations = append(durations, test.EndTime.Sub(test.StartTime))
+			}
+		}
+		if runs := stats.Outcomes.Passed + stats.Outcomes.Failed + stats.Outcomes.Flaky; runs != 0 {
+			stats.PassRate = float64(stats.Outcomes.Passed) / float64(runs)
+		}
+		slices.Sort(durations)
+		stats.P50 = percentile(durations, 50)
+		stats.P90 = percentile(durations, 90)
+		stats.P99 = percentile(durations, 99)
+		out = append(out, stats)
+	}
+	return out
+}
+
+// percentile uses the nearest-rank method on sorted values.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
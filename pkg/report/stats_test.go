@@ -0,0 +1,60 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Stats(t *testing.T) {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	report := JSONReport{Tests: []JSONTestReport{
+		test("foo", StatusSkip, start, 0),
+		test("bar", StatusPass, start, time.Second),
+	}}
+	for i := 1; i <= 10; i++ {
+		status := StatusPass
+		if i%4 == 0 {
+			status = StatusFail
+		}
+		report.Tests = append(report.Tests, test("foo", status, start, time.Duration(i)*time.Second))
+	}
+	assert.Equal(t, []TestStats{{
+		Test:     TestKey{Name: "foo"},
+		Outcomes: Outcomes{Passed: 8, Failed: 2, Skipped: 1},
+		PassRate: 0.8,
+		P50:      5 * time.Second,
+		P90:      9 * time.Second,
+		P99:      10 * time.Second,
+	}, {
+		Test:     TestKey{Name: "bar"},
+		Outcomes: Outcomes{Passed: 1},
+		PassRate: 1,
+		P50:      time.Second,
+		P90:      time.Second,
+		P99:      time.Second,
+	}}, Stats(report))
+	assert.Nil(t, Stats(JSONReport{}))
+	merged := Merge("", Run{
+		Source: "a",
+		Report: JSONReport{Tests: []JSONTestReport{test("foo", StatusPass, start, time.Second)}},
+	}, Run{
+		Source: "b",
+		Report: JSONReport{Tests: []JSONTestReport{test("foo", StatusFail, start, time.Second)}},
+	})
+	assert.Equal(t, []TestStats{{
+		Test:     TestKey{Source: "a", Name: "foo"},
+		Outcomes: Outcomes{Passed: 1},
+		PassRate: 1,
+		P50:      time.Second,
+		P90:      time.Second,
+		P99:      time.Second,
+	}, {
+		Test:     TestKey{Source: "b", Name: "foo"},
+		Outcomes: Outcomes{Failed: 1},
+		P50:      time.Second,
+		P90:      time.Second,
+		P99:      time.Second,
+	}}, Stats(merged))
+}
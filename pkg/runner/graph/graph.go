@@ -0,0 +1,186 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/discovery"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Graph contains the dependencies between tests, tests are identified by their index.
+type Graph struct {
+	tests   []discovery.Test
+	deps    [][]int
+	missing [][]string
+}
+
+// Build builds the dependency graph of tests.
+// Dependencies on tests that are not part of the run (excluded by a regex or a selector for example)
+// are recorded as missing, see Missing. It fails if a selector is invalid or if dependencies form a cycle.
+func Build(tests ...discovery.Test) (*Graph, error) {
+	g := &Graph{
+		tests:   tests,
+		deps:    make([][]int, len(tests)),
+		missing: make([][]string, len(tests)),
+	}
+	for i, test := range tests {
+		if test.Test == nil {
+			continue
+		}
+		for _, dependency := range test.Test.Spec.DependsOn {
+			if dependency.Name == "" && dependency.Selector == "" {
+				continue
+			}
+			var selector labels.Selector
+			if dependency.Selector != "" {
+				parsed, err := labels.Parse(dependency.Selector)
+				if err != nil {
+					return nil, fmt.Errorf("test %q has an invalid dependency selector: %w", test.Test.Name, err)
+				}
+				selector = parsed
+			}
+			found := false
+			for j, other := range tests {
+				if j == i || other.Test == nil {
+					continue
+				}
+				if dependency.Name != "" && other.Test.Name != dependency.Name {
+					continue
+				}
+				if selector != nil && !selector.Matches(labels.Set(other.Test.Labels)) {
+					continue
+				}
+				found = true
+				if !slices.Contains(g.deps[i], j) {
+					g.deps[i] = append(g.deps[i], j)
+				}
+			}
+			if !found && dependency.Name != "" && !slices.Contains(g.missing[i], dependency.Name) {
+				g.missing[i] = append(g.missing[i], dependency.Name)
+			}
+		}
+	}
+	if cycle := g.cycle(); len(cycle) != 0 {
+		var names []string
+		for _, i := range cycle {
+			names = append(names, g.name(i))
+		}
+		return nil, fmt.Errorf("dependency cycle detected: %s", strings.Join(names, " -> "))
+	}
+	return g, nil
+}
+
+// HasDependencies returns true if at least one test depends on another test.
+func (g *Graph) HasDependencies() bool {
+	for _, deps := range g.deps {
+		if len(deps) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Dependencies returns the tests a test depends on.
+func (g *Graph) Dependencies(i int) []int {
+	return g.deps[i]
+}
+
+// Missing returns the names of the tests a test depends on that are not part of the run.
+func (g *Graph) Missing(i int) []string {
+	return g.missing[i]
+}
+
+// Waves groups tests so that every test runs after the tests it depends on,
+// tests in the same wave don't depend on each other and can run in parallel.
+// Tests keep their discovery order in a wave.
+// Waves only describe the depth of tests in the graph, a test doesn't wait on tests it doesn't depend on.
+func (g *Graph) Waves() [][]int {
+	levels := make([]int, len(g.tests))
+	var level func(int) int
+	level = func(i int) int {
+		if levels[i] == 0 {
+			levels[i] = 1
+			for _, dep := range g.deps[i] {
+				levels[i] = max(levels[i], level(dep)+1)
+			}
+		}
+		return levels[i]
+	}
+	var out [][]int
+	for i := range g.tests {
+		l := level(i)
+		for len(out) < l {
+			out = append(out, nil)
+		}
+		out[l-1] = append(out[l-1], i)
+	}
+	return out
+}
+
+// WriteDot writes the graph in DOT format, edges go from a test to the tests depending on it.
+func (g *Graph) WriteDot(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph chainsaw {\n")
+	for w, wave := range g.Waves() {
+		fmt.Fprintf(&b, "  // wave %d\n", w+1)
+		for _, i := range wave {
+			fmt.Fprintf(&b, "  t%d [label=%q];\n", i, g.name(i))
+		}
+	}
+	for i, deps := range g.deps {
+		for _, dep := range deps {
+			fmt.Fprintf(&b, "  t%d -> t%d;\n", dep, i)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (g *Graph) name(i int) string {
+	if g.tests[i].Test == nil {
+		return g.tests[i].BasePath
+	}
+	return g.tests[i].Test.Name
+}
+
+// cycle returns the tests forming a dependency cycle (the first test is repeated at the end), if any.
+func (g *Graph) cycle() []int {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(g.tests))
+	var path []int
+	var visit func(int) []int
+	visit = func(i int) []int {
+		state[i] = visiting
+		path = append(path, i)
+		for _, dep := range g.deps[i] {
+			switch state[dep] {
+			case visiting:
+				start := slices.Index(path, dep)
+				return append(slices.Clone(path[start:]), dep)
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+	for i := range g.tests {
+		if state[i] == unvisited {
+			if cycle := visit(i); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
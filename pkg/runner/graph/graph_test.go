@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/discovery"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func test(name string, labels map[string]string, deps ...v1alpha1.TestDependency) discovery.Test {
+	return discovery.Test{
+		BasePath: name,
+		Test: &v1alpha1.Test{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec: v1alpha1.TestSpec{
+				DependsOn: deps,
+			},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name    string
+		tests   []discovery.Test
+		deps    [][]int
+		missing [][]string
+		waves   [][]int
+		wantErr string
+	}{{
+		name: "no dependencies",
+		tests: []discovery.Test{
+			test("a", nil),
+			test("b", nil),
+		},
+		deps:  [][]int{nil, nil},
+		waves: [][]int{{0, 1}},
+	}, {
+		name: "by name and selector",
+		tests: []discovery.Test{
+			test("install", nil, v1alpha1.TestDependency{Selector: "tier=setup"}),
+			test("crds", map[string]string{"tier": "setup"}),
+			test("webhook", map[string]string{"tier": "setup"}),
+			test("upgrade", nil, v1alpha1.TestDependency{Name: "install"}),
+			test("standalone", nil),
+		},
+		deps:  [][]int{{1, 2}, nil, nil, {0}, nil},
+		waves: [][]int{{1, 2, 4}, {0}, {3}},
+	}, {
+		name: "unknown test",
+		tests: []discovery.Test{
+			test("a", nil, v1alpha1.TestDependency{Name: "missing"}),
+			test("b", nil, v1alpha1.TestDependency{Name: "a"}),
+		},
+		deps:    [][]int{nil, {0}},
+		missing: [][]string{{"missing"}, nil},
+		waves:   [][]int{{0}, {1}},
+	}, {
+		name: "invalid selector",
+		tests: []discovery.Test{
+			test("a", nil, v1alpha1.TestDependency{Selector: "=="}),
+		},
+		wantErr: `test "a" has an invalid dependency selector`,
+	}, {
+		name: "cycle",
+		tests: []discovery.Test{
+			test("a", nil, v1alpha1.TestDependency{Name: "b"}),
+			test("b", nil, v1alpha1.TestDependency{Name: "c"}),
+			test("c", nil, v1alpha1.TestDependency{Name: "a"}),
+		},
+		wantErr: "dependency cycle detected: a -> b -> c -> a",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Build(tt.tests...)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			for i := range tt.tests {
+				assert.Equal(t, tt.deps[i], got.Dependencies(i))
+				if tt.missing != nil {
+					assert.Equal(t, tt.missing[i], got.Missing(i))
+				} else {
+					assert.Nil(t, got.Missing(i))
+				}
+			}
+			assert.Equal(t, tt.waves, got.Waves())
+		})
+	}
+}
+
+func TestGraph_WriteDot(t *testing.T) {
+	g, err := Build(
+		test("a", nil),
+		test("b", nil, v1alpha1.TestDependency{Name: "a"}),
+	)
+	assert.NoError(t, err)
+	assert.True(t, g.HasDependencies())
+	var out bytes.Buffer
+	assert.NoError(t, g.WriteDot(&out))
+	assert.Equal(t, `digraph chainsaw {
+  // wave 1
+  t0 [label="a"];
+  // wave 2
+  t1 [label="b"];
+  t0 -> t1;
+}
+`, out.String())
+}
@@ -0,0 +1,305 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/clusters"
+	"go.uber.org/multierr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// Result is the outcome of the checks run against a cluster.
+type Result struct {
+	Cluster string
+	Version string
+	Err     error
+}
+
+func (r Result) Healthy() bool {
+	return r.Err == nil
+}
+
+type discoveryFactory = func(*rest.Config) (discovery.ServerGroupsInterface, discovery.ServerVersionInterface, error)
+
+func defaultDiscoveryFactory(config *rest.Config) (discovery.ServerGroupsInterface, discovery.ServerVersionInterface, error) {
+	client, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, client, nil
+}
+
+// Check runs the configured checks against a cluster registered in registry.
+func Check(ctx context.Context, registry clusters.Registry, name string, options v1alpha2.PreflightOptions) Result {
+	return check(ctx, registry, name, options, defaultDiscoveryFactory)
+}
+
+// CheckAll runs the configured checks against the given clusters in parallel.
+func CheckAll(ctx context.Context, registry clusters.Registry, names []string, options v1alpha2.PreflightOptions) map[string]Result {
+	return checkAll(ctx, registry, names, options, defaultDiscoveryFactory)
+}
+
+func checkAll(ctx context.Context, registry clusters.Registry, names []string, options v1alpha2.PreflightOptions, f discoveryFactory) map[string]Result {
+	results := make([]Result, len(names))
+	var wg sync.WaitGroup
+	for i := range names {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = check(ctx, registry, names[i], options, f)
+		}(i)
+	}
+	wg.Wait()
+	out := make(map[string]Result, len(results))
+	for _, result := range results {
+		out[result.Cluster] = result
+	}
+	return out
+}
+
+func check(ctx context.Context, registry clusters.Registry, name string, options v1alpha2.PreflightOptions, f discoveryFactory) Result {
+	result := Result{Cluster: name}
+	timeout := defaultTimeout
+	if options.Timeout != nil {
+		timeout = options.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cluster := registry.Lookup(name)
+	if cluster == nil {
+		result.Err = fmt.Errorf("cluster not found: %q", name)
+		return result
+	}
+	config, client, err := registry.Build(cluster)
+	if err != nil {
+		result.Err = fmt.Errorf("cluster is not reachable: %w", err)
+		return result
+	}
+	if config == nil || client == nil {
+		result.Err = errors.New("cluster is not reachable: no client available")
+		return result
+	}
+	groups, versions, err := f(config)
+	if err != nil {
+		result.Err = fmt.Errorf("cluster is not reachable: %w", err)
+		return result
+	}
+	version, err := versions.ServerVersion()
+	if err != nil {
+		result.Err = fmt.Errorf("cluster is not reachable: %w", err)
+		return result
+	}
+	result.Version = version.GitVersion
+	var errs []error
+	if len(options.APIGroups) != 0 {
+		errs = append(errs, checkAPIGroups(groups, options.APIGroups...))
+	}
+	for _, crd := range options.CRDs {
+		errs = append(errs, checkCRD(ctx, client, crd))
+	}
+	for _, permission := range options.Permissions {
+		errs = append(errs, checkPermission(ctx, client, permission))
+	}
+	result.Err = multierr.Combine(errs...)
+	return result
+}
+
+func checkAPIGroups(discovery discovery.ServerGroupsInterface, required ...string) error {
+	list, err := discovery.ServerGroups()
+	if err != nil {
+		return fmt.Errorf("failed to discover api groups: %w", err)
+	}
+	served := map[string]struct{}{}
+	for _, group := range list.Groups {
+		served[group.Name] = struct{}{}
+		for _, version := range group.Versions {
+			served[version.GroupVersion] = struct{}{}
+		}
+	}
+	var errs []error
+	for _, group := range required {
+		if _, ok := served[group]; !ok {
+			errs = append(errs, fmt.Errorf("api group not served: %s", group))
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+func checkCRD(ctx context.Context, c client.Client, name string) error {
+	var crd unstructured.Unstructured
+	crd.SetAPIVersion("apiextensions.k8s.io/v1")
+	crd.SetKind("CustomResourceDefinition")
+	if err := c.Get(ctx, client.ObjectKey{Name: name}, &crd); err != nil {
+		return fmt.Errorf("crd not available: %s: %w", name, err)
+	}
+	return nil
+}
+
+func checkPermission(ctx context.Context, c client.Client, permission v1alpha2.PreflightPermission) error {
+	var errs []error
+	for _, verb := range permission.Verbs {
+		var review unstructured.Unstructured
+		review.SetAPIVersion("authorization.k8s.io/v1")
+		review.SetKind("SelfSubjectAccessReview")
+		attributes := map[string]any{
+			"group":    permission.Group,
+			"resource": permission.Resource,
+			"verb":     verb,
+		}
+		if permission.Namespace != "" {
+			attributes["namespace"] = permission.Namespace
+		}
+		if err := unstructured.SetNestedMap(review.Object, attributes, "spec", "resourceAttributes"); err != nil {
+			return err
+		}
+		if err := c.Create(ctx, &review); err != nil {
+			errs = append(errs, fmt.Errorf("failed to review access to %s %s: %w", verb, resource(permission), err))
+			continue
+		}
+		if allowed, _, _ := unstructured.NestedBool(review.Object, "status", "allowed"); !allowed {
+			reason, _, _ := unstructured.NestedString(review.Object, "status", "reason")
+			err := fmt.Errorf("access denied: %s %s", verb, resource(permission))
+			if reason != "" {
+				err = fmt.Errorf("%w (%s)", err, reason)
+			}
+			errs = append(errs, err)
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+func resource(permission v1alpha2.PreflightPermission) string {
+	resource := permission.Resource
+	if permission.Group != "" {
+		resource += "." + permission.Group
+	}
+	if permission.Namespace != "" {
+		resource = permission.Namespace + "/" + resource
+	}
+	return resource
+}
+
+// Targets returns the names of the clusters a test refers to, the default cluster
+// is included unless the test selects another one.
+// Cluster names computed from expressions can't be known upfront and are ignored.
+func Targets(test *v1alpha1.Test) []string {
+	if test == nil {
+		return nil
+	}
+	targets := map[string]struct{}{}
+	if test.Spec.Cluster == nil {
+		targets[clusters.DefaultClient] = struct{}{}
+	}
+	add(targets, test.Spec.Cluster)
+	addRegistry(targets, test.Spec.Clusters)
+	addCatchFinally(targets, test.Spec.Catch...)
+	for _, step := range test.Spec.Steps {
+		add(targets, step.Cluster)
+		addRegistry(targets, step.Clusters)
+		for _, operation := range step.Try {
+			addOperation(targets, operation)
+		}
+		addCatchFinally(targets, step.Catch...)
+		addCatchFinally(targets, step.Finally...)
+		addCatchFinally(targets, step.Cleanup...)
+	}
+	out := make([]string, 0, len(targets))
+	for target := range targets {
+		out = append(out, target)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func add(targets map[string]struct{}, cluster *string) {
+	if cluster != nil && !strings.Contains(*cluster, "(") {
+		targets[*cluster] = struct{}{}
+	}
+}
+
+func addRegistry(targets map[string]struct{}, registry v1alpha1.Clusters) {
+	for _, cluster := range registry {
+		if cluster.DataClusterName != "" {
+			add(targets, &cluster.ControlClusterName)
+		}
+	}
+}
+
+func addAction(targets map[string]struct{}, action v1alpha1.ActionClusters, fanOut *v1alpha1.FanOut) {
+	add(targets, action.Cluster)
+	addRegistry(targets, action.Clusters)
+	if fanOut != nil {
+		add(targets, &fanOut.ControlCluster)
+	}
+}
+
+func addOperation(targets map[string]struct{}, operation v1alpha1.Operation) {
+	switch {
+	case operation.Apply != nil:
+		addAction(targets, operation.Apply.ActionClusters, operation.Apply.FanOut)
+	case operation.Assert != nil:
+		addAction(targets, operation.Assert.ActionClusters, operation.Assert.FanOut)
+	case operation.Command != nil:
+		addAction(targets, operation.Command.ActionClusters, nil)
+	case operation.Create != nil:
+		addAction(targets, operation.Create.ActionClusters, nil)
+	case operation.Delete != nil:
+		addAction(targets, operation.Delete.ActionClusters, operation.Delete.FanOut)
+	case operation.Describe != nil:
+		addAction(targets, operation.Describe.ActionClusters, nil)
+	case operation.Error != nil:
+		addAction(targets, operation.Error.ActionClusters, nil)
+	case operation.Events != nil:
+		addAction(targets, operation.Events.ActionClusters, nil)
+	case operation.Get != nil:
+		addAction(targets, operation.Get.ActionClusters, nil)
+	case operation.Patch != nil:
+		addAction(targets, operation.Patch.ActionClusters, operation.Patch.FanOut)
+	case operation.PodLogs != nil:
+		addAction(targets, operation.PodLogs.ActionClusters, nil)
+	case operation.Proxy != nil:
+		addAction(targets, operation.Proxy.ActionClusters, nil)
+	case operation.Script != nil:
+		addAction(targets, operation.Script.ActionClusters, nil)
+	case operation.Update != nil:
+		addAction(targets, operation.Update.ActionClusters, nil)
+	case operation.Wait != nil:
+		addAction(targets, operation.Wait.ActionClusters, nil)
+	}
+}
+
+func addCatchFinally(targets map[string]struct{}, operations ...v1alpha1.CatchFinally) {
+	for _, operation := range operations {
+		switch {
+		case operation.Command != nil:
+			addAction(targets, operation.Command.ActionClusters, nil)
+		case operation.Delete != nil:
+			addAction(targets, operation.Delete.ActionClusters, operation.Delete.FanOut)
+		case operation.Describe != nil:
+			addAction(targets, operation.Describe.ActionClusters, nil)
+		case operation.Events != nil:
+			addAction(targets, operation.Events.ActionClusters, nil)
+		case operation.Get != nil:
+			addAction(targets, operation.Get.ActionClusters, nil)
+		case operation.PodLogs != nil:
+			addAction(targets, operation.PodLogs.ActionClusters, nil)
+		case operation.Script != nil:
+			addAction(targets, operation.Script.ActionClusters, nil)
+		case operation.Wait != nil:
+			addAction(targets, operation.Wait.ActionClusters, nil)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/clusters"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func fakeDiscovery(config *rest.Config) (discovery.ServerGroupsInterface, discovery.ServerVersionInterface, error) {
+	d := &fakediscovery.FakeDiscovery{
+		Fake: &clienttesting.Fake{
+			Resources: []*metav1.APIResourceList{{
+				GroupVersion: "apps/v1",
+			}, {
+				GroupVersion: "v1",
+			}},
+		},
+		FakedServerVersion: &version.Info{GitVersion: "v1.30.0"},
+	}
+	return d, d, nil
+}
+
+func TestCheck(t *testing.T) {
+	cluster, err := clusters.NewClusterFromConfig(&rest.Config{})
+	assert.NoError(t, err)
+	allowed := func(allowed bool) func(context.Context, int, client.Object, ...client.CreateOption) error {
+		return func(_ context.Context, _ int, obj client.Object, _ ...client.CreateOption) error {
+			return unstructured.SetNestedField(obj.(*unstructured.Unstructured).Object, allowed, "status", "allowed")
+		}
+	}
+	tests := []struct {
+		name     string
+		cluster  string
+		client   *tclient.FakeClient
+		buildErr error
+		options  v1alpha2.PreflightOptions
+		wantErr  string
+	}{{
+		name:    "unknown cluster",
+		cluster: "unknown",
+		client:  &tclient.FakeClient{},
+		wantErr: `cluster not found: "unknown"`,
+	}, {
+		name:     "unreachable",
+		client:   &tclient.FakeClient{},
+		buildErr: errors.New("dial error"),
+		wantErr:  "cluster is not reachable: dial error",
+	}, {
+		name:   "healthy",
+		client: &tclient.FakeClient{},
+	}, {
+		name:   "api groups",
+		client: &tclient.FakeClient{},
+		options: v1alpha2.PreflightOptions{
+			APIGroups: []string{"apps", "apps/v1", "batch"},
+		},
+		wantErr: "api group not served: batch",
+	}, {
+		name: "crds",
+		client: &tclient.FakeClient{
+			GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				if key.Name == "foos.example.com" {
+					return nil
+				}
+				return errors.New("not found")
+			},
+		},
+		options: v1alpha2.PreflightOptions{
+			CRDs: []string{"foos.example.com", "bars.example.com"},
+		},
+		wantErr: "crd not available: bars.example.com: not found",
+	}, {
+		name: "permissions allowed",
+		client: &tclient.FakeClient{
+			CreateFn: allowed(true),
+		},
+		options: v1alpha2.PreflightOptions{
+			Permissions: []v1alpha2.PreflightPermission{{
+				Group:    "apps",
+				Resource: "deployments",
+				Verbs:    []string{"create", "delete"},
+			}},
+		},
+	}, {
+		name: "permissions denied",
+		client: &tclient.FakeClient{
+			CreateFn: allowed(false),
+		},
+		options: v1alpha2.PreflightOptions{
+			Permissions: []v1alpha2.PreflightPermission{{
+				Resource:  "configmaps",
+				Verbs:     []string{"create"},
+				Namespace: "default",
+			}},
+		},
+		wantErr: "access denied: create default/configmaps",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := clusters.NewRegistry(func(clusters.Cluster) (*rest.Config, client.Client, error) {
+				if tt.buildErr != nil {
+					return nil, nil, tt.buildErr
+				}
+				return &rest.Config{}, tt.client, nil
+			}).Register(clusters.DefaultClient, cluster)
+			got := check(context.TODO(), registry, tt.cluster, tt.options, fakeDiscovery)
+			assert.Equal(t, tt.cluster, got.Cluster)
+			if tt.wantErr != "" {
+				assert.EqualError(t, got.Err, tt.wantErr)
+				assert.False(t, got.Healthy())
+			} else {
+				assert.NoError(t, got.Err)
+				assert.True(t, got.Healthy())
+				assert.Equal(t, "v1.30.0", got.Version)
+			}
+		})
+	}
+}
+
+func TestCheckAll(t *testing.T) {
+	cluster, err := clusters.NewClusterFromConfig(&rest.Config{})
+	assert.NoError(t, err)
+	registry := clusters.NewRegistry(func(clusters.Cluster) (*rest.Config, client.Client, error) {
+		return &rest.Config{}, &tclient.FakeClient{}, nil
+	}).Register(clusters.DefaultClient, cluster).Register("other", cluster)
+	got := checkAll(context.TODO(), registry, []string{"", "other", "missing"}, v1alpha2.PreflightOptions{}, fakeDiscovery)
+	assert.Len(t, got, 3)
+	assert.True(t, got[""].Healthy())
+	assert.True(t, got["other"].Healthy())
+	assert.False(t, got["missing"].Healthy())
+}
+
+func TestTargets(t *testing.T) {
+	tests := []struct {
+		name string
+		test *v1alpha1.Test
+		want []string
+	}{{
+		name: "nil",
+	}, {
+		name: "default",
+		test: &v1alpha1.Test{},
+		want: []string{""},
+	}, {
+		name: "test cluster",
+		test: &v1alpha1.Test{
+			Spec: v1alpha1.TestSpec{
+				Cluster: ptr.To("foo"),
+			},
+		},
+		want: []string{"foo"},
+	}, {
+		name: "step clusters",
+		test: &v1alpha1.Test{
+			Spec: v1alpha1.TestSpec{
+				Steps: []v1alpha1.TestStep{{
+					TestStepSpec: v1alpha1.TestStepSpec{
+						Cluster: ptr.To("bar"),
+						Try: []v1alpha1.Operation{{
+							Apply: &v1alpha1.Apply{
+								ActionClusters: v1alpha1.ActionClusters{
+									Cluster: ptr.To("baz"),
+								},
+							},
+						}},
+					},
+				}},
+			},
+		},
+		want: []string{"", "bar", "baz"},
+	}, {
+		name: "fan out and catch",
+		test: &v1alpha1.Test{
+			Spec: v1alpha1.TestSpec{
+				Cluster: ptr.To("foo"),
+				Steps: []v1alpha1.TestStep{{
+					TestStepSpec: v1alpha1.TestStepSpec{
+						Try: []v1alpha1.Operation{{
+							Patch: &v1alpha1.Patch{
+								ActionFanOut: v1alpha1.ActionFanOut{
+									FanOut: &v1alpha1.FanOut{
+										ControlCluster: "control",
+									},
+								},
+							},
+						}},
+						Catch: []v1alpha1.CatchFinally{{
+							Events: &v1alpha1.Events{
+								ActionClusters: v1alpha1.ActionClusters{
+									Cluster: ptr.To("($cluster)"),
+								},
+							},
+						}, {
+							Script: &v1alpha1.Script{
+								ActionClusters: v1alpha1.ActionClusters{
+									Cluster: ptr.To("qux"),
+								},
+							},
+						}},
+					},
+				}},
+			},
+		},
+		want: []string{"control", "foo", "qux"},
+	}, {
+		name: "data cluster registry",
+		test: &v1alpha1.Test{
+			Spec: v1alpha1.TestSpec{
+				Cluster: ptr.To("data"),
+				Clusters: v1alpha1.Clusters{
+					"data": v1alpha1.Cluster{
+						DataClusterName:    "data",
+						ControlClusterName: "control",
+					},
+				},
+			},
+		},
+		want: []string{"control", "data"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Targets(tt.test)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
 	"github.com/kyverno/chainsaw/pkg/client"
 	"github.com/kyverno/chainsaw/pkg/engine"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/pkg/ext/output/color"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 )
@@ -24,10 +26,39 @@ type contextData struct {
 	clusters  v1alpha1.Clusters
 	dryRun    *bool
 	namespace *namespaceData
+	// scope owns the clusters, it is nil for operations as they run on every execution and retry
+	scope *clusterScope
+}
+
+// clusterScope collects the teardown functions of the clusters provided for a configuration, a test or a step.
+type clusterScope struct {
+	teardowns []func() error
+}
+
+func (s *clusterScope) cleanup(teardown func() error) {
+	s.teardowns = append(s.teardowns, teardown)
+}
+
+// teardown runs the teardown functions in reverse order.
+func (s *clusterScope) teardown(ctx context.Context) {
+	for i := len(s.teardowns) - 1; i >= 0; i-- {
+		if err := s.teardowns[i](); err != nil {
+			logging.Log(ctx, logging.Cleanup, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+		}
+	}
+	s.teardowns = nil
 }
 
 func setupContextData(ctx context.Context, tc engine.Context, data contextData) (engine.Context, *corev1.Namespace, error) {
-	tc = engine.WithClusters(ctx, tc, data.basePath, data.clusters)
+	var cleanup func(func() error)
+	if data.scope != nil {
+		cleanup = data.scope.cleanup
+	}
+	if _tc, err := engine.WithClusters(ctx, tc, data.basePath, data.clusters, cleanup); err != nil {
+		return tc, nil, err
+	} else {
+		tc = _tc
+	}
 	if data.dryRun != nil {
 		tc = tc.WithDryRun(ctx, *data.dryRun)
 	}
@@ -0,0 +1,47 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	enginecontext "github.com/kyverno/chainsaw/pkg/engine/context"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_clusterScope(t *testing.T) {
+	var scope clusterScope
+	var calls []string
+	scope.cleanup(func() error {
+		calls = append(calls, "a")
+		return nil
+	})
+	scope.cleanup(func() error {
+		calls = append(calls, "b")
+		return errors.New("dummy")
+	})
+	ctx := logging.IntoContext(context.TODO(), &tlogging.FakeLogger{})
+	scope.teardown(ctx)
+	assert.Equal(t, []string{"b", "a"}, calls)
+	// clusters are torn down once
+	scope.teardown(ctx)
+	assert.Equal(t, []string{"b", "a"}, calls)
+}
+
+func Test_setupContextData_ephemeralClusters(t *testing.T) {
+	clusters := v1alpha1.Clusters{
+		"ephemeral": v1alpha1.Cluster{Ephemeral: &v1alpha1.EphemeralCluster{}},
+	}
+	// operations don't own a scope and can't declare ephemeral clusters
+	_, _, err := setupContextData(context.TODO(), enginecontext.EmptyContext(), contextData{clusters: clusters})
+	assert.EqualError(t, err, `failed to create cluster "ephemeral": ephemeral clusters can only be declared in the configuration, a test or a step`)
+	var scope clusterScope
+	tc, _, err := setupContextData(context.TODO(), enginecontext.EmptyContext(), contextData{clusters: clusters, scope: &scope})
+	assert.NoError(t, err)
+	assert.NotNil(t, tc.Clusters().Lookup("ephemeral"))
+	assert.Len(t, scope.teardowns, 1)
+	scope.teardown(context.TODO())
+}
@@ -0,0 +1,101 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmespath-community/go-jmespath/pkg/binding"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine"
+	"github.com/kyverno/chainsaw/pkg/engine/clusters"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	opfanout "github.com/kyverno/chainsaw/pkg/engine/operations/fanout"
+	"github.com/kyverno/chainsaw/pkg/report"
+)
+
+type fanOutFactory = func(client.Client) operations.Operation
+
+func (p *stepProcessor) fanOutOperation(
+	ctx context.Context,
+	tc engine.Context,
+	fanOut v1alpha1.FanOut,
+	parent *report.OperationReport,
+	factory fanOutFactory,
+) (operations.Operation, error) {
+	names, err := fanOutClusters(ctx, tc.Bindings(), fanOut.Clusters...)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, errors.New("fan out didn't resolve any cluster")
+	}
+	control := tc.Cluster(fanOut.ControlCluster)
+	if control == nil {
+		return nil, fmt.Errorf("control cluster not found: %s", fanOut.ControlCluster)
+	}
+	var targets []opfanout.Target
+	for _, cluster := range names {
+		tc := tc.WithCluster(ctx, cluster, clusters.NewDataCluster(ctx, tc.DataClusters(), control, cluster)).WithCurrentCluster(ctx, cluster)
+		config, client, err := tc.CurrentClusterClient()
+		if err != nil {
+			return nil, fmt.Errorf("cluster %s: %w", cluster, err)
+		}
+		targets = append(targets, opfanout.Target{
+			Cluster:   cluster,
+			Config:    config,
+			Client:    client,
+			Operation: factory(client),
+		})
+	}
+	var concurrency int
+	if fanOut.Concurrency != nil {
+		concurrency = *fanOut.Concurrency
+	}
+	var reporter opfanout.Reporter
+	if p.report != nil && parent != nil {
+		reporter = func(cluster string, startTime time.Time, endTime time.Time, err error) {
+			operationReport := p.report.ForOperation(fanOutOperationName(parent.Name(), cluster), parent.Type())
+			operationReport.SetPhase(parent.Phase())
+			operationReport.SetCluster(cluster)
+			operationReport.SetStartTime(startTime)
+			if err != nil {
+				operationReport.SetErr(err)
+			}
+			operationReport.SetEndTime(endTime)
+		}
+	}
+	return opfanout.New(targets, concurrency, reporter), nil
+}
+
+func fanOutClusters(ctx context.Context, bindings binding.Bindings, expressions ...v1alpha1.Expression) ([]string, error) {
+	var names []string
+	seen := map[string]struct{}{}
+	for _, expression := range expressions {
+		value, err := expression.Value(ctx, bindings)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// fanOutOperationName returns the report name of an operation run on a fanned out cluster,
+// the parent name can end with a space when the operation has no file.
+func fanOutOperationName(parent string, cluster string) string {
+	return strings.TrimSpace(parent) + " @ " + cluster
+}
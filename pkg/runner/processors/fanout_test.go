@@ -0,0 +1,55 @@
+package processors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jmespath-community/go-jmespath/pkg/binding"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	apibindings "github.com/kyverno/chainsaw/pkg/engine/bindings"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_fanOutClusters(t *testing.T) {
+	bindings := apibindings.RegisterBinding(context.TODO(), binding.NewBindings(), "clusters", "b, c,,a")
+	tests := []struct {
+		name        string
+		expressions []v1alpha1.Expression
+		want        []string
+		wantErr     bool
+	}{{
+		name: "nil",
+	}, {
+		name:        "literals",
+		expressions: []v1alpha1.Expression{"a", "b"},
+		want:        []string{"a", "b"},
+	}, {
+		name:        "comma separated",
+		expressions: []v1alpha1.Expression{"a,b", " c "},
+		want:        []string{"a", "b", "c"},
+	}, {
+		name:        "expression",
+		expressions: []v1alpha1.Expression{"a", "($clusters)"},
+		want:        []string{"a", "b", "c"},
+	}, {
+		name:        "not a string",
+		expressions: []v1alpha1.Expression{"(`42`)"},
+		wantErr:     true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fanOutClusters(context.TODO(), bindings, tt.expressions...)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_fanOutOperationName(t *testing.T) {
+	assert.Equal(t, "Assert @ data", fanOutOperationName("Assert ", "data"))
+	assert.Equal(t, "Apply foo.yaml @ data", fanOutOperationName("Apply foo.yaml", "data"))
+}
@@ -0,0 +1,143 @@
+package processors
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/kyverno/chainsaw/pkg/testing"
+)
+
+// isolatedT runs a function on behalf of a parent test (an attempt of a test, suite setup or teardown steps).
+// Failures are recorded instead of being reported to the parent test, so that a failed attempt can be retried.
+// Logs are forwarded to the parent test, logged errors are kept to classify failures.
+type isolatedT struct {
+	testing.TTest
+	lock     sync.Mutex
+	failed   bool
+	skipped  bool
+	cleanups []func()
+	errs     []error
+}
+
+func newIsolatedT(t testing.TTest) *isolatedT {
+	return &isolatedT{TTest: t}
+}
+
+// run runs f and the registered cleanups the same way the testing package runs a test,
+// FailNow and SkipNow stop the function (or the cleanup) they are called from.
+func (t *isolatedT) run(f func()) {
+	call(f)
+	t.cleanup()
+}
+
+// cleanup runs the registered cleanups in reverse order.
+func (t *isolatedT) cleanup() {
+	for {
+		t.lock.Lock()
+		if len(t.cleanups) == 0 {
+			t.lock.Unlock()
+			return
+		}
+		cleanup := t.cleanups[len(t.cleanups)-1]
+		t.cleanups = t.cleanups[:len(t.cleanups)-1]
+		t.lock.Unlock()
+		call(cleanup)
+	}
+}
+
+func call(f func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	<-done
+}
+
+func (t *isolatedT) Errors() []error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.errs
+}
+
+func (t *isolatedT) RecordError(err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.errs = append(t.errs, err)
+}
+
+func (t *isolatedT) Cleanup(f func()) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.cleanups = append(t.cleanups, f)
+}
+
+func (t *isolatedT) Error(args ...any) {
+	t.Log(args...)
+	t.Fail()
+}
+
+func (t *isolatedT) Errorf(format string, args ...any) {
+	t.Logf(format, args...)
+	t.Fail()
+}
+
+func (t *isolatedT) Fail() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.failed = true
+}
+
+func (t *isolatedT) FailNow() {
+	t.Fail()
+	runtime.Goexit()
+}
+
+func (t *isolatedT) Failed() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.failed
+}
+
+func (t *isolatedT) Fatal(args ...any) {
+	t.Log(args...)
+	t.FailNow()
+}
+
+func (t *isolatedT) Fatalf(format string, args ...any) {
+	t.Logf(format, args...)
+	t.FailNow()
+}
+
+func (t *isolatedT) Log(args ...any) {
+	t.TTest.Helper()
+	t.TTest.Log(args...)
+}
+
+func (t *isolatedT) Logf(format string, args ...any) {
+	t.Log(fmt.Sprintf(format, args...))
+}
+
+func (t *isolatedT) Skip(args ...any) {
+	t.Log(args...)
+	t.SkipNow()
+}
+
+func (t *isolatedT) SkipNow() {
+	t.lock.Lock()
+	t.skipped = true
+	t.lock.Unlock()
+	runtime.Goexit()
+}
+
+func (t *isolatedT) Skipf(format string, args ...any) {
+	t.Logf(format, args...)
+	t.SkipNow()
+}
+
+func (t *isolatedT) Skipped() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.skipped
+}
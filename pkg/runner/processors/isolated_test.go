@@ -0,0 +1,42 @@
+package processors
+
+import (
+	"context"
+
+	"github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsolatedT(t *testing.T) {
+	parent := &testing.MockT{}
+	at := newIsolatedT(parent)
+	var order []string
+	at.run(func() {
+		at.Cleanup(func() {
+			order = append(order, "first")
+		})
+		at.Cleanup(func() {
+			order = append(order, "second")
+			at.FailNow()
+			order = append(order, "unreachable")
+		})
+		at.Log("failed")
+		at.RecordError(context.DeadlineExceeded)
+		at.FailNow()
+		order = append(order, "unreachable")
+	})
+	assert.True(t, at.Failed())
+	assert.False(t, at.Skipped())
+	assert.False(t, parent.Failed())
+	assert.Equal(t, []string{"second", "first"}, order)
+	assert.Equal(t, []error{context.DeadlineExceeded}, at.Errors())
+}
+
+func TestIsolatedT_Skip(t *testing.T) {
+	at := newIsolatedT(&testing.MockT{})
+	at.run(func() {
+		at.SkipNow()
+	})
+	assert.True(t, at.Skipped())
+	assert.False(t, at.Failed())
+}
@@ -1,6 +1,8 @@
 package processors
 
 import (
+	"context"
+
 	"github.com/kyverno/chainsaw/pkg/client"
 	"github.com/kyverno/chainsaw/pkg/engine/clusters"
 	"k8s.io/client-go/rest"
@@ -21,3 +23,11 @@ func (r registryMock) Lookup(string) clusters.Cluster {
 func (r registryMock) Build(clusters.Cluster) (*rest.Config, client.Client, error) {
 	return nil, r.client, nil
 }
+
+func (r registryMock) RegisterProvider(clusters.ClusterProvider) clusters.Registry {
+	return r
+}
+
+func (r registryMock) Provide(context.Context, clusters.ProviderRequest) (clusters.Cluster, error) {
+	return nil, nil
+}
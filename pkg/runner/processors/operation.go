@@ -7,9 +7,11 @@ import (
 	"github.com/kyverno/chainsaw/pkg/engine"
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
 	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/recorder"
 	"github.com/kyverno/chainsaw/pkg/engine/outputs"
 	"github.com/kyverno/chainsaw/pkg/report"
 	"github.com/kyverno/chainsaw/pkg/runner/failer"
+	"github.com/kyverno/chainsaw/pkg/testing"
 	"github.com/kyverno/pkg/ext/output/color"
 )
 
@@ -42,6 +44,11 @@ func (o operation) execute(ctx context.Context, tc engine.Context) outputs.Outpu
 		defer func() {
 			o.report.SetEndTime(time.Now())
 		}()
+		if o.report.Type().IsCollector() {
+			ctx = recorder.IntoContext(ctx, collectorRecorder{report: o.report})
+		} else {
+			ctx = recorder.IntoContext(ctx, o.report)
+		}
 	}
 	handleError := func(err error) {
 		if err != nil {
@@ -58,6 +65,9 @@ func (o operation) execute(ctx context.Context, tc engine.Context) outputs.Outpu
 	}
 	tc = tc.WithBinding(ctx, "operation", o.info)
 	operation, timeout, tc, err := o.operation(ctx, tc)
+	if o.report != nil {
+		o.report.SetCluster(tc.CurrentClusterName())
+	}
 	if err != nil {
 		handleError(err)
 	} else {
@@ -75,6 +85,12 @@ func (o operation) execute(ctx context.Context, tc engine.Context) outputs.Outpu
 			if o.report != nil {
 				o.report.SetErr(err)
 			}
+			// operations like assertions return their last errors when they time out
+			if err := ctx.Err(); err != nil {
+				if recorder, ok := testing.FromContext(ctx).(logging.ErrorRecorder); ok {
+					recorder.RecordError(err)
+				}
+			}
 			// we pass nil in the err argument so that it is not logged in the output
 			handleError(nil)
 		}
@@ -82,3 +98,17 @@ func (o operation) execute(ctx context.Context, tc engine.Context) outputs.Outpu
 	}
 	return nil
 }
+
+// collectorRecorder attaches the output of collector operations to the operation report,
+// errors (stderr) are kept as logs.
+type collectorRecorder struct {
+	report *report.OperationReport
+}
+
+func (r collectorRecorder) AddOutput(stream string, content string) {
+	if stream == recorder.Stdout {
+		r.report.AddAttachment(string(r.report.Type()), content)
+	} else {
+		r.report.AddOutput(stream, content)
+	}
+}
@@ -0,0 +1,59 @@
+package processors
+
+import (
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// resourceDescription describes the resources referenced by an operation in reports,
+// expressions are reported as written since they are only evaluated when the operation runs.
+func resourceDescription(ref v1alpha1.ActionResourceRef) string {
+	if ref.File != "" {
+		return string(ref.File)
+	}
+	if ref.Resource != nil {
+		return objectDescription(ref.Resource.GetAPIVersion(), ref.Resource.GetKind(), ref.Resource.GetNamespace(), ref.Resource.GetName(), "")
+	}
+	return ""
+}
+
+func checkDescription(ref v1alpha1.ActionCheckRef) string {
+	if ref.File != "" {
+		return string(ref.File)
+	}
+	if ref.Check != nil {
+		if object, ok := ref.Check.Value.(map[string]any); ok {
+			resource := unstructured.Unstructured{Object: object}
+			return objectDescription(resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName(), "")
+		}
+	}
+	return ""
+}
+
+func actionObjectDescription(object v1alpha1.ActionObject) string {
+	return objectDescription(string(object.APIVersion), string(object.Kind), string(object.Namespace), string(object.Name), string(object.Selector))
+}
+
+func objectDescription(apiVersion, kind, namespace, name, selector string) string {
+	var b strings.Builder
+	if apiVersion != "" {
+		b.WriteString(apiVersion)
+		b.WriteString("/")
+	}
+	b.WriteString(kind)
+	if namespace != "" || name != "" {
+		b.WriteString(" ")
+		if namespace != "" {
+			b.WriteString(namespace)
+			b.WriteString("/")
+		}
+		b.WriteString(name)
+	}
+	if selector != "" {
+		b.WriteString(" ")
+		b.WriteString(selector)
+	}
+	return b.String()
+}
@@ -10,13 +10,16 @@ import (
 	"github.com/jmespath-community/go-jmespath/pkg/binding"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
+	"github.com/kyverno/chainsaw/pkg/cleanup/journal"
+	"github.com/kyverno/chainsaw/pkg/client"
 	"github.com/kyverno/chainsaw/pkg/engine"
-	"github.com/kyverno/chainsaw/pkg/engine/kubectl"
+	"github.com/kyverno/chainsaw/pkg/engine/functions/tracectx"
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
 	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
 	"github.com/kyverno/chainsaw/pkg/engine/operations"
 	opapply "github.com/kyverno/chainsaw/pkg/engine/operations/apply"
 	opassert "github.com/kyverno/chainsaw/pkg/engine/operations/assert"
+	opcollect "github.com/kyverno/chainsaw/pkg/engine/operations/collect"
 	opcommand "github.com/kyverno/chainsaw/pkg/engine/operations/command"
 	opcreate "github.com/kyverno/chainsaw/pkg/engine/operations/create"
 	opdelete "github.com/kyverno/chainsaw/pkg/engine/operations/delete"
@@ -25,6 +28,7 @@ import (
 	opscript "github.com/kyverno/chainsaw/pkg/engine/operations/script"
 	opsleep "github.com/kyverno/chainsaw/pkg/engine/operations/sleep"
 	opupdate "github.com/kyverno/chainsaw/pkg/engine/operations/update"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
 	"github.com/kyverno/chainsaw/pkg/loaders/resource"
 	"github.com/kyverno/chainsaw/pkg/report"
 	"github.com/kyverno/chainsaw/pkg/runner/failer"
@@ -33,10 +37,14 @@ import (
 	"github.com/kyverno/pkg/ext/output/color"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
 )
 
+// errNoCluster is returned by collectors when there is no cluster to collect from (--no-cluster for example).
+var errNoCluster = errors.New("no cluster configured")
+
 type StepProcessor interface {
-	Run(context.Context, namespacer.Namespacer, engine.Context)
+	Run(context.Context, namespacer.Namespacer, engine.Context) outputs.Outputs
 }
 
 func NewStepProcessor(
@@ -44,6 +52,7 @@ func NewStepProcessor(
 	basePath string,
 	report *report.StepReport,
 	delayBeforeCleanup *time.Duration,
+	forceFinalize *time.Duration,
 	terminationGracePeriod *metav1.Duration,
 	timeouts v1alpha1.DefaultTimeouts,
 	deletionPropagationPolicy metav1.DeletionPropagation,
@@ -69,6 +78,7 @@ func NewStepProcessor(
 		basePath:                  basePath,
 		report:                    report,
 		delayBeforeCleanup:        delayBeforeCleanup,
+		forceFinalize:             forceFinalize,
 		terminationGracePeriod:    terminationGracePeriod,
 		timeouts:                  timeouts,
 		deletionPropagationPolicy: deletionPropagationPolicy,
@@ -83,6 +93,7 @@ type stepProcessor struct {
 	step                      v1alpha1.TestStep
 	report                    *report.StepReport
 	delayBeforeCleanup        *time.Duration
+	forceFinalize             *time.Duration
 	terminationGracePeriod    *metav1.Duration
 	timeouts                  v1alpha1.DefaultTimeouts
 	deletionPropagationPolicy metav1.DeletionPropagation
@@ -91,20 +102,27 @@ type stepProcessor struct {
 	catch                     []v1alpha1.CatchFinally
 }
 
-func (p *stepProcessor) Run(ctx context.Context, namespacer namespacer.Namespacer, tc engine.Context) {
+func (p *stepProcessor) Run(ctx context.Context, namespacer namespacer.Namespacer, tc engine.Context) outputs.Outputs {
 	t := testing.FromContext(ctx)
 	if p.report != nil {
 		p.report.SetStartTime(time.Now())
 		t.Cleanup(func() {
 			p.report.SetEndTime(time.Now())
 		})
+		ctx = tracectx.IntoContext(ctx, p.report)
 	}
 	logger := logging.FromContext(ctx)
+	// clusters are torn down once the resources they contain have been cleaned up
+	var clusters clusterScope
+	t.Cleanup(func() {
+		clusters.teardown(ctx)
+	})
 	tc, _, err := setupContextData(ctx, tc, contextData{
 		basePath: p.basePath,
 		bindings: p.step.Bindings,
 		cluster:  p.step.Cluster,
 		clusters: p.step.Clusters,
+		scope:    &clusters,
 	})
 	if err != nil {
 		logging.Log(ctx, logging.Internal, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
@@ -113,7 +131,7 @@ func (p *stepProcessor) Run(ctx context.Context, namespacer namespacer.Namespace
 		}
 		failer.FailNow(ctx)
 	}
-	cleaner := cleaner.New(p.timeouts.Cleanup.Duration, p.delayBeforeCleanup, p.deletionPropagationPolicy)
+	cleaner := cleaner.New(p.timeouts.Cleanup.Duration, p.delayBeforeCleanup, p.deletionPropagationPolicy, p.forceFinalize, journal.FromContext(ctx))
 	t.Cleanup(func() {
 		if !cleaner.Empty() || len(p.step.Cleanup) != 0 {
 			logger.Log(logging.Cleanup, logging.BeginStatus, color.BoldFgCyan)
@@ -128,7 +146,7 @@ func (p *stepProcessor) Run(ctx context.Context, namespacer namespacer.Namespace
 				failer.Fail(ctx)
 			}
 			for i, operation := range p.step.Cleanup {
-				operations, err := p.finallyOperation(i, namespacer, tc.Bindings(), operation)
+				operations, err := p.finallyOperation(i, namespacer, tc.Bindings(), operation, report.PhaseCleanup)
 				if err != nil {
 					logger.Log(logging.Cleanup, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
 					if p.report != nil {
@@ -149,7 +167,7 @@ func (p *stepProcessor) Run(ctx context.Context, namespacer namespacer.Namespace
 				logger.Log(logging.Finally, logging.EndStatus, color.BoldFgCyan)
 			}()
 			for i, operation := range p.step.Finally {
-				operations, err := p.finallyOperation(i, namespacer, tc.Bindings(), operation)
+				operations, err := p.finallyOperation(i, namespacer, tc.Bindings(), operation, report.PhaseFinally)
 				if err != nil {
 					logger.Log(logging.Finally, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
 					if p.report != nil {
@@ -205,6 +223,18 @@ func (p *stepProcessor) Run(ctx context.Context, namespacer namespacer.Namespace
 			}
 		}
 	}
+	if len(p.step.Outputs) == 0 {
+		return nil
+	}
+	results, err := outputs.Process(ctx, tc.Bindings(), nil, p.step.Outputs...)
+	if err != nil {
+		if p.report != nil {
+			p.report.SetErr(err)
+		}
+		logger.Log(logging.Try, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+		failer.FailNow(ctx)
+	}
+	return results
 }
 
 func (p *stepProcessor) tryOperation(id int, namespacer namespacer.Namespacer, bindings binding.Bindings, handler v1alpha1.Operation, cleaner cleaner.CleanerCollector) ([]operation, error) {
@@ -251,19 +281,7 @@ func (p *stepProcessor) tryOperation(id int, namespacer namespacer.Namespacer, b
 		}
 		register(loaded...)
 	} else if handler.Events != nil {
-		get := v1alpha1.Get{
-			ActionClusters: handler.Events.ActionClusters,
-			ActionFormat:   handler.Events.ActionFormat,
-			ActionTimeout:  handler.Events.ActionTimeout,
-			ActionObject: v1alpha1.ActionObject{
-				ObjectType: v1alpha1.ObjectType{
-					APIVersion: "v1",
-					Kind:       "Event",
-				},
-				ActionObjectSelector: handler.Events.ActionObjectSelector,
-			},
-		}
-		register(p.getOperation(id+1, namespacer, get))
+		register(p.eventsOperation(id+1, namespacer, *handler.Events))
 	} else if handler.Get != nil {
 		register(p.getOperation(id+1, namespacer, *handler.Get))
 	} else if handler.Patch != nil {
@@ -299,25 +317,16 @@ func (p *stepProcessor) catchOperation(id int, namespacer namespacer.Namespacer,
 	register := func(o ...operation) {
 		for _, o := range o {
 			o.continueOnError = true
+			if o.report != nil {
+				o.report.SetPhase(report.PhaseCatch)
+			}
 			ops = append(ops, o)
 		}
 	}
 	if handler.PodLogs != nil {
 		register(p.logsOperation(id+1, namespacer, *handler.PodLogs))
 	} else if handler.Events != nil {
-		get := v1alpha1.Get{
-			ActionClusters: handler.Events.ActionClusters,
-			ActionFormat:   handler.Events.ActionFormat,
-			ActionTimeout:  handler.Events.ActionTimeout,
-			ActionObject: v1alpha1.ActionObject{
-				ObjectType: v1alpha1.ObjectType{
-					APIVersion: "v1",
-					Kind:       "Event",
-				},
-				ActionObjectSelector: handler.Events.ActionObjectSelector,
-			},
-		}
-		register(p.getOperation(id+1, namespacer, get))
+		register(p.eventsOperation(id+1, namespacer, *handler.Events))
 	} else if handler.Describe != nil {
 		register(p.describeOperation(id+1, namespacer, *handler.Describe))
 	} else if handler.Get != nil {
@@ -342,30 +351,21 @@ func (p *stepProcessor) catchOperation(id int, namespacer namespacer.Namespacer,
 	return ops, nil
 }
 
-func (p *stepProcessor) finallyOperation(id int, namespacer namespacer.Namespacer, bindings binding.Bindings, handler v1alpha1.CatchFinally) ([]operation, error) {
+func (p *stepProcessor) finallyOperation(id int, namespacer namespacer.Namespacer, bindings binding.Bindings, handler v1alpha1.CatchFinally, phase report.Phase) ([]operation, error) {
 	var ops []operation
 	register := func(o ...operation) {
 		for _, o := range o {
 			o.continueOnError = true
+			if o.report != nil {
+				o.report.SetPhase(phase)
+			}
 			ops = append(ops, o)
 		}
 	}
 	if handler.PodLogs != nil {
 		register(p.logsOperation(id+1, namespacer, *handler.PodLogs))
 	} else if handler.Events != nil {
-		get := v1alpha1.Get{
-			ActionClusters: handler.Events.ActionClusters,
-			ActionFormat:   handler.Events.ActionFormat,
-			ActionTimeout:  handler.Events.ActionTimeout,
-			ActionObject: v1alpha1.ActionObject{
-				ObjectType: v1alpha1.ObjectType{
-					APIVersion: "v1",
-					Kind:       "Event",
-				},
-				ActionObjectSelector: handler.Events.ActionObjectSelector,
-			},
-		}
-		register(p.getOperation(id+1, namespacer, get))
+		register(p.eventsOperation(id+1, namespacer, *handler.Events))
 	} else if handler.Describe != nil {
 		register(p.describeOperation(id+1, namespacer, *handler.Describe))
 	} else if handler.Get != nil {
@@ -394,6 +394,7 @@ func (p *stepProcessor) applyOperation(id int, namespacer namespacer.Namespacer,
 	var operationReport *report.OperationReport
 	if p.report != nil {
 		operationReport = p.report.ForOperation("Apply "+string(op.File), report.OperationTypeApply)
+		operationReport.SetResource(resourceDescription(op.ActionResourceRef))
 	}
 	resources, err := p.fileRefOrResource(context.TODO(), op.ActionResourceRef, bindings)
 	if err != nil {
@@ -423,10 +424,8 @@ func (p *stepProcessor) applyOperation(id int, namespacer namespacer.Namespacer,
 				}); err != nil {
 					return nil, nil, tc, err
 				} else {
-					if _, client, err := tc.CurrentClusterClient(); err != nil {
-						return nil, nil, tc, err
-					} else {
-						op := opapply.New(
+					factory := func(client client.Client) operations.Operation {
+						return opapply.New(
 							client,
 							resource,
 							namespacer,
@@ -435,7 +434,15 @@ func (p *stepProcessor) applyOperation(id int, namespacer namespacer.Namespacer,
 							op.Expect,
 							op.Outputs,
 						)
-						return op, timeout, tc, nil
+					}
+					if op.FanOut != nil {
+						fanOut, err := p.fanOutOperation(ctx, tc, *op.FanOut, operationReport, factory)
+						return fanOut, timeout, tc, err
+					}
+					if _, client, err := tc.CurrentClusterClient(); err != nil {
+						return nil, nil, tc, err
+					} else {
+						return factory(client), timeout, tc, nil
 					}
 				}
 			},
@@ -454,6 +461,7 @@ func (p *stepProcessor) assertOperation(id int, namespacer namespacer.Namespacer
 	var operationReport *report.OperationReport
 	if p.report != nil {
 		operationReport = p.report.ForOperation("Assert ", report.OperationTypeAssert)
+		operationReport.SetResource(checkDescription(op.ActionCheckRef))
 	}
 	template := p.getTemplating(op.Template)
 	for i := range resources {
@@ -473,16 +481,24 @@ func (p *stepProcessor) assertOperation(id int, namespacer namespacer.Namespacer
 					clusters: op.Clusters,
 				}); err != nil {
 					return nil, nil, tc, err
-				} else if _, client, err := tc.CurrentClusterClient(); err != nil {
-					return nil, nil, tc, err
 				} else {
-					op := opassert.New(
-						client,
-						resource,
-						namespacer,
-						template,
-					)
-					return op, timeout, tc, nil
+					factory := func(client client.Client) operations.Operation {
+						return opassert.New(
+							client,
+							resource,
+							namespacer,
+							template,
+						)
+					}
+					if op.FanOut != nil {
+						fanOut, err := p.fanOutOperation(ctx, tc, *op.FanOut, operationReport, factory)
+						return fanOut, timeout, tc, err
+					}
+					if _, client, err := tc.CurrentClusterClient(); err != nil {
+						return nil, nil, tc, err
+					} else {
+						return factory(client), timeout, tc, nil
+					}
 				}
 			},
 			operationReport,
@@ -539,6 +555,7 @@ func (p *stepProcessor) createOperation(id int, namespacer namespacer.Namespacer
 	var operationReport *report.OperationReport
 	if p.report != nil {
 		operationReport = p.report.ForOperation("Create ", report.OperationTypeCreate)
+		operationReport.SetResource(resourceDescription(op.ActionResourceRef))
 	}
 	template := p.getTemplating(op.Template)
 	for i := range resources {
@@ -606,6 +623,7 @@ func (p *stepProcessor) deleteOperation(id int, namespacer namespacer.Namespacer
 	var operationReport *report.OperationReport
 	if p.report != nil {
 		operationReport = p.report.ForOperation("Delete ", report.OperationTypeDelete)
+		operationReport.SetResource(resourceDescription(ref))
 	}
 	deletionPropagationPolicy := p.getDeletionPropagationPolicy(op.DeletionPropagationPolicy)
 	template := p.getTemplating(op.Template)
@@ -626,18 +644,26 @@ func (p *stepProcessor) deleteOperation(id int, namespacer namespacer.Namespacer
 					clusters: op.Clusters,
 				}); err != nil {
 					return nil, nil, tc, err
-				} else if _, client, err := tc.CurrentClusterClient(); err != nil {
-					return nil, nil, tc, err
 				} else {
-					op := opdelete.New(
-						client,
-						resource,
-						namespacer,
-						template,
-						deletionPropagationPolicy,
-						op.Expect...,
-					)
-					return op, timeout, tc, nil
+					factory := func(client client.Client) operations.Operation {
+						return opdelete.New(
+							client,
+							resource,
+							namespacer,
+							template,
+							deletionPropagationPolicy,
+							op.Expect...,
+						)
+					}
+					if op.FanOut != nil {
+						fanOut, err := p.fanOutOperation(ctx, tc, *op.FanOut, operationReport, factory)
+						return fanOut, timeout, tc, err
+					}
+					if _, client, err := tc.CurrentClusterClient(); err != nil {
+						return nil, nil, tc, err
+					} else {
+						return factory(client), timeout, tc, nil
+					}
 				}
 			},
 			operationReport,
@@ -649,7 +675,8 @@ func (p *stepProcessor) deleteOperation(id int, namespacer namespacer.Namespacer
 func (p *stepProcessor) describeOperation(id int, namespacer namespacer.Namespacer, op v1alpha1.Describe) operation {
 	var operationReport *report.OperationReport
 	if p.report != nil {
-		operationReport = p.report.ForOperation("Describe ", report.OperationTypeCommand)
+		operationReport = p.report.ForOperation("Describe ", report.OperationTypeDescribe)
+		operationReport.SetResource(actionObjectDescription(op.ActionObject))
 	}
 	ns := ""
 	if namespacer != nil {
@@ -669,25 +696,12 @@ func (p *stepProcessor) describeOperation(id int, namespacer namespacer.Namespac
 				clusters: op.Clusters,
 			}); err != nil {
 				return nil, nil, tc, err
-			} else if config, client, err := tc.CurrentClusterClient(); err != nil {
+			} else if _, client, err := tc.CurrentClusterClient(); err != nil {
 				return nil, nil, tc, err
+			} else if client == nil {
+				return nil, nil, tc, errNoCluster
 			} else {
-				entrypoint, args, err := kubectl.Describe(ctx, client, tc.Bindings(), &op)
-				if err != nil {
-					return nil, nil, tc, err
-				}
-				op := opcommand.New(
-					v1alpha1.Command{
-						ActionClusters: op.ActionClusters,
-						ActionTimeout:  op.ActionTimeout,
-						Entrypoint:     entrypoint,
-						Args:           args,
-					},
-					p.basePath,
-					ns,
-					config,
-				)
-				return op, timeout, tc, nil
+				return opcollect.NewDescribe(client, ns, op), timeout, tc, nil
 			}
 		},
 		operationReport,
@@ -702,7 +716,8 @@ func (p *stepProcessor) errorOperation(id int, namespacer namespacer.Namespacer,
 	var ops []operation
 	var operationReport *report.OperationReport
 	if p.report != nil {
-		operationReport = p.report.ForOperation("Error ", report.OperationTypeCommand)
+		operationReport = p.report.ForOperation("Error ", report.OperationTypeError)
+		operationReport.SetResource(checkDescription(op.ActionCheckRef))
 	}
 	template := p.getTemplating(op.Template)
 	for i := range resources {
@@ -740,10 +755,47 @@ func (p *stepProcessor) errorOperation(id int, namespacer namespacer.Namespacer,
 	return ops, nil
 }
 
+func (p *stepProcessor) eventsOperation(id int, namespacer namespacer.Namespacer, op v1alpha1.Events) operation {
+	var operationReport *report.OperationReport
+	if p.report != nil {
+		operationReport = p.report.ForOperation("Events ", report.OperationTypeEvents)
+		operationReport.SetResource(objectDescription("v1", "Event", string(op.Namespace), string(op.Name), string(op.Selector)))
+	}
+	ns := ""
+	if namespacer != nil {
+		ns = namespacer.GetNamespace()
+	}
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		false,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout := timeout.Get(op.Timeout, p.timeouts.Exec.Duration)
+			if tc, _, err := setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
+				bindings: nil,
+				cluster:  op.Cluster,
+				clusters: op.Clusters,
+			}); err != nil {
+				return nil, nil, tc, err
+			} else if _, client, err := tc.CurrentClusterClient(); err != nil {
+				return nil, nil, tc, err
+			} else if client == nil {
+				return nil, nil, tc, errNoCluster
+			} else {
+				return opcollect.NewEvents(client, ns, op), timeout, tc, nil
+			}
+		},
+		operationReport,
+	)
+}
+
 func (p *stepProcessor) getOperation(id int, namespacer namespacer.Namespacer, op v1alpha1.Get) operation {
 	var operationReport *report.OperationReport
 	if p.report != nil {
-		operationReport = p.report.ForOperation("Get ", report.OperationTypeCommand)
+		operationReport = p.report.ForOperation("Get ", report.OperationTypeGet)
+		operationReport.SetResource(actionObjectDescription(op.ActionObject))
 	}
 	ns := ""
 	if namespacer != nil {
@@ -763,25 +815,12 @@ func (p *stepProcessor) getOperation(id int, namespacer namespacer.Namespacer, o
 				clusters: op.Clusters,
 			}); err != nil {
 				return nil, nil, tc, err
-			} else if config, client, err := tc.CurrentClusterClient(); err != nil {
+			} else if _, client, err := tc.CurrentClusterClient(); err != nil {
 				return nil, nil, tc, err
+			} else if client == nil {
+				return nil, nil, tc, errNoCluster
 			} else {
-				entrypoint, args, err := kubectl.Get(ctx, client, tc.Bindings(), &op)
-				if err != nil {
-					return nil, nil, tc, err
-				}
-				op := opcommand.New(
-					v1alpha1.Command{
-						ActionClusters: op.ActionClusters,
-						ActionTimeout:  op.ActionTimeout,
-						Entrypoint:     entrypoint,
-						Args:           args,
-					},
-					p.basePath,
-					ns,
-					config,
-				)
-				return op, timeout, tc, nil
+				return opcollect.NewGet(client, ns, op), timeout, tc, nil
 			}
 		},
 		operationReport,
@@ -791,7 +830,8 @@ func (p *stepProcessor) getOperation(id int, namespacer namespacer.Namespacer, o
 func (p *stepProcessor) logsOperation(id int, namespacer namespacer.Namespacer, op v1alpha1.PodLogs) operation {
 	var operationReport *report.OperationReport
 	if p.report != nil {
-		operationReport = p.report.ForOperation("Logs ", report.OperationTypeCommand)
+		operationReport = p.report.ForOperation("Logs ", report.OperationTypePodLogs)
+		operationReport.SetResource(objectDescription("v1", "Pod", string(op.Namespace), string(op.Name), string(op.Selector)))
 	}
 	ns := ""
 	if namespacer != nil {
@@ -813,23 +853,12 @@ func (p *stepProcessor) logsOperation(id int, namespacer namespacer.Namespacer,
 				return nil, nil, tc, err
 			} else if config, _, err := tc.CurrentClusterClient(); err != nil {
 				return nil, nil, tc, err
+			} else if config == nil {
+				return nil, nil, tc, errNoCluster
+			} else if clientset, err := kubernetes.NewForConfig(config); err != nil {
+				return nil, nil, tc, err
 			} else {
-				entrypoint, args, err := kubectl.Logs(ctx, tc.Bindings(), &op)
-				if err != nil {
-					return nil, nil, tc, err
-				}
-				op := opcommand.New(
-					v1alpha1.Command{
-						ActionClusters: op.ActionClusters,
-						ActionTimeout:  op.ActionTimeout,
-						Entrypoint:     entrypoint,
-						Args:           args,
-					},
-					p.basePath,
-					ns,
-					config,
-				)
-				return op, timeout, tc, nil
+				return opcollect.NewLogs(clientset, ns, op), timeout, tc, nil
 			}
 		},
 		operationReport,
@@ -844,7 +873,8 @@ func (p *stepProcessor) patchOperation(id int, namespacer namespacer.Namespacer,
 	var ops []operation
 	var operationReport *report.OperationReport
 	if p.report != nil {
-		operationReport = p.report.ForOperation("Patch ", report.OperationTypeCreate)
+		operationReport = p.report.ForOperation("Patch ", report.OperationTypePatch)
+		operationReport.SetResource(resourceDescription(op.ActionResourceRef))
 	}
 	template := p.getTemplating(op.Template)
 	for i := range resources {
@@ -868,18 +898,26 @@ func (p *stepProcessor) patchOperation(id int, namespacer namespacer.Namespacer,
 					dryRun:   op.DryRun,
 				}); err != nil {
 					return nil, nil, tc, err
-				} else if _, client, err := tc.CurrentClusterClient(); err != nil {
-					return nil, nil, tc, err
 				} else {
-					op := oppatch.New(
-						client,
-						resource,
-						namespacer,
-						template,
-						op.Expect,
-						op.Outputs,
-					)
-					return op, timeout, tc, nil
+					factory := func(client client.Client) operations.Operation {
+						return oppatch.New(
+							client,
+							resource,
+							namespacer,
+							template,
+							op.Expect,
+							op.Outputs,
+						)
+					}
+					if op.FanOut != nil {
+						fanOut, err := p.fanOutOperation(ctx, tc, *op.FanOut, operationReport, factory)
+						return fanOut, timeout, tc, err
+					}
+					if _, client, err := tc.CurrentClusterClient(); err != nil {
+						return nil, nil, tc, err
+					} else {
+						return factory(client), timeout, tc, nil
+					}
 				}
 			},
 			operationReport,
@@ -891,7 +929,7 @@ func (p *stepProcessor) patchOperation(id int, namespacer namespacer.Namespacer,
 func (p *stepProcessor) proxyOperation(id int, namespacer namespacer.Namespacer, op v1alpha1.Proxy) operation {
 	var operationReport *report.OperationReport
 	if p.report != nil {
-		operationReport = p.report.ForOperation("Proxy ", report.OperationTypeCommand)
+		operationReport = p.report.ForOperation("Proxy ", report.OperationTypeProxy)
 	}
 	ns := ""
 	if namespacer != nil {
@@ -913,23 +951,12 @@ func (p *stepProcessor) proxyOperation(id int, namespacer namespacer.Namespacer,
 				return nil, nil, tc, err
 			} else if config, client, err := tc.CurrentClusterClient(); err != nil {
 				return nil, nil, tc, err
+			} else if config == nil || client == nil {
+				return nil, nil, tc, errNoCluster
+			} else if clientset, err := kubernetes.NewForConfig(config); err != nil {
+				return nil, nil, tc, err
 			} else {
-				entrypoint, args, err := kubectl.Proxy(ctx, client, tc.Bindings(), &op)
-				if err != nil {
-					return nil, nil, tc, err
-				}
-				op := opcommand.New(
-					v1alpha1.Command{
-						ActionClusters: op.ActionClusters,
-						ActionTimeout:  op.ActionTimeout,
-						Entrypoint:     entrypoint,
-						Args:           args,
-					},
-					p.basePath,
-					ns,
-					config,
-				)
-				return op, timeout, tc, nil
+				return opcollect.NewProxy(client, clientset.CoreV1().RESTClient(), ns, op), timeout, tc, nil
 			}
 		},
 		operationReport,
@@ -1000,7 +1027,8 @@ func (p *stepProcessor) updateOperation(id int, namespacer namespacer.Namespacer
 	var ops []operation
 	var operationReport *report.OperationReport
 	if p.report != nil {
-		operationReport = p.report.ForOperation("Update ", report.OperationTypeCreate)
+		operationReport = p.report.ForOperation("Update ", report.OperationTypeUpdate)
+		operationReport.SetResource(resourceDescription(op.ActionResourceRef))
 	}
 	template := p.getTemplating(op.Template)
 	for i := range resources {
@@ -1047,7 +1075,8 @@ func (p *stepProcessor) updateOperation(id int, namespacer namespacer.Namespacer
 func (p *stepProcessor) waitOperation(id int, namespacer namespacer.Namespacer, op v1alpha1.Wait) operation {
 	var operationReport *report.OperationReport
 	if p.report != nil {
-		operationReport = p.report.ForOperation("Wait ", report.OperationTypeCommand)
+		operationReport = p.report.ForOperation("Wait ", report.OperationTypeWait)
+		operationReport.SetResource(actionObjectDescription(op.ActionObject))
 	}
 	ns := ""
 	if namespacer != nil {
@@ -1059,7 +1088,7 @@ func (p *stepProcessor) waitOperation(id int, namespacer namespacer.Namespacer,
 		},
 		false,
 		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
-			// make sure timeout is set to populate the command flag
+			// make sure timeout is set, the operation waits until it expires
 			op.Timeout = &metav1.Duration{Duration: *timeout.Get(op.Timeout, p.timeouts.Exec.Duration)}
 			// shift operation timeout
 			timeout := op.Timeout.Duration + 30*time.Second
@@ -1070,25 +1099,12 @@ func (p *stepProcessor) waitOperation(id int, namespacer namespacer.Namespacer,
 				clusters: op.Clusters,
 			}); err != nil {
 				return nil, nil, tc, err
-			} else if config, client, err := tc.CurrentClusterClient(); err != nil {
+			} else if _, client, err := tc.CurrentClusterClient(); err != nil {
 				return nil, nil, tc, err
+			} else if client == nil {
+				return nil, nil, tc, errNoCluster
 			} else {
-				entrypoint, args, err := kubectl.Wait(ctx, client, tc.Bindings(), &op)
-				if err != nil {
-					return nil, nil, tc, err
-				}
-				op := opcommand.New(
-					v1alpha1.Command{
-						ActionClusters: op.ActionClusters,
-						ActionTimeout:  op.ActionTimeout,
-						Entrypoint:     entrypoint,
-						Args:           args,
-					},
-					p.basePath,
-					ns,
-					config,
-				)
-				return op, &timeout, tc, nil
+				return opcollect.NewWait(client, ns, op), &timeout, tc, nil
 			}
 		},
 		operationReport,
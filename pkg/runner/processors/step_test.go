@@ -805,6 +805,7 @@ func TestStepProcessor_Run(t *testing.T) {
 				tc.basePath,
 				tc.stepReport,
 				nil,
+				nil,
 				tc.terminationGracePeriod,
 				config.Spec.Timeouts,
 				config.Spec.Deletion.Propagation,
@@ -825,3 +826,87 @@ func TestStepProcessor_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestStepProcessor_RunOutputs(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	assert.NoError(t, err)
+	step := v1alpha1.TestStep{
+		TestStepSpec: v1alpha1.TestStepSpec{
+			Bindings: []v1alpha1.Binding{{
+				Name:  "foo",
+				Value: v1alpha1.Any{Value: "bar"},
+			}},
+			Outputs: []v1alpha1.Output{{
+				Binding: v1alpha1.Binding{
+					Name:  "result",
+					Value: v1alpha1.Any{Value: "($foo)"},
+				},
+			}},
+		},
+	}
+	stepProcessor := NewStepProcessor(
+		step,
+		"",
+		nil,
+		nil,
+		nil,
+		nil,
+		config.Spec.Timeouts,
+		config.Spec.Deletion.Propagation,
+		config.Spec.Templating.Enabled,
+		config.Spec.Cleanup.SkipDelete,
+	)
+	nt := &testing.MockT{}
+	ctx := testing.IntoContext(context.Background(), nt)
+	ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+	tcontext := enginecontext.MakeContext(binding.NewBindings(), registryMock{})
+	got := stepProcessor.Run(ctx, nil, tcontext)
+	assert.False(t, nt.FailedVar)
+	assert.Equal(t, map[string]any{"result": "bar"}, got)
+}
+
+func TestStepProcessor_collectorsWithoutCluster(t *testing.T) {
+	object := v1alpha1.ActionObject{
+		ObjectType: v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+	}
+	p := &stepProcessor{}
+	tests := []struct {
+		name      string
+		operation operation
+		client    client.Client
+	}{{
+		name:      "describe",
+		operation: p.describeOperation(1, nil, v1alpha1.Describe{ActionObject: object}),
+	}, {
+		name:      "events",
+		operation: p.eventsOperation(1, nil, v1alpha1.Events{}),
+	}, {
+		name:      "get",
+		operation: p.getOperation(1, nil, v1alpha1.Get{ActionObject: object}),
+	}, {
+		name:      "logs",
+		operation: p.logsOperation(1, nil, v1alpha1.PodLogs{}),
+	}, {
+		name:      "logs with a fake client",
+		operation: p.logsOperation(1, nil, v1alpha1.PodLogs{}),
+		client:    &fake.FakeClient{},
+	}, {
+		name:      "proxy",
+		operation: p.proxyOperation(1, nil, v1alpha1.Proxy{}),
+	}, {
+		name:      "proxy with a fake client",
+		operation: p.proxyOperation(1, nil, v1alpha1.Proxy{}),
+		client:    &fake.FakeClient{},
+	}, {
+		name:      "wait",
+		operation: p.waitOperation(1, nil, v1alpha1.Wait{ActionObject: object}),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := enginecontext.MakeContext(binding.NewBindings(), registryMock{client: tt.client})
+			op, _, _, err := tt.operation.operation(context.TODO(), tc)
+			assert.Nil(t, op)
+			assert.EqualError(t, err, "no cluster configured")
+		})
+	}
+}
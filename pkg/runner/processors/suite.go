@@ -0,0 +1,98 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis/conversion"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/kyverno/chainsaw/pkg/engine"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/runner/failer"
+	"github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/kyverno/pkg/ext/output/color"
+)
+
+// setup runs the suite setup steps and registers the suite teardown.
+// Resources created by setup steps are deleted after the teardown steps ran,
+// the returned context contains the outputs of the setup steps.
+func (p *testsProcessor) setup(ctx context.Context, nspacer namespacer.Namespacer, tc engine.Context) (engine.Context, bool) {
+	t := testing.FromContext(ctx)
+	setup := newIsolatedT(t)
+	t.Cleanup(func() {
+		if len(p.config.Teardown) != 0 {
+			teardown := newIsolatedT(t)
+			teardown.run(func() {
+				p.runSuiteSteps(testing.IntoContext(ctx, teardown), logging.Teardown, nspacer, tc, p.config.Teardown...)
+			})
+			if teardown.Failed() {
+				t.Fail()
+			}
+		}
+		setup.cleanup()
+		if setup.Failed() {
+			t.Fail()
+		}
+	})
+	if len(p.config.Setup) != 0 {
+		call(func() {
+			tc = p.runSuiteSteps(testing.IntoContext(ctx, setup), logging.Setup, nspacer, tc, p.config.Setup...)
+		})
+	}
+	return tc, !setup.Failed()
+}
+
+func (p *testsProcessor) runSuiteSteps(ctx context.Context, operation logging.Operation, nspacer namespacer.Namespacer, tc engine.Context, steps ...v1alpha2.TestStep) engine.Context {
+	t := testing.FromContext(ctx)
+	logging.Log(ctx, operation, logging.BeginStatus, color.BoldFgCyan)
+	defer func() {
+		logging.Log(ctx, operation, logging.EndStatus, color.BoldFgCyan)
+	}()
+	var delayBeforeCleanup *time.Duration
+	if p.config.Cleanup.DelayBeforeCleanup != nil {
+		delayBeforeCleanup = &p.config.Cleanup.DelayBeforeCleanup.Duration
+	}
+	test := "@" + strings.ToLower(string(operation))
+	size := len(test)
+	for i, step := range steps {
+		size = max(size, len(stepName(step.Name, i)))
+	}
+	for i := range steps {
+		var step v1alpha1.TestStep
+		if err := conversion.Convert_v1alpha2_TestStep_To_v1alpha1_TestStep(&steps[i], &step, nil); err != nil {
+			logging.Log(ctx, logging.Internal, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+			failer.FailNow(ctx)
+			return tc
+		}
+		ctx := logging.IntoContext(ctx, logging.NewLogger(t, p.clock, test, fmt.Sprintf("%-*s", size, stepName(step.Name, i)), nil))
+		processor := NewStepProcessor(
+			step,
+			"",
+			nil,
+			delayBeforeCleanup,
+			p.forceFinalize,
+			p.config.Execution.ForceTerminationGracePeriod,
+			p.config.Timeouts,
+			p.config.Deletion.Propagation,
+			p.config.Templating.Enabled,
+			p.config.Cleanup.SkipDelete,
+			p.config.Error.Catch...,
+		)
+		// outputs returned by a step are available to the next steps and to the tests
+		for k, v := range processor.Run(ctx, nspacer, tc.WithBinding(ctx, "step", StepInfo{Id: i + 1})) {
+			tc = tc.WithBinding(ctx, k, v)
+		}
+	}
+	return tc
+}
+
+func stepName(name string, i int) string {
+	if name == "" {
+		return fmt.Sprintf("step-%d", i+1)
+	}
+	return name
+}
@@ -0,0 +1,84 @@
+package processors
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmespath-community/go-jmespath/pkg/binding"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	enginecontext "github.com/kyverno/chainsaw/pkg/engine/context"
+	"github.com/kyverno/chainsaw/pkg/model"
+	"github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+)
+
+func TestTestsProcessor_setup(t *testing.T) {
+	sleep := v1alpha2.TryOperation{
+		Operation: v1alpha2.Operation{
+			OperationAction: v1alpha2.OperationAction{
+				Sleep: &v1alpha2.Sleep{Duration: metav1.Duration{Duration: time.Millisecond}},
+			},
+		},
+	}
+	check := v1alpha2.TryOperation{
+		Operation: v1alpha2.Operation{
+			OperationAction: v1alpha2.OperationAction{
+				Assert: &v1alpha2.Assert{
+					ActionCheckRef: v1alpha2.ActionCheckRef{
+						FileRef: v1alpha2.FileRef{File: "missing.yaml"},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name   string
+		setup  []v1alpha2.TestStep
+		wantOk bool
+		want   any
+	}{{
+		name: "outputs",
+		setup: []v1alpha2.TestStep{{
+			TestStepSpec: v1alpha2.TestStepSpec{
+				Try: []v1alpha2.TryOperation{sleep},
+				Outputs: []v1alpha1.Output{{
+					Binding: v1alpha1.Binding{Name: "operator", Value: v1alpha1.Any{Value: "installed"}},
+				}},
+			},
+		}},
+		wantOk: true,
+		want:   "installed",
+	}, {
+		name: "failure",
+		setup: []v1alpha2.TestStep{{
+			TestStepSpec: v1alpha2.TestStepSpec{
+				Try: []v1alpha2.TryOperation{check},
+			},
+		}},
+		wantOk: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &testsProcessor{
+				config: model.Configuration{Setup: tt.setup},
+				clock:  clock.RealClock{},
+			}
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			tc := enginecontext.MakeContext(binding.NewBindings(), registryMock{})
+			tc, ok := p.setup(ctx, nil, tc)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.False(t, nt.Failed())
+			if tt.want != nil {
+				got, err := tc.Bindings().Get("$operator")
+				assert.NoError(t, err)
+				value, err := got.Value()
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, value)
+			}
+		})
+	}
+}
@@ -2,12 +2,18 @@ package processors
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	petname "github.com/dustinkirkland/golang-petname"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
 	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
+	"github.com/kyverno/chainsaw/pkg/cleanup/journal"
+	"github.com/kyverno/chainsaw/pkg/cleanup/leaks"
+	"github.com/kyverno/chainsaw/pkg/client"
 	"github.com/kyverno/chainsaw/pkg/discovery"
 	"github.com/kyverno/chainsaw/pkg/engine"
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
@@ -31,6 +37,8 @@ func NewTestProcessor(
 	report *report.TestReport,
 	nsTemplate *v1alpha1.Any,
 	delayBeforeCleanup *time.Duration,
+	forceFinalize *time.Duration,
+	leakDetection *v1alpha2.LeakDetectionOptions,
 	terminationGracePeriod *metav1.Duration,
 	timeouts v1alpha1.DefaultTimeouts,
 	deletionPropagationPolicy metav1.DeletionPropagation,
@@ -67,6 +75,8 @@ func NewTestProcessor(
 		report:                    report,
 		nsTemplate:                nsTemplate,
 		delayBeforeCleanup:        delayBeforeCleanup,
+		forceFinalize:             forceFinalize,
+		leakDetection:             leakDetection,
 		terminationGracePeriod:    terminationGracePeriod,
 		timeouts:                  timeouts,
 		deletionPropagationPolicy: deletionPropagationPolicy,
@@ -83,6 +93,8 @@ type testProcessor struct {
 	report                    *report.TestReport
 	nsTemplate                *v1alpha1.Any
 	delayBeforeCleanup        *time.Duration
+	forceFinalize             *time.Duration
+	leakDetection             *v1alpha2.LeakDetectionOptions
 	terminationGracePeriod    *metav1.Duration
 	timeouts                  v1alpha1.DefaultTimeouts
 	deletionPropagationPolicy metav1.DeletionPropagation
@@ -96,16 +108,30 @@ func (p *testProcessor) Run(ctx context.Context, nspacer namespacer.Namespacer,
 	if p.report != nil {
 		p.report.SetStartTime(time.Now())
 		t.Cleanup(func() {
-			p.report.SetEndTime(time.Now())
 			if t.Failed() {
 				p.report.Fail()
 			}
 			if t.Skipped() {
 				p.report.Skip()
 			}
+			p.report.SetEndTime(time.Now())
 		})
 	}
-	mainCleaner := cleaner.New(p.timeouts.Cleanup.Duration, nil, p.deletionPropagationPolicy)
+	// clusters are torn down once the resources they contain have been cleaned up
+	var clusters clusterScope
+	t.Cleanup(func() {
+		clusters.teardown(ctx)
+	})
+	// leaks are detected once the resources have been cleaned up, the cleanup below is registered
+	// after this one and runs before it
+	var leakDetector leaks.Detector
+	var leakClient client.Client
+	t.Cleanup(func() {
+		if leakDetector != nil {
+			p.detectLeaks(ctx, leakDetector, leakClient)
+		}
+	})
+	mainCleaner := cleaner.New(p.timeouts.Cleanup.Duration, nil, p.deletionPropagationPolicy, p.forceFinalize, journal.FromContext(ctx))
 	t.Cleanup(func() {
 		if !mainCleaner.Empty() {
 			logging.Log(ctx, logging.Cleanup, logging.BeginStatus, color.BoldFgCyan)
@@ -126,6 +152,7 @@ func (p *testProcessor) Run(ctx context.Context, nspacer namespacer.Namespacer,
 		clusters: p.test.Test.Spec.Clusters,
 		cluster:  p.test.Test.Spec.Cluster,
 		bindings: p.test.Test.Spec.Bindings,
+		scope:    &clusters,
 	}
 	nsName := p.test.Test.Spec.Namespace
 	if nspacer == nil && nsName == "" {
@@ -150,6 +177,36 @@ func (p *testProcessor) Run(ctx context.Context, nspacer namespacer.Namespacer,
 		}
 		failer.FailNow(ctx)
 	}
+	if p.leakDetection != nil && !p.skipDelete {
+		_, clusterClient, err := tc.CurrentClusterClient()
+		if err == nil && clusterClient != nil {
+			resources := p.leakDetection.Resources
+			// resources created by other tests would show up in the snapshots of a concurrent test,
+			// they are scoped to the test namespace
+			if p.concurrent() {
+				var scope string
+				if namespace != nil {
+					scope = namespace.GetName()
+				}
+				var skipped []v1alpha2.LeakResource
+				resources, skipped, err = leaks.InNamespace(clusterClient, scope, resources...)
+				if len(skipped) != 0 {
+					logging.Log(ctx, logging.Leaks, logging.WarnStatus, color.BoldYellow, logging.ErrSection(skippedLeakResourcesError(skipped)))
+				}
+			}
+			if err == nil && len(resources) != 0 {
+				leakDetector, err = leaks.New(ctx, clusterClient, resources...)
+				leakClient = clusterClient
+			}
+		}
+		if err != nil {
+			logging.Log(ctx, logging.Leaks, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+			if p.report != nil {
+				p.report.SetErr(err)
+			}
+			failer.FailNow(ctx)
+		}
+	}
 	if namespace != nil {
 		nspacer = namespacer.New(namespace.GetName())
 	}
@@ -165,9 +222,62 @@ func (p *testProcessor) Run(ctx context.Context, nspacer namespacer.Namespacer,
 		info := StepInfo{
 			Id: i + 1,
 		}
-		tc := tc.WithBinding(ctx, "step", info)
 		processor := p.createStepProcessor(step)
-		processor.Run(ctx, nspacer, tc)
+		// outputs returned by a step are available to the next steps
+		for k, v := range processor.Run(ctx, nspacer, tc.WithBinding(ctx, "step", info)) {
+			tc = tc.WithBinding(ctx, k, v)
+		}
+	}
+}
+
+// concurrent returns true if the test runs concurrently with other tests.
+func (p *testProcessor) concurrent() bool {
+	return p.test.Test.Spec.Concurrent == nil || *p.test.Test.Spec.Concurrent
+}
+
+// skippedLeakResourcesError returns the error reported when resources can't be checked for leaks in a concurrent test.
+func skippedLeakResourcesError(resources []v1alpha2.LeakResource) error {
+	names := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		name := resource.APIVersion + "/" + resource.Kind
+		if resource.Namespace != "" {
+			name += " @ " + resource.Namespace
+		}
+		names = append(names, name)
+	}
+	return fmt.Errorf("leaks are not detected for %s, they can't be scoped to the namespace of a concurrent test, set concurrent to false", strings.Join(names, ", "))
+}
+
+func (p *testProcessor) detectLeaks(ctx context.Context, detector leaks.Detector, client client.Client) {
+	leaked, err := detector.Detect(ctx)
+	if err == nil && len(leaked) != 0 {
+		err = &leaks.LeakError{Objects: leaked}
+	}
+	if err == nil {
+		return
+	}
+	var leakErr *leaks.LeakError
+	if !errors.As(err, &leakErr) || p.leakDetection.Action == v1alpha2.LeakActionFail {
+		logging.Log(ctx, logging.Leaks, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+		if p.report != nil {
+			p.report.SetErr(err)
+		}
+		failer.Fail(ctx)
+		return
+	}
+	logging.Log(ctx, logging.Leaks, logging.WarnStatus, color.BoldYellow, logging.ErrSection(err))
+	if p.leakDetection.Action == v1alpha2.LeakActionCollect {
+		collector := cleaner.New(p.timeouts.Cleanup.Duration, nil, p.deletionPropagationPolicy, p.forceFinalize, journal.FromContext(ctx))
+		for i := range leaked {
+			collector.Add(client, &leaked[i])
+		}
+		for _, err := range collector.Run(ctx) {
+			logging.Log(ctx, logging.Leaks, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+			if p.report != nil {
+				p.report.SetErr(err)
+			}
+			failer.Fail(ctx)
+		}
 	}
 }
 
@@ -185,6 +295,7 @@ func (p *testProcessor) createStepProcessor(step v1alpha1.TestStep) StepProcesso
 		workdir,
 		report,
 		p.delayBeforeCleanup,
+		p.forceFinalize,
 		p.terminationGracePeriod,
 		p.timeouts,
 		p.deletionPropagationPolicy,
@@ -7,6 +7,7 @@ import (
 
 	"github.com/jmespath-community/go-jmespath/pkg/binding"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
 	"github.com/kyverno/chainsaw/pkg/client"
 	fake "github.com/kyverno/chainsaw/pkg/client/testing"
 	"github.com/kyverno/chainsaw/pkg/discovery"
@@ -19,6 +20,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	kerror "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/clock"
 	tclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
@@ -301,6 +304,8 @@ func TestTestProcessor_Run(t *testing.T) {
 				tc.testsReport,
 				config.Spec.Namespace.Template,
 				nil,
+				nil,
+				nil,
 				config.Spec.Execution.ForceTerminationGracePeriod,
 				config.Spec.Timeouts,
 				config.Spec.Deletion.Propagation,
@@ -320,3 +325,153 @@ func TestTestProcessor_Run(t *testing.T) {
 		})
 	}
 }
+
+type fakeDetector struct {
+	leaks []unstructured.Unstructured
+	err   error
+}
+
+func (d fakeDetector) Detect(context.Context) ([]unstructured.Unstructured, error) {
+	return d.leaks, d.err
+}
+
+func TestTestProcessor_detectLeaks(t *testing.T) {
+	var leaked unstructured.Unstructured
+	leaked.SetAPIVersion("v1")
+	leaked.SetKind("ConfigMap")
+	leaked.SetNamespace("foo")
+	leaked.SetName("bar")
+	testCases := []struct {
+		name         string
+		detector     fakeDetector
+		action       v1alpha2.LeakAction
+		expectedFail bool
+		expectDelete bool
+	}{{
+		name:   "no leaks",
+		action: v1alpha2.LeakActionFail,
+	}, {
+		name:     "warn",
+		detector: fakeDetector{leaks: []unstructured.Unstructured{leaked}},
+		action:   v1alpha2.LeakActionWarn,
+	}, {
+		name:         "fail",
+		detector:     fakeDetector{leaks: []unstructured.Unstructured{leaked}},
+		action:       v1alpha2.LeakActionFail,
+		expectedFail: true,
+	}, {
+		name:         "collect",
+		detector:     fakeDetector{leaks: []unstructured.Unstructured{leaked}},
+		action:       v1alpha2.LeakActionCollect,
+		expectDelete: true,
+	}, {
+		name:         "error",
+		detector:     fakeDetector{err: errors.New("dummy")},
+		action:       v1alpha2.LeakActionWarn,
+		expectedFail: true,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			deleted := false
+			client := &fake.FakeClient{
+				DeleteFn: func(_ context.Context, _ int, obj ctrlclient.Object, _ ...ctrlclient.DeleteOption) error {
+					deleted = true
+					return nil
+				},
+				GetFn: func(_ context.Context, _ int, key ctrlclient.ObjectKey, _ ctrlclient.Object, _ ...ctrlclient.GetOption) error {
+					return kerror.NewNotFound(v1alpha1.Resource("configmap"), key.Name)
+				},
+			}
+			processor := &testProcessor{
+				leakDetection: &v1alpha2.LeakDetectionOptions{Action: tc.action},
+				timeouts:      v1alpha1.DefaultTimeouts{Cleanup: v1.Duration{Duration: time.Second}},
+			}
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			processor.detectLeaks(ctx, tc.detector, client)
+			assert.Equal(t, tc.expectedFail, nt.FailedVar)
+			assert.Equal(t, tc.expectDelete, deleted)
+		})
+	}
+}
+
+func TestTestProcessor_Run_leakDetection(t *testing.T) {
+	testCases := []struct {
+		name         string
+		concurrent   *bool
+		namespace    string
+		action       v1alpha2.LeakAction
+		expectedList []string
+	}{{
+		name:         "concurrent",
+		namespace:    "foo",
+		expectedList: []string{"ConfigMapList @ foo"},
+	}, {
+		name:         "concurrent collect",
+		namespace:    "foo",
+		action:       v1alpha2.LeakActionCollect,
+		expectedList: []string{"ConfigMapList @ foo"},
+	}, {
+		name: "concurrent without test namespace",
+	}, {
+		name:         "not concurrent",
+		concurrent:   ptr.To(false),
+		namespace:    "foo",
+		expectedList: []string{"ConfigMapList @ ", "NamespaceList @ "},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			test := discovery.Test{
+				Test: &model.Test{
+					Spec: v1alpha1.TestSpec{
+						Concurrent: tc.concurrent,
+						Namespace:  tc.namespace,
+						Timeouts:   &v1alpha1.Timeouts{},
+					},
+				},
+			}
+			leakDetection := &v1alpha2.LeakDetectionOptions{
+				Action: tc.action,
+				Resources: []v1alpha2.LeakResource{
+					{APIVersion: "v1", Kind: "ConfigMap"},
+					{APIVersion: "v1", Kind: "Namespace"},
+				},
+			}
+			var lists []string
+			client := &fake.FakeClient{
+				GetFn: func(context.Context, int, ctrlclient.ObjectKey, ctrlclient.Object, ...ctrlclient.GetOption) error {
+					return nil
+				},
+				IsObjectNamespacedFn: func(_ int, obj runtime.Object) (bool, error) {
+					return obj.GetObjectKind().GroupVersionKind().Kind != "Namespace", nil
+				},
+				ListFn: func(_ context.Context, _ int, list ctrlclient.ObjectList, opts ...ctrlclient.ListOption) error {
+					var options ctrlclient.ListOptions
+					options.ApplyOptions(opts)
+					lists = append(lists, list.GetObjectKind().GroupVersionKind().Kind+" @ "+options.Namespace)
+					return nil
+				},
+			}
+			processor := NewTestProcessor(test, 0, tclock.NewFakePassiveClock(time.Now()), nil, nil, nil, nil, leakDetection, nil, v1alpha1.DefaultTimeouts{}, v1.DeletePropagationBackground, true, false)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			tcontext := enginecontext.MakeContext(binding.NewBindings(), registryMock{client: client})
+			nspacer := &fakeNamespacer.FakeNamespacer{
+				GetNamespaceFn: func(call int) string {
+					return "chainsaw"
+				},
+			}
+			processor.Run(ctx, nspacer, tcontext)
+			assert.False(t, nt.FailedVar)
+			assert.Equal(t, tc.expectedList, lists)
+		})
+	}
+}
+
+func Test_skippedLeakResourcesError(t *testing.T) {
+	err := skippedLeakResourcesError([]v1alpha2.LeakResource{
+		{APIVersion: "v1", Kind: "Namespace"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "shared"},
+	})
+	assert.EqualError(t, err, "leaks are not detected for v1/Namespace, v1/ConfigMap @ shared, they can't be scoped to the namespace of a concurrent test, set concurrent to false")
+}
@@ -2,18 +2,30 @@ package processors
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
 	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
+	"github.com/kyverno/chainsaw/pkg/cleanup/journal"
 	"github.com/kyverno/chainsaw/pkg/discovery"
 	"github.com/kyverno/chainsaw/pkg/engine"
+	"github.com/kyverno/chainsaw/pkg/engine/clusters"
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
 	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
 	"github.com/kyverno/chainsaw/pkg/model"
 	"github.com/kyverno/chainsaw/pkg/report"
 	"github.com/kyverno/chainsaw/pkg/runner/failer"
+	"github.com/kyverno/chainsaw/pkg/runner/graph"
 	"github.com/kyverno/chainsaw/pkg/runner/names"
+	"github.com/kyverno/chainsaw/pkg/runner/preflight"
+	"github.com/kyverno/chainsaw/pkg/runner/retry"
 	"github.com/kyverno/chainsaw/pkg/testing"
 	"github.com/kyverno/pkg/ext/output/color"
 	"k8s.io/utils/clock"
@@ -24,21 +36,27 @@ type TestsProcessor interface {
 }
 
 func NewTestsProcessor(config model.Configuration, clock clock.PassiveClock, report *report.Report) TestsProcessor {
+	var forceFinalize *time.Duration
+	if config.Cleanup.ForceFinalize != nil {
+		forceFinalize = &config.Cleanup.ForceFinalize.Duration
+	}
 	return &testsProcessor{
-		config: config,
-		clock:  clock,
-		report: report,
+		config:        config,
+		clock:         clock,
+		report:        report,
+		forceFinalize: forceFinalize,
 	}
 }
 
 type testsProcessor struct {
-	config model.Configuration
-	clock  clock.PassiveClock
-	report *report.Report
+	config        model.Configuration
+	clock         clock.PassiveClock
+	report        *report.Report
+	forceFinalize *time.Duration
 }
 
 func (p *testsProcessor) Run(ctx context.Context, tc engine.Context, tests ...discovery.Test) {
-	// 1. setup context
+	// setup context
 	t := testing.FromContext(ctx)
 	if p.report != nil {
 		p.report.SetStartTime(time.Now())
@@ -46,7 +64,12 @@ func (p *testsProcessor) Run(ctx context.Context, tc engine.Context, tests ...di
 			p.report.SetEndTime(time.Now())
 		})
 	}
-	mainCleaner := cleaner.New(p.config.Timeouts.Cleanup.Duration, nil, p.config.Deletion.Propagation)
+	// clusters are torn down once the resources they contain have been cleaned up
+	var clusters clusterScope
+	t.Cleanup(func() {
+		clusters.teardown(ctx)
+	})
+	mainCleaner := cleaner.New(p.config.Timeouts.Cleanup.Duration, nil, p.config.Deletion.Propagation, p.forceFinalize, journal.FromContext(ctx))
 	t.Cleanup(func() {
 		if !mainCleaner.Empty() {
 			logging.Log(ctx, logging.Cleanup, logging.BeginStatus, color.BoldFgCyan)
@@ -59,9 +82,18 @@ func (p *testsProcessor) Run(ctx context.Context, tc engine.Context, tests ...di
 			}
 		}
 	})
+	// clusters are registered first so that preflight checks run before anything is written to them
+	tc, err := engine.WithClusters(ctx, tc, "", p.config.Clusters, clusters.cleanup)
+	if err != nil {
+		logging.Log(ctx, logging.Internal, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+		failer.FailNow(ctx)
+	}
+	var unhealthy map[string]preflight.Result
+	if p.config.Preflight.Enabled {
+		unhealthy = p.preflight(ctx, tc)
+	}
 	contextData := contextData{
 		basePath: "",
-		clusters: p.config.Clusters,
 	}
 	if p.config.Namespace.Name != "" {
 		var nsCleaner cleaner.CleanerCollector
@@ -83,21 +115,46 @@ func (p *testsProcessor) Run(ctx context.Context, tc engine.Context, tests ...di
 	if namespace != nil {
 		nspacer = namespacer.New(namespace.GetName())
 	}
-	// 2. loop through tests
-	for i := range tests {
+	// build the tests dependency graph
+	dependencies, err := graph.Build(tests...)
+	if err != nil {
+		logging.Log(ctx, logging.Internal, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+		failer.FailNow(ctx)
+		return
+	}
+	// run suite setup
+	if len(p.config.Setup) != 0 || len(p.config.Teardown) != 0 {
+		var ok bool
+		tc, ok = p.setup(ctx, nspacer, tc)
+		if !ok {
+			logging.Log(ctx, logging.Setup, logging.ErrorStatus, color.BoldRed, logging.ErrSection(errors.New("suite setup failed, tests will not run")))
+			tc.IncFailed()
+			t.FailNow()
+			return
+		}
+	}
+	// tests that failed or were skipped, tests depending on them are skipped
+	unsuccessful := make([]atomic.Bool, len(tests))
+	// runTest runs the scenarios of a test, start runs a scenario in a separate T
+	runTest := func(ctx context.Context, i int, start func(name string, concurrent bool, f func(*testing.T))) {
 		test := tests[i]
 		name, err := names.Test(p.config.Discovery.FullName, test)
 		if err != nil {
 			logging.Log(ctx, logging.Internal, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
 			failer.FailNow(ctx)
 		}
-		// 3. compute test scenarios
+		// compute test scenarios
 		scenarios := applyScenarios(test)
-		// 4. loop through test scenarios
+		// loop through test scenarios
 		for s := range scenarios {
 			test := scenarios[s]
-			// 5. run each test scenario in a separate T
-			t.Run(name, func(t *testing.T) {
+			var scenario *v1alpha1.Scenario
+			if len(tests[i].Test.Spec.Scenarios) != 0 {
+				scenario = &tests[i].Test.Spec.Scenarios[s]
+			}
+			// run each test scenario in a separate T
+			concurrent := test.Test.Spec.Concurrent == nil || *test.Test.Spec.Concurrent
+			start(name, concurrent, func(t *testing.T) {
 				t.Helper()
 				ctx := testing.IntoContext(ctx, t)
 				size := len("@chainsaw")
@@ -117,20 +174,23 @@ func (p *testsProcessor) Run(ctx context.Context, tc engine.Context, tests ...di
 					Metadata:   test.Test.ObjectMeta,
 				}
 				tc := tc.WithBinding(ctx, "test", info)
+				var flaky bool
 				t.Cleanup(func() {
+					if t.Skipped() || t.Failed() {
+						unsuccessful[i].Store(true)
+					}
 					if t.Skipped() {
 						tc.IncSkipped()
 					} else {
 						if t.Failed() {
 							tc.IncFailed()
+						} else if flaky {
+							tc.IncFlaky()
 						} else {
 							tc.IncPassed()
 						}
 					}
 				})
-				if test.Test.Spec.Concurrent == nil || *test.Test.Spec.Concurrent {
-					t.Parallel()
-				}
 				if test.Test.Spec.Skip != nil && *test.Test.Spec.Skip {
 					t.SkipNow()
 				}
@@ -143,18 +203,159 @@ func (p *testsProcessor) Run(ctx context.Context, tc engine.Context, tests ...di
 						t.SkipNow()
 					}
 				}
-				processor := p.createTestProcessor(test, size)
-				processor.Run(ctx, nspacer, tc)
+				for _, missing := range dependencies.Missing(i) {
+					logging.Log(ctx, logging.Internal, logging.WarnStatus, color.BoldYellow, logging.Section("DEPENDENCY", fmt.Sprintf("%q is not part of the run", missing)))
+					t.SkipNow()
+				}
+				for _, dependency := range dependencies.Dependencies(i) {
+					if unsuccessful[dependency].Load() {
+						logging.Log(ctx, logging.Internal, logging.WarnStatus, color.BoldYellow, logging.Section("DEPENDENCY", fmt.Sprintf("%q failed or was skipped", tests[dependency].Test.Name)))
+						t.SkipNow()
+					}
+				}
+				for _, target := range preflight.Targets(test.Test) {
+					if result, ok := unhealthy[target]; ok {
+						logging.Log(ctx, logging.Preflight, logging.ErrorStatus, color.BoldRed, logging.ErrSection(fmt.Errorf("cluster %q is unhealthy: %w", target, result.Err)))
+						if p.config.Preflight.Failure == v1alpha2.PreflightSkip {
+							t.SkipNow()
+						}
+						failer.FailNow(ctx)
+					}
+				}
+				var report *report.TestReport
+				if p.report != nil {
+					report = p.report.ForTest(&test)
+					if scenario != nil {
+						report.SetScenario(s+1, scenario.Bindings)
+					}
+				}
+				processor := p.createTestProcessor(test, size, report)
+				policy := retry.Policy(p.config.Execution.Retry, test.Test.Spec.Retry)
+				maxAttempts := retry.MaxAttempts(policy)
+				// run the test until it passes or it can't be retried
+				for attempt := 1; ; attempt++ {
+					at := newIsolatedT(t)
+					at.run(func() {
+						processor.Run(testing.IntoContext(ctx, at), nspacer, tc)
+					})
+					if at.Skipped() {
+						t.SkipNow()
+					}
+					if !at.Failed() {
+						flaky = attempt > 1
+						return
+					}
+					if attempt >= maxAttempts || !retry.ShouldRetry(policy, at.Errors()...) {
+						t.FailNow()
+					}
+					backoff := retry.Backoff(policy, attempt)
+					logging.Log(ctx, logging.Retry, logging.WarnStatus, color.BoldYellow, logging.Section("ATTEMPT", fmt.Sprintf("%d/%d failed, retrying in %s", attempt, maxAttempts, backoff)))
+					select {
+					case <-ctx.Done():
+						t.FailNow()
+					case <-time.After(backoff):
+					}
+					if report != nil {
+						report.NewAttempt()
+					}
+				}
 			})
 		}
 	}
+	// run tests
+	if !dependencies.HasDependencies() {
+		for i := range tests {
+			runTest(ctx, i, func(name string, concurrent bool, f func(*testing.T)) {
+				t.Run(name, func(t *testing.T) {
+					t.Helper()
+					if concurrent {
+						t.Parallel()
+					}
+					f(t)
+				})
+			})
+		}
+		return
+	}
+	// when tests depend on other tests, a test starts once the tests it depends on completed.
+	// parallel tests only start when the parent test returns, instead scenarios are started from
+	// goroutines so that test names don't change, and the number of tests running at once is bounded here.
+	slots := make(chan struct{}, parallelism(p.config.Execution.Parallel))
+	// concurrent tests share the slots, other tests run alone
+	var exclusive sync.RWMutex
+	done := make([]chan struct{}, len(tests))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	var wg sync.WaitGroup
+	for i := range tests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[i])
+			for _, dependency := range dependencies.Dependencies(i) {
+				<-done[dependency]
+			}
+			var scenarios sync.WaitGroup
+			defer scenarios.Wait()
+			runTest(ctx, i, func(name string, concurrent bool, f func(*testing.T)) {
+				scenarios.Add(1)
+				go func() {
+					defer scenarios.Done()
+					if concurrent {
+						exclusive.RLock()
+						defer exclusive.RUnlock()
+						slots <- struct{}{}
+						defer func() { <-slots }()
+					} else {
+						exclusive.Lock()
+						defer exclusive.Unlock()
+					}
+					t.Run(name, f)
+				}()
+			})
+		}()
+	}
+	wg.Wait()
 }
 
-func (p *testsProcessor) createTestProcessor(test discovery.Test, size int) TestProcessor {
-	var report *report.TestReport
-	if p.report != nil {
-		report = p.report.ForTest(&test)
+// parallelism returns the maximum number of tests running at once, it defaults to GOMAXPROCS like `go test -parallel`.
+func parallelism(parallel *int) int {
+	if parallel != nil && *parallel > 0 {
+		return *parallel
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+func (p *testsProcessor) preflight(ctx context.Context, tc engine.Context) map[string]preflight.Result {
+	logging.Log(ctx, logging.Preflight, logging.BeginStatus, color.BoldFgCyan)
+	defer func() {
+		logging.Log(ctx, logging.Preflight, logging.EndStatus, color.BoldFgCyan)
+	}()
+	registry := tc.Clusters()
+	var names []string
+	if registry.Lookup(clusters.DefaultClient) != nil {
+		names = append(names, clusters.DefaultClient)
 	}
+	for name := range p.config.Clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	unhealthy := map[string]preflight.Result{}
+	results := preflight.CheckAll(ctx, registry, names, p.config.Preflight)
+	for _, name := range names {
+		result := results[name]
+		if result.Healthy() {
+			logging.Log(ctx, logging.Preflight, logging.OkStatus, color.BoldGreen, logging.Section("CLUSTER", fmt.Sprintf("%q (%s)", name, result.Version)))
+		} else {
+			logging.Log(ctx, logging.Preflight, logging.WarnStatus, color.BoldYellow, logging.Section("CLUSTER", fmt.Sprintf("%q", name)), logging.ErrSection(result.Err))
+			unhealthy[name] = result
+		}
+	}
+	return unhealthy
+}
+
+func (p *testsProcessor) createTestProcessor(test discovery.Test, size int, report *report.TestReport) TestProcessor {
 	var delayBeforeCleanup *time.Duration
 	if p.config.Cleanup.DelayBeforeCleanup != nil {
 		delayBeforeCleanup = &p.config.Cleanup.DelayBeforeCleanup.Duration
@@ -166,6 +367,8 @@ func (p *testsProcessor) createTestProcessor(test discovery.Test, size int) Test
 		report,
 		p.config.Namespace.Template,
 		delayBeforeCleanup,
+		p.forceFinalize,
+		p.config.Cleanup.LeakDetection,
 		p.config.Execution.ForceTerminationGracePeriod,
 		p.config.Timeouts,
 		p.config.Deletion.Propagation,
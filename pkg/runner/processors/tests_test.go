@@ -2,6 +2,7 @@ package processors
 
 import (
 	"context"
+	"sync"
 
 	"github.com/jmespath-community/go-jmespath/pkg/binding"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
@@ -15,6 +16,7 @@ import (
 	"github.com/kyverno/chainsaw/pkg/testing"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/clock"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -155,6 +157,42 @@ func TestTestsProcessor_Run(t *testing.T) {
 			},
 		},
 		expectedFail: true,
+	}, {
+		name: "Dependency cycle",
+		config: model.Configuration{
+			Namespace: v1alpha2.NamespaceOptions{
+				Name: "default",
+			},
+		},
+		client: &fake.FakeClient{
+			GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+				return nil
+			},
+		},
+		clock:       nil,
+		testsReport: &report.Report{},
+		bindings:    binding.NewBindings(),
+		tests: []discovery.Test{
+			{
+				BasePath: "foo",
+				Test: &model.Test{
+					ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+					Spec: v1alpha1.TestSpec{
+						DependsOn: []v1alpha1.TestDependency{{Name: "bar"}},
+					},
+				},
+			},
+			{
+				BasePath: "bar",
+				Test: &model.Test{
+					ObjectMeta: metav1.ObjectMeta{Name: "bar"},
+					Spec: v1alpha1.TestSpec{
+						DependsOn: []v1alpha1.TestDependency{{Name: "foo"}},
+					},
+				},
+			},
+		},
+		expectedFail: true,
 	}}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -181,3 +219,58 @@ func TestTestsProcessor_Run(t *testing.T) {
 		})
 	}
 }
+
+// recordingT records when subtests start and return.
+type recordingT struct {
+	testing.TTest
+	lock   sync.Mutex
+	events []string
+}
+
+func (t *recordingT) record(event string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.events = append(t.events, event)
+}
+
+func (t *recordingT) Run(name string, f func(t *testing.T)) bool {
+	t.record("start " + name)
+	defer t.record("done " + name)
+	return t.TTest.Run(name, f)
+}
+
+func TestTestsProcessor_Run_dependencies(t *testing.T) {
+	test := func(name string, dependsOn ...string) discovery.Test {
+		var dependencies []v1alpha1.TestDependency
+		for _, dependency := range dependsOn {
+			dependencies = append(dependencies, v1alpha1.TestDependency{Name: dependency})
+		}
+		return discovery.Test{
+			BasePath: name,
+			Test: &model.Test{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: v1alpha1.TestSpec{
+					DependsOn: dependencies,
+				},
+			},
+		}
+	}
+	config := model.Configuration{
+		Namespace: v1alpha2.NamespaceOptions{
+			Name: "default",
+		},
+	}
+	registry := registryMock{
+		client: &fake.FakeClient{
+			GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+				return nil
+			},
+		},
+	}
+	rt := &recordingT{TTest: t}
+	processor := NewTestsProcessor(config, nil, &report.Report{})
+	ctx := testing.IntoContext(context.Background(), rt)
+	processor.Run(ctx, enginecontext.MakeContext(binding.NewBindings(), registry), test("foo"), test("bar", "foo"), test("baz", "bar"))
+	// test names don't change and dependents start once their dependencies completed
+	assert.Equal(t, []string{"start foo", "done foo", "start bar", "done bar", "start baz", "done baz"}, rt.events)
+}
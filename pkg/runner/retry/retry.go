@@ -0,0 +1,99 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// MaxBackoff is the maximum delay between two attempts.
+const MaxBackoff = 5 * time.Minute
+
+// Policy returns the retry policy of a test, the test policy overrides the configuration one.
+func Policy(config *v1alpha1.RetryPolicy, test *v1alpha1.RetryPolicy) *v1alpha1.RetryPolicy {
+	if test != nil {
+		return test
+	}
+	return config
+}
+
+// MaxAttempts returns the maximum number of times a test runs, tests run once without a policy.
+func MaxAttempts(policy *v1alpha1.RetryPolicy) int {
+	if policy == nil || policy.MaxAttempts < 1 {
+		return 1
+	}
+	return policy.MaxAttempts
+}
+
+// Backoff returns the delay before the given retry (starting at 1), the delay doubles for every retry
+// and is capped at MaxBackoff.
+func Backoff(policy *v1alpha1.RetryPolicy, retry int) time.Duration {
+	if policy == nil || policy.Backoff == nil || retry < 1 {
+		return 0
+	}
+	backoff := policy.Backoff.Duration
+	for i := 1; i < retry && backoff < MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, MaxBackoff)
+}
+
+// Classify returns the error classes an error belongs to.
+func Classify(err error) []v1alpha1.RetryOn {
+	var out []v1alpha1.RetryOn
+	if isTimeout(err) {
+		out = append(out, v1alpha1.RetryOnTimeout)
+	}
+	if kerrors.IsConflict(err) {
+		out = append(out, v1alpha1.RetryOnConflict)
+	}
+	if isUnavailable(err) {
+		out = append(out, v1alpha1.RetryOnUnavailable)
+	}
+	return out
+}
+
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || kerrors.IsTimeout(err) || kerrors.IsServerTimeout(err) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func isUnavailable(err error) bool {
+	if kerrors.IsServiceUnavailable(err) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// ShouldRetry returns true when a failure that recorded the given errors can be retried.
+// Any failure can be retried if the policy doesn't restrict error classes.
+func ShouldRetry(policy *v1alpha1.RetryPolicy, errs ...error) bool {
+	if policy == nil {
+		return false
+	}
+	if len(policy.On) == 0 {
+		return true
+	}
+	for _, err := range errs {
+		for _, on := range Classify(err) {
+			if slices.Contains(policy.On, on) {
+				return true
+			}
+		}
+	}
+	return false
+}
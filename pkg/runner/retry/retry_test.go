@@ -0,0 +1,141 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/multierr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestPolicy(t *testing.T) {
+	config := &v1alpha1.RetryPolicy{MaxAttempts: 2}
+	test := &v1alpha1.RetryPolicy{MaxAttempts: 3}
+	assert.Nil(t, Policy(nil, nil))
+	assert.Equal(t, config, Policy(config, nil))
+	assert.Equal(t, test, Policy(config, test))
+	assert.Equal(t, test, Policy(nil, test))
+}
+
+func TestMaxAttempts(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *v1alpha1.RetryPolicy
+		want   int
+	}{{
+		name: "nil",
+		want: 1,
+	}, {
+		name:   "not set",
+		policy: &v1alpha1.RetryPolicy{},
+		want:   1,
+	}, {
+		name:   "set",
+		policy: &v1alpha1.RetryPolicy{MaxAttempts: 3},
+		want:   3,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MaxAttempts(tt.policy))
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := &v1alpha1.RetryPolicy{Backoff: &metav1.Duration{Duration: time.Second}}
+	assert.Equal(t, time.Duration(0), Backoff(nil, 1))
+	assert.Equal(t, time.Duration(0), Backoff(&v1alpha1.RetryPolicy{}, 1))
+	assert.Equal(t, time.Second, Backoff(policy, 1))
+	assert.Equal(t, 2*time.Second, Backoff(policy, 2))
+	assert.Equal(t, 4*time.Second, Backoff(policy, 3))
+	assert.Equal(t, MaxBackoff, Backoff(policy, 10))
+	assert.Equal(t, MaxBackoff, Backoff(policy, 100))
+	assert.Equal(t, MaxBackoff, Backoff(&v1alpha1.RetryPolicy{Backoff: &metav1.Duration{Duration: time.Hour}}, 1))
+}
+
+func TestClassify(t *testing.T) {
+	conflict := kerrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "foo", errors.New("the object has been modified"))
+	tests := []struct {
+		name string
+		err  error
+		want []v1alpha1.RetryOn
+	}{{
+		name: "deadline exceeded",
+		err:  fmt.Errorf("wait: %w", context.DeadlineExceeded),
+		want: []v1alpha1.RetryOn{v1alpha1.RetryOnTimeout},
+	}, {
+		name: "server timeout",
+		err:  kerrors.NewTimeoutError("timeout", 1),
+		want: []v1alpha1.RetryOn{v1alpha1.RetryOnTimeout},
+	}, {
+		name: "conflict",
+		err:  multierr.Combine(errors.New("other"), conflict),
+		want: []v1alpha1.RetryOn{v1alpha1.RetryOnConflict},
+	}, {
+		name: "service unavailable",
+		err:  kerrors.NewServiceUnavailable("unavailable"),
+		want: []v1alpha1.RetryOn{v1alpha1.RetryOnUnavailable},
+	}, {
+		name: "connection refused",
+		err:  &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+		want: []v1alpha1.RetryOn{v1alpha1.RetryOnUnavailable},
+	}, {
+		name: "no such host",
+		err:  &net.DNSError{Err: "no such host", Name: "foo", IsNotFound: true},
+		want: []v1alpha1.RetryOn{v1alpha1.RetryOnUnavailable},
+	}, {
+		name: "message only",
+		err:  errors.New("context deadline exceeded: conflict: connection refused"),
+	}, {
+		name: "assertion",
+		err:  errors.New("spec.replicas: Invalid value: 2: Expected value: 3"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Classify(tt.err))
+		})
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *v1alpha1.RetryPolicy
+		errs   []error
+		want   bool
+	}{{
+		name: "no policy",
+		errs: []error{context.DeadlineExceeded},
+		want: false,
+	}, {
+		name:   "any failure",
+		policy: &v1alpha1.RetryPolicy{MaxAttempts: 2},
+		errs:   []error{errors.New("spec.replicas: Expected value: 3")},
+		want:   true,
+	}, {
+		name:   "matching class",
+		policy: &v1alpha1.RetryPolicy{MaxAttempts: 2, On: []v1alpha1.RetryOn{v1alpha1.RetryOnTimeout}},
+		errs:   []error{errors.New("spec.replicas: Expected value: 3"), context.DeadlineExceeded},
+		want:   true,
+	}, {
+		name:   "other class",
+		policy: &v1alpha1.RetryPolicy{MaxAttempts: 2, On: []v1alpha1.RetryOn{v1alpha1.RetryOnConflict}},
+		errs:   []error{context.DeadlineExceeded},
+		want:   false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ShouldRetry(tt.policy, tt.errs...))
+		})
+	}
+}
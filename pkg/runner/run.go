@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/kyverno/chainsaw/pkg/cleanup/journal"
 	"github.com/kyverno/chainsaw/pkg/discovery"
 	"github.com/kyverno/chainsaw/pkg/engine"
+	engineclient "github.com/kyverno/chainsaw/pkg/engine/client"
 	"github.com/kyverno/chainsaw/pkg/engine/clusters"
 	enginecontext "github.com/kyverno/chainsaw/pkg/engine/context"
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
@@ -43,19 +45,39 @@ func run(
 	tests ...discovery.Test,
 ) (model.SummaryResult, error) {
 	var testsReport *report.Report
-	if config.Report != nil && config.Report.Format != "" {
+	if config.Report != nil && (config.Report.Format != "" || config.Report.Events != "") {
 		testsReport = report.New(config.Report.Name)
 	}
 	tc, err := setupTestContext(ctx, values, cfg, config)
 	if err != nil {
 		return nil, err
 	}
+	defer tc.DataClusters().Close()
+	ctx = engineclient.IntoContext(ctx, tc.DataClusters())
 	if len(tests) == 0 {
 		return tc, nil
 	}
 	if err := internal.SetupFlags(config); err != nil {
 		return nil, err
 	}
+	var events *report.FileSink
+	if testsReport != nil && config.Report.Events != "" {
+		sink, err := report.NewFileSink(config.Report.Events)
+		if err != nil {
+			return nil, err
+		}
+		testsReport.SetEventSink(sink)
+		events = sink
+	}
+	var cleanupJournal *journal.File
+	if config.Cleanup.Journal != "" {
+		file, err := journal.Open(config.Cleanup.Journal)
+		if err != nil {
+			return nil, err
+		}
+		ctx = journal.IntoContext(ctx, file)
+		cleanupJournal = file
+	}
 	internalTests := []testing.InternalTest{{
 		Name: "chainsaw",
 		F: func(t *testing.T) {
@@ -77,7 +99,18 @@ func run(
 	// - 2 if running the tests was not possible
 	// In our case, we consider an error only when running the tests was not possible.
 	// For now, the case where some of the tests failed will be covered by the summary.
-	if code := m.Run(); code > 1 {
+	code := m.Run()
+	if cleanupJournal != nil {
+		if err := cleanupJournal.Close(); err != nil {
+			return tc.Summary, fmt.Errorf("failed to write cleanup journal: %w", err)
+		}
+	}
+	if events != nil {
+		if err := events.Close(); err != nil {
+			return tc.Summary, fmt.Errorf("failed to write report events: %w", err)
+		}
+	}
+	if code > 1 {
 		return tc.Summary, fmt.Errorf("testing framework exited with non zero code %d", code)
 	}
 	if testsReport != nil && config.Report != nil && config.Report.Format != "" {
@@ -0,0 +1,106 @@
+package ndjson
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Appender appends JSON documents to a file, one document per line.
+// Every document is written immediately so that the file survives an interrupted run.
+type Appender struct {
+	file *os.File
+	lock sync.Mutex
+	err  error
+}
+
+// Open opens the file at path for appending, missing parent directories are created.
+// The existing content is discarded when truncate is true.
+func Open(path string, truncate bool) (*Appender, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	flag := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if truncate {
+		flag |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(filepath.Clean(path), flag, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &Appender{file: file}, nil
+}
+
+// Append writes a document, nothing is written after an error.
+func (a *Appender) Append(document any) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.err != nil {
+		return
+	}
+	data, err := json.Marshal(document)
+	if err != nil {
+		a.err = err
+		return
+	}
+	if _, err := a.file.Write(append(data, '\n')); err != nil {
+		a.err = err
+	}
+}
+
+// Close closes the file and returns the first error encountered while appending documents.
+func (a *Appender) Close() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if err := a.file.Close(); err != nil && a.err == nil {
+		a.err = err
+	}
+	return a.err
+}
+
+// LineError is returned when a line cannot be read.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// Read decodes the documents of r in order and calls fn with each of them, empty lines are skipped.
+// A truncated last line (interrupted write) is ignored.
+func Read[T any](r io.Reader, fn func(T) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	var pending error
+	line := 0
+	for scanner.Scan() {
+		line++
+		if pending != nil {
+			return pending
+		}
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var document T
+		if err := json.Unmarshal(scanner.Bytes(), &document); err != nil {
+			pending = &LineError{Line: line, Err: err}
+			continue
+		}
+		if err := fn(document); err != nil {
+			return &LineError{Line: line, Err: err}
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,93 @@
+package ndjson
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type document struct {
+	Name string `json:"name"`
+}
+
+func TestAppender(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir", "file.ndjson")
+	appender, err := Open(path, false)
+	assert.NoError(t, err)
+	appender.Append(document{Name: "a"})
+	// documents are persisted as soon as they are appended
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"name\":\"a\"}\n", string(data))
+	assert.NoError(t, appender.Close())
+	appender, err = Open(path, false)
+	assert.NoError(t, err)
+	appender.Append(document{Name: "b"})
+	assert.NoError(t, appender.Close())
+	data, err = os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"name\":\"a\"}\n{\"name\":\"b\"}\n", string(data))
+	appender, err = Open(path, true)
+	assert.NoError(t, err)
+	appender.Append(document{Name: "c"})
+	// documents that can't be marshalled fail the appender
+	appender.Append(func() {})
+	appender.Append(document{Name: "d"})
+	assert.Error(t, appender.Close())
+	data, err = os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"name\":\"c\"}\n", string(data))
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		want     []string
+		wantLine int
+	}{{
+		name: "empty",
+	}, {
+		name: "documents",
+		in:   "{\"name\":\"a\"}\n\n{\"name\":\"b\"}\n",
+		want: []string{"a", "b"},
+	}, {
+		name: "truncated last line",
+		in:   "{\"name\":\"a\"}\n{\"na",
+		want: []string{"a"},
+	}, {
+		name:     "invalid line",
+		in:       "{\"name\":\"a\"}\n{\"name\":\n{\"name\":\"b\"}\n",
+		want:     []string{"a"},
+		wantLine: 2,
+	}, {
+		name:     "callback error",
+		in:       "{\"name\":\"a\"}\n{\"name\":\"invalid\"}\n{\"name\":\"b\"}\n",
+		want:     []string{"a"},
+		wantLine: 2,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := Read(strings.NewReader(tt.in), func(document document) error {
+				if document.Name == "invalid" {
+					return errors.New("invalid name")
+				}
+				got = append(got, document.Name)
+				return nil
+			})
+			if tt.wantLine != 0 {
+				var lineErr *LineError
+				assert.ErrorAs(t, err, &lineErr)
+				assert.Equal(t, tt.wantLine, lineErr.Line)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}